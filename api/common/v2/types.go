@@ -0,0 +1,355 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentTemplate is the specification of pods shared by all components of DorisCluster and DorisDisaggregatedCluster.
+type ComponentTemplate struct {
+	// Replicas represent the number of desired Pod.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Image is the doris docker image to deploy.
+	Image string `json:"image,omitempty"`
+
+	// ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images used by pods.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// StartTimeout is the timeout of pod starting, unit is second.
+	StartTimeout int32 `json:"startTimeout,omitempty"`
+
+	// LiveTimeout is the number of seconds after which the liveness probe times out.
+	LiveTimeout int32 `json:"liveTimeout,omitempty"`
+
+	// ReadinessProbePolicy defines the timing policy for readiness probe.
+	// +optional
+	ReadinessProbePolicy *ReadinessProbePolicy `json:"readinessProbePolicy,omitempty"`
+
+	// defines the specification of resource cpu and mem. ep: {"requests":{"cpu": 4, "memory": "8Gi"},"limits":{"cpu":4,"memory":"8Gi"}}
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Labels are added to the pods for user selecting or classifying.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Affinity is a group of affinity scheduling rules.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations for scheduling pods onto some dedicated nodes.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeSelector specifies the nodes that pods deployed on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// ServiceAccount is the service account of pods.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// HostAliases is an optional list of hosts and IPs that will be injected into the pod's hosts file.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// SecurityContext is the security context of pod.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext is the security context of all containers running in the pod.
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// EnvVars is a slice of environment variables that are added to the pods.
+	// +optional
+	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
+
+	// SystemInitialization sets the system parameters of nodes for doris.
+	// +optional
+	SystemInitialization *SystemInitialization `json:"systemInitialization,omitempty"`
+
+	// Secrets are mounted in the pods.
+	// +optional
+	Secrets []Secret `json:"secrets,omitempty"`
+
+	// ConfigMaps are mounted in the pods, the configmap without mountPath contains the config file of component, eg: fe.conf, be.conf.
+	// +optional
+	ConfigMaps []ConfigMap `json:"configMaps,omitempty"`
+
+	// PersistentVolumes are the templates of pvcs mounted in the pods.
+	// +optional
+	PersistentVolumes []PersistentVolume `json:"persistentVolumes,omitempty"`
+
+	// PersistentVolumeClaimRetentionPolicy describes whether the pvcs are deleted when scaling down the component or deleting the cluster.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *PersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// Service exports the component for accessing from outside kubernetes.
+	// +optional
+	Service *ExportService `json:"service,omitempty"`
+}
+
+// ReadinessProbePolicy defines the timing policy for readiness probe.
+type ReadinessProbePolicy struct {
+	// Number of seconds after which the readiness probe times out.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Minimum consecutive failures for the readiness probe to be considered failed.
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// How often (in seconds) to perform the readiness probe.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+}
+
+// SystemInitialization describes the init container that sets system parameters.
+type SystemInitialization struct {
+	// InitImage is the image of init container.
+	InitImage string `json:"initImage,omitempty"`
+
+	// Entrypoint array. Not executed within a shell.
+	Command []string `json:"command,omitempty"`
+
+	// Arguments to the entrypoint.
+	Args []string `json:"args,omitempty"`
+
+	// defines the specification of resource cpu and mem for init container.
+	corev1.ResourceRequirements `json:",inline"`
+}
+
+// Secret describes the secret mounted in pods.
+type Secret struct {
+	SecretName string `json:"secretName,omitempty"`
+	MountPath  string `json:"mountPath,omitempty"`
+}
+
+// ConfigMap describes the configmap mounted in pods.
+type ConfigMap struct {
+	Name string `json:"name,omitempty"`
+	// MountPath is the path that configmap mounted, empty represents the config directory of component.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// PersistentVolume defines the template of pvc and the paths it mounted.
+type PersistentVolume struct {
+	// Name is the name of volume, the name of pvc is generated from it.
+	Name string `json:"name,omitempty"`
+
+	// PersistentVolumeClaimSpec is the claim spec about storage that pods are required.
+	// +kubebuilder:validation:Optional
+	corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaimSpec,omitempty"`
+
+	// MountPaths are the paths that volume mounted, DorisCluster supports one mount path for a volume.
+	MountPaths []string `json:"mountPaths,omitempty"`
+
+	// Annotations for pvc. It only takes effect in the first configuration and cannot be added or modified later.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Provisioner is the creator of pvc.
+	// +kubebuilder:validation:Enum=StatefulSet;Operator
+	Provisioner PVCProvisioner `json:"provisioner,omitempty"`
+
+	// StorageMedium is the medium of storage path in be.conf, only takes effect on be of DorisCluster.
+	// +kubebuilder:validation:Enum=SSD;HDD
+	StorageMedium string `json:"storageMedium,omitempty"`
+
+	// SpillStorage renders the volume as spill_storage_root_path of be, only takes effect on be of DorisCluster.
+	SpillStorage bool `json:"spillStorage,omitempty"`
+
+	// AutoExpansion expands the storage request of pvc automatically when the disk usage crosses the threshold.
+	AutoExpansion *PVCAutoExpansion `json:"autoExpansion,omitempty"`
+}
+
+// PVCProvisioner is the creator of pvc.
+type PVCProvisioner string
+
+const (
+	PVCProvisionerStatefulSet PVCProvisioner = "StatefulSet"
+	PVCProvisionerOperator    PVCProvisioner = "Operator"
+)
+
+// PVCAutoExpansion defines the policy of growing the storage request of pvc in steps when the disk usage crosses the threshold.
+type PVCAutoExpansion struct {
+	// UsedPercentThreshold is the disk used percent that triggers an expansion, default is 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	UsedPercentThreshold int32 `json:"usedPercentThreshold,omitempty"`
+
+	// Step is the storage size added to the pvc in one expansion, eg: 50Gi.
+	Step resource.Quantity `json:"step"`
+
+	// MaxCapacity is the ceiling of storage size that the pvc can be expanded to.
+	MaxCapacity resource.Quantity `json:"maxCapacity"`
+}
+
+// PersistentVolumeClaimRetentionPolicy describes the lifecycle of pvcs of the component.
+type PersistentVolumeClaimRetentionPolicy struct {
+	// WhenScaled specifies what happens to the pvcs of pods removed by scaling down, the default depends on the component.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	WhenScaled string `json:"whenScaled,omitempty"`
+
+	// WhenDeleted specifies what happens to the pvcs when the cluster is deleted, default is `Retain`.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	WhenDeleted string `json:"whenDeleted,omitempty"`
+}
+
+// ExportService describes the service for accessing component from outside kubernetes.
+type ExportService struct {
+	// Type of service, ClusterIP, NodePort, LoadBalancer or ExternalName.
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations of service for using function on different cloud platform.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PortMaps specify node port for target port in pod, when the service type=NodePort.
+	PortMaps []PortMap `json:"portMaps,omitempty"`
+
+	// LoadBalancerIP is the ip of load balancer, only takes effect on DorisCluster.
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+}
+
+// PortMap maps the node port to the target port in pod.
+type PortMap struct {
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// +optional
+	TargetPort int32 `json:"targetPort,omitempty"`
+}
+
+// KerberosInfo contains the files for accessing kerberos.
+type KerberosInfo struct {
+	// Krb5ConfigMap is the configmap that contains `krb5.conf`.
+	Krb5ConfigMap string `json:"krb5ConfigMap,omitempty"`
+
+	// KeytabSecretName is the secret that contains the keytab files.
+	KeytabSecretName string `json:"keytabSecretName,omitempty"`
+
+	// KeytabPath is the path that keytab files mounted.
+	KeytabPath string `json:"keytabPath,omitempty"`
+}
+
+// TLS specifies the certificates issued by operator for the components.
+type TLS struct {
+	// Enabled enables operator issuing certificates.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CertificateValidity is the validity of issued certificates.
+	CertificateValidity *metav1.Duration `json:"certificateValidity,omitempty"`
+
+	// RenewBefore is how long before expiry the certificates are renewed.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// IssuerRef specifies the cert-manager issuer, the certificates are issued by operator when nil.
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// CertManagerIssuerRef references the cert-manager issuer.
+type CertManagerIssuerRef struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// PasswordRotation describes the rotation of the password of the management user.
+type PasswordRotation struct {
+	// Interval is the period of rotating the password by operator, e.g. 720h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// QueryProbe describes the synthetic query that operator runs for checking the queries really work.
+type QueryProbe struct {
+	// Enabled enables operator probing by the query.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Query is the sql executed by probing, default `SELECT 1`.
+	Query string `json:"query,omitempty"`
+
+	// Interval is the period of probing, default 1m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout of a probe, default 5s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes for regarding the target as unavailable, default 3.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// Monitoring describes the Prometheus Operator objects created by operator for scraping the metrics of components.
+type Monitoring struct {
+	// Enabled enables operator creating a `ServiceMonitor` or `PodMonitor` for every component.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Kind of the monitor objects, `ServiceMonitor` or `PodMonitor`. default `ServiceMonitor`.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	Kind string `json:"kind,omitempty"`
+
+	// Interval at which metrics should be scraped.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout is the timeout of scraping.
+	ScrapeTimeout *metav1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// Labels are added on the monitor objects for the selector of Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Rules makes operator create a `PrometheusRule` with the curated alerts of the cluster.
+	Rules *MonitoringRules `json:"rules,omitempty"`
+}
+
+// MonitoringRules describes the `PrometheusRule` with the alerts of the cluster.
+type MonitoringRules struct {
+	// Enabled enables operator creating a `PrometheusRule` for the cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are added on the PrometheusRule for the `ruleSelector` of Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// For is how long the condition must be true before the alert fires, default 5m.
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// DiskUsagePercentThreshold is the used percent of disks that fires the alert, default 85.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	DiskUsagePercentThreshold *int32 `json:"diskUsagePercentThreshold,omitempty"`
+
+	// CompactionScoreThreshold is the max compaction score of tablets that fires the alert, default 100.
+	// +kubebuilder:validation:Minimum=1
+	CompactionScoreThreshold *int32 `json:"compactionScoreThreshold,omitempty"`
+
+	// DecommissionTimeout is how long backends in decommissioning before the alert fires, default 2h.
+	DecommissionTimeout *metav1.Duration `json:"decommissionTimeout,omitempty"`
+}
@@ -20,16 +20,25 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
-	"strings"
 )
 
+// RejectPlaintextAdminPassword rejects the new plaintext `adminUser.password`, the credentials of management user should be configured by `authSecret`.
+var RejectPlaintextAdminPassword bool
+
+// ConfigValidator validates the configs in the configmaps referenced by the cluster, the errors reject the request and the warnings are returned to user.
+// it is registered by controller when the webhook enabled, as the configmaps should be fetched from kubernetes.
+var ConfigValidator func(ctx context.Context, cluster *DorisDisaggregatedCluster) ([]string, []error)
+
 // log is for logging in this package.
 func (ddc *DorisDisaggregatedCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -61,11 +70,15 @@ func (ddc *DorisDisaggregatedCluster) ValidateCreate(ctx context.Context, obj ru
 	}
 	klog.Info("validate create", "name", cluster.Name)
 
-	if errs := cluster.validate(); len(errs) != 0 {
-		return nil, kerrors.NewAggregate(errs)
+	errs := cluster.validate()
+	errs = append(errs, cluster.validateAdminUserPassword(nil)...)
+	warnings, cerrs := cluster.validateConfigs(ctx)
+	errs = append(errs, cerrs...)
+	if len(errs) != 0 {
+		return warnings, kerrors.NewAggregate(errs)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a unnamedwatches will be registered for the type
@@ -74,13 +87,24 @@ func (ddc *DorisDisaggregatedCluster) ValidateUpdate(ctx context.Context, oldObj
 	if !ok {
 		return nil, fmt.Errorf("expected a DorisDisaggregatedCluster but got %T", newObj)
 	}
+	old, ok := oldObj.(*DorisDisaggregatedCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a DorisDisaggregatedCluster but got %T", oldObj)
+	}
 	klog.Info("validate update", "name", cluster.Name)
 
-	if errs := cluster.validate(); len(errs) != 0 {
-		return nil, kerrors.NewAggregate(errs)
+	errs := cluster.validate()
+	errs = append(errs, cluster.validateAdminUserPassword(old)...)
+	warnings, uerrs := cluster.validateUpdate(old)
+	errs = append(errs, uerrs...)
+	cwarnings, cerrs := cluster.validateConfigs(ctx)
+	warnings = append(warnings, cwarnings...)
+	errs = append(errs, cerrs...)
+	if len(errs) != 0 {
+		return warnings, kerrors.NewAggregate(errs)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a unnamedwatches will be registered for the type
@@ -97,6 +121,36 @@ func (ddc *DorisDisaggregatedCluster) validate() []error {
 	if err := ddc.validateFEReplicas(); err != nil {
 		errs = append(errs, err)
 	}
+	errs = append(errs, ddc.validateTLS()...)
+	errs = append(errs, ddc.validateQueryProbe()...)
+	return errs
+}
+
+// validateQueryProbe rejects the query of probing that is not a single SELECT statement, as the query runs with the credentials of management user.
+func (ddc *DorisDisaggregatedCluster) validateQueryProbe() []error {
+	if ddc.Spec.QueryProbe == nil || ddc.Spec.QueryProbe.Query == "" || doris.IsSelectQuery(ddc.Spec.QueryProbe.Query) {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'queryProbe.query' error: only a single SELECT statement is allowed")}
+}
+
+// validateTLS rejects the tls enabled cluster that the fe or a compute group has no configmaps, as the tls configs are rendered
+// into the configmap that has fe.conf or be.conf by operator.
+func (ddc *DorisDisaggregatedCluster) validateTLS() []error {
+	if !ddc.IsTLSEnabled() {
+		return nil
+	}
+
+	var errs []error
+	if len(ddc.Spec.FeSpec.ConfigMaps) == 0 {
+		errs = append(errs, fmt.Errorf("'feSpec.configMaps' error: tls enabled requires the configmap of fe.conf"))
+	}
+	for i := range ddc.Spec.ComputeGroups {
+		if len(ddc.Spec.ComputeGroups[i].ConfigMaps) == 0 {
+			errs = append(errs, fmt.Errorf("'computeGroups[%d].configMaps' error: tls enabled requires the configmap of be.conf", i))
+		}
+	}
 	return errs
 }
 
@@ -117,3 +171,144 @@ func (ddc *DorisDisaggregatedCluster) validateFEReplicas() error {
 	}
 	return nil
 }
+
+// validateAdminUserPassword rejects the new plaintext `adminUser.password` when RejectPlaintextAdminPassword enabled.
+// the unchanged password of existing cluster is allowed, it will be moved into `authSecret` by operator.
+func (ddc *DorisDisaggregatedCluster) validateAdminUserPassword(old *DorisDisaggregatedCluster) []error {
+	if !RejectPlaintextAdminPassword || ddc.Spec.AdminUser == nil || ddc.Spec.AdminUser.Password == "" {
+		return nil
+	}
+	if old != nil && old.Spec.AdminUser != nil && old.Spec.AdminUser.Password == ddc.Spec.AdminUser.Password {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'adminUser.password' error: plaintext password is not allowed, use authSecret with a kubernetes.io/basic-auth secret")}
+}
+
+// validateConfigs validates the configs of components by ConfigValidator, skipped when the validator not registered.
+func (ddc *DorisDisaggregatedCluster) validateConfigs(ctx context.Context) (admission.Warnings, []error) {
+	if ConfigValidator == nil {
+		return nil, nil
+	}
+
+	warnings, errs := ConfigValidator(ctx, ddc)
+	return warnings, errs
+}
+
+// validateUpdate checks the changes from old cluster that can not be applied safely, the destructive but allowed changes are returned as warnings.
+func (ddc *DorisDisaggregatedCluster) validateUpdate(old *DorisDisaggregatedCluster) (admission.Warnings, []error) {
+	var warnings admission.Warnings
+	var errs []error
+	errs = append(errs, ddc.validateComputeGroupUniqueIds(old)...)
+	errs = append(errs, ddc.validateElectionNumberUpdate(old)...)
+	errs = append(errs, ddc.validateFDBUpdate(old)...)
+
+	errs = append(errs, validatePersistentVolumesUpdate("metaService", &old.Spec.MetaService.CommonSpec, &ddc.Spec.MetaService.CommonSpec)...)
+	errs = append(errs, validatePersistentVolumesUpdate("feSpec", &old.Spec.FeSpec.CommonSpec, &ddc.Spec.FeSpec.CommonSpec)...)
+	newCGs := map[string]*ComputeGroup{}
+	for i := range ddc.Spec.ComputeGroups {
+		newCGs[ddc.Spec.ComputeGroups[i].UniqueId] = &ddc.Spec.ComputeGroups[i]
+	}
+	for i := range old.Spec.ComputeGroups {
+		ocg := &old.Spec.ComputeGroups[i]
+		cg, ok := newCGs[ocg.UniqueId]
+		if !ok {
+			if ocg.PersistentVolume != nil || len(ocg.PersistentVolumes) != 0 {
+				warnings = append(warnings, fmt.Sprintf("compute group %s is removed, the pvcs of it will be deleted or retained according to persistentVolumeClaimRetentionPolicy", ocg.UniqueId))
+			}
+			continue
+		}
+		errs = append(errs, validatePersistentVolumesUpdate("compute group "+cg.UniqueId, &ocg.CommonSpec, &cg.CommonSpec)...)
+	}
+
+	if len(old.Spec.ComputeGroups) != 0 && len(ddc.Spec.ComputeGroups) == 0 && old.hasData() {
+		errs = append(errs, fmt.Errorf("'computeGroups' error: the last compute group can not be removed, the cluster has data that can not be queried without compute group"))
+	}
+	return warnings, errs
+}
+
+// validateComputeGroupUniqueIds rejects the compute group that replaced the uniqueId of the old one at the same position, the uniqueId is
+// the name of compute group in doris and the name of its statefulset, it can not be renamed.
+func (ddc *DorisDisaggregatedCluster) validateComputeGroupUniqueIds(old *DorisDisaggregatedCluster) []error {
+	oldIds := map[string]bool{}
+	for _, cg := range old.Spec.ComputeGroups {
+		oldIds[cg.UniqueId] = true
+	}
+	newIds := map[string]bool{}
+	for _, cg := range ddc.Spec.ComputeGroups {
+		newIds[cg.UniqueId] = true
+	}
+
+	var errs []error
+	for i := 0; i < len(old.Spec.ComputeGroups) && i < len(ddc.Spec.ComputeGroups); i++ {
+		oldId, newId := old.Spec.ComputeGroups[i].UniqueId, ddc.Spec.ComputeGroups[i].UniqueId
+		if oldId != newId && !newIds[oldId] && !oldIds[newId] {
+			errs = append(errs, fmt.Errorf("'computeGroups[%d].uniqueId' error: uniqueId can not be changed from %s to %s, remove the compute group and add a new one in separate updates", i, oldId, newId))
+		}
+	}
+	return errs
+}
+
+// validateElectionNumberUpdate rejects changing the number of fe in election after fe deployed, the followers and observers are decided when they registered.
+func (ddc *DorisDisaggregatedCluster) validateElectionNumberUpdate(old *DorisDisaggregatedCluster) []error {
+	if old.Status.FEStatus.Phase == "" || old.GetElectionNumber() == ddc.GetElectionNumber() {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'FeSpec.ElectionNumber' error: electionNumber can not be changed from %d to %d after fe deployed", old.GetElectionNumber(), ddc.GetElectionNumber())}
+}
+
+// validateFDBUpdate rejects pointing meta service to another fdb, the metadata of cluster is stored in the configured fdb.
+func (ddc *DorisDisaggregatedCluster) validateFDBUpdate(old *DorisDisaggregatedCluster) []error {
+	oldFDB, fdb := old.Spec.MetaService.FDB, ddc.Spec.MetaService.FDB
+	if oldFDB == (FDB{}) || oldFDB == fdb {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'metaService.fdb' error: fdb can not be changed after cluster created, the metadata of cluster is stored in it")}
+}
+
+// hasData returns true when the cluster has served, the fe has been available or compute groups have registered in doris.
+func (ddc *DorisDisaggregatedCluster) hasData() bool {
+	if ddc.Status.FEStatus.AvailableStatus == Available {
+		return true
+	}
+	for _, cgs := range ddc.Status.ComputeGroupStatuses {
+		if cgs.ComputeGroupId != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePersistentVolumesUpdate rejects shrinking the storage of pvc, the persistent volumes are matched by mount paths.
+func validatePersistentVolumesUpdate(scope string, old, cs *CommonSpec) []error {
+	storages := func(spec *CommonSpec) map[string]PersistentVolume {
+		res := map[string]PersistentVolume{}
+		if spec.PersistentVolume != nil {
+			res[strings.Join(spec.PersistentVolume.MountPaths, ",")] = *spec.PersistentVolume
+		}
+		for _, pv := range spec.PersistentVolumes {
+			res[strings.Join(pv.MountPaths, ",")] = pv
+		}
+		return res
+	}
+
+	var errs []error
+	newPVs := storages(cs)
+	for mountPaths, opv := range storages(old) {
+		pv, ok := newPVs[mountPaths]
+		if !ok {
+			continue
+		}
+		oldSize, ook := opv.Resources.Requests[corev1.ResourceStorage]
+		size, nok := pv.Resources.Requests[corev1.ResourceStorage]
+		if ook && nok && size.Cmp(oldSize) < 0 {
+			if mountPaths == "" {
+				mountPaths = "default mount paths"
+			}
+			errs = append(errs, fmt.Errorf("'persistentVolumes' of %s error: the storage of %s can not be shrunk from %s to %s", scope, mountPaths, oldSize.String(), size.String()))
+		}
+	}
+	return errs
+}
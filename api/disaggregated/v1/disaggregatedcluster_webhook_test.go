@@ -20,6 +20,9 @@ package v1
 import (
 	"context"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestDorisDisaggregatedClusterRejectsAdminManagementUser(t *testing.T) {
@@ -86,3 +89,135 @@ func TestDorisDisaggregatedClusterValidateFEReplicas(t *testing.T) {
 		t.Fatalf("expected valid update to pass, got %v", err)
 	}
 }
+
+func TestDorisDisaggregatedClusterValidateTLS(t *testing.T) {
+	validator := &DorisDisaggregatedCluster{}
+	ddc := &DorisDisaggregatedCluster{
+		Spec: DorisDisaggregatedClusterSpec{
+			TLS:           &TLS{Enabled: true},
+			ComputeGroups: []ComputeGroup{{UniqueId: "cg1"}},
+		},
+	}
+	if _, err := validator.ValidateCreate(context.Background(), ddc); err == nil {
+		t.Fatal("expected tls enabled without configmaps to be rejected on create")
+	}
+
+	ddc.Spec.FeSpec.ConfigMaps = []ConfigMap{{Name: "fe-conf"}}
+	if _, err := validator.ValidateUpdate(context.Background(), ddc, ddc); err == nil {
+		t.Fatal("expected tls enabled without configmaps of compute group to be rejected on update")
+	}
+
+	ddc.Spec.ComputeGroups[0].ConfigMaps = []ConfigMap{{Name: "be-conf"}}
+	if _, err := validator.ValidateCreate(context.Background(), ddc); err != nil {
+		t.Fatalf("expected tls enabled with configmaps to pass, got %v", err)
+	}
+}
+
+func TestDorisDisaggregatedClusterRejectsPlaintextAdminPassword(t *testing.T) {
+	validator := &DorisDisaggregatedCluster{}
+	old := &DorisDisaggregatedCluster{
+		Spec: DorisDisaggregatedClusterSpec{
+			AdminUser: &AdminUser{Name: "root", Password: "old"},
+		},
+	}
+	if _, err := validator.ValidateCreate(context.Background(), old); err != nil {
+		t.Fatalf("expected plaintext password to be allowed when the switch disabled: %v", err)
+	}
+
+	RejectPlaintextAdminPassword = true
+	defer func() { RejectPlaintextAdminPassword = false }()
+	if _, err := validator.ValidateCreate(context.Background(), old); err == nil {
+		t.Fatal("expected plaintext password to be rejected on create")
+	}
+	if _, err := validator.ValidateUpdate(context.Background(), old, old); err != nil {
+		t.Fatalf("expected unchanged plaintext password to be allowed on update: %v", err)
+	}
+
+	ddc := old.DeepCopy()
+	ddc.Spec.AdminUser.Password = "new"
+	if _, err := validator.ValidateUpdate(context.Background(), old, ddc); err == nil {
+		t.Fatal("expected changed plaintext password to be rejected on update")
+	}
+}
+
+func TestDorisDisaggregatedClusterValidateConfigs(t *testing.T) {
+	ConfigValidator = func(ctx context.Context, cluster *DorisDisaggregatedCluster) ([]string, []error) {
+		return []string{"unknown config"}, nil
+	}
+	defer func() { ConfigValidator = nil }()
+
+	validator := &DorisDisaggregatedCluster{}
+	cluster := &DorisDisaggregatedCluster{}
+	warnings, err := validator.ValidateCreate(context.Background(), cluster)
+	if err != nil || len(warnings) != 1 {
+		t.Fatalf("expected the config warnings returned on create, warnings=%v, err=%v", warnings, err)
+	}
+	warnings, err = validator.ValidateUpdate(context.Background(), cluster, cluster)
+	if err != nil || len(warnings) != 1 {
+		t.Fatalf("expected the config warnings returned on update, warnings=%v, err=%v", warnings, err)
+	}
+}
+
+func TestDorisDisaggregatedClusterValidateUpdate(t *testing.T) {
+	pvc := func(size string) []PersistentVolume {
+		return []PersistentVolume{{
+			MountPaths: []string{"/opt/apache-doris/be/file_cache"},
+			PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}},
+			},
+		}}
+	}
+	newCluster := func() *DorisDisaggregatedCluster {
+		return &DorisDisaggregatedCluster{
+			Spec: DorisDisaggregatedClusterSpec{
+				MetaService:   MetaService{FDB: FDB{Address: "fdb:fdb@10.0.0.1:4500"}},
+				ComputeGroups: []ComputeGroup{{UniqueId: "cg1", CommonSpec: CommonSpec{PersistentVolumes: pvc("100Gi")}}, {UniqueId: "cg2"}},
+			},
+			Status: DorisDisaggregatedClusterStatus{FEStatus: FEStatus{Phase: Ready, AvailableStatus: Available}},
+		}
+	}
+	old := newCluster()
+
+	tests := []struct {
+		name     string
+		mutate   func(ddc *DorisDisaggregatedCluster)
+		errs     int
+		warnings int
+	}{
+		{"unchanged", func(ddc *DorisDisaggregatedCluster) {}, 0, 0},
+		{"rename compute group", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.ComputeGroups[1].UniqueId = "cg3" }, 1, 0},
+		{"reorder compute groups", func(ddc *DorisDisaggregatedCluster) {
+			ddc.Spec.ComputeGroups[0], ddc.Spec.ComputeGroups[1] = ddc.Spec.ComputeGroups[1], ddc.Spec.ComputeGroups[0]
+		}, 0, 0},
+		{"shrink storage", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.ComputeGroups[0].PersistentVolumes = pvc("50Gi") }, 1, 0},
+		{"expand storage", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.ComputeGroups[0].PersistentVolumes = pvc("200Gi") }, 0, 0},
+		{"change election number", func(ddc *DorisDisaggregatedCluster) {
+			en := int32(5)
+			ddc.Spec.FeSpec.ElectionNumber = &en
+		}, 1, 0},
+		{"change fdb", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.MetaService.FDB.Address = "fdb:fdb@10.0.0.2:4500" }, 1, 0},
+		{"remove compute group with pvc", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.ComputeGroups = ddc.Spec.ComputeGroups[1:] }, 0, 1},
+		{"remove last compute group", func(ddc *DorisDisaggregatedCluster) { ddc.Spec.ComputeGroups = nil }, 1, 1},
+	}
+
+	for _, test := range tests {
+		ddc := newCluster()
+		test.mutate(ddc)
+		warnings, errs := ddc.validateUpdate(old)
+		if len(errs) != test.errs || len(warnings) != test.warnings {
+			t.Errorf("%s: expected %d errors and %d warnings, got errors=%v, warnings=%v", test.name, test.errs, test.warnings, errs, warnings)
+		}
+	}
+}
+
+func TestDorisDisaggregatedClusterValidateQueryProbe(t *testing.T) {
+	ddc := &DorisDisaggregatedCluster{Spec: DorisDisaggregatedClusterSpec{QueryProbe: &QueryProbe{Enabled: true, Query: "INSERT INTO db.t VALUES (1)"}}}
+	if errs := ddc.validateQueryProbe(); len(errs) != 1 {
+		t.Fatalf("expected the query not a single SELECT statement to be rejected, got %v", errs)
+	}
+
+	ddc.Spec.QueryProbe.Query = "SELECT 1;"
+	if errs := ddc.validateQueryProbe(); len(errs) != 0 {
+		t.Fatalf("expected the SELECT statement to pass, got %v", errs)
+	}
+}
@@ -36,6 +36,9 @@ const (
 
 	//use uniqueId as indifier of which statefulset updated. value is the ddc updateVersion
 	UpdateStatefulsetName = "doris.disaggregated.cluster/%s"
+
+	//cancel the decommission of compute groups in progress, the value is the uniqueIds of compute groups separated by comma.
+	CancelDecommissionAnnotation = "doris.disaggregated.cluster/cancel-decommission"
 )
 
 type DisaggregatedComponentType string
@@ -65,3 +68,14 @@ func (ddc *DorisDisaggregatedCluster) GetElectionNumber() int32 {
 	}
 	return DefaultDisFeElectionNumber
 }
+
+// IsTLSEnabled returns true when operator should issue certificates for the cluster.
+func (ddc *DorisDisaggregatedCluster) IsTLSEnabled() bool {
+	return ddc.Spec.TLS != nil && ddc.Spec.TLS.Enabled
+}
+
+// IsRestartWhenConfigChangeEnabled returns true when the pods should be restarted for the changed configmaps, default is false.
+// not enabled by default, as the hash annotation added to the pod template restarts all pods of the clusters deployed by the older operator.
+func (ddc *DorisDisaggregatedCluster) IsRestartWhenConfigChangeEnabled() bool {
+	return ddc.Spec.EnableRestartWhenConfigChange != nil && *ddc.Spec.EnableRestartWhenConfigChange
+}
@@ -0,0 +1,375 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	commonv2 "github.com/apache/doris-operator/api/common/v2"
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation stores the fields of v1 spec that v2 can not represent in the v2 object, eg: the deprecated fields.
+// the fields are restored from it when the v2 spec is converted back.
+const ConversionDataAnnotation = "apache.doris.org/v1-spec"
+
+var _ conversion.Convertible = &DorisDisaggregatedCluster{}
+
+// ConvertTo converts this DorisDisaggregatedCluster to the hub version v1.
+func (ddc *DorisDisaggregatedCluster) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*dv1.DorisDisaggregatedCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1 DorisDisaggregatedCluster but got %T", hub)
+	}
+
+	spec, err := convertSpecToV1(&ddc.Spec)
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = *ddc.ObjectMeta.DeepCopy()
+	if data, ok := dst.Annotations[ConversionDataAnnotation]; ok {
+		delete(dst.Annotations, ConversionDataAnnotation)
+		restored := &dv1.DorisDisaggregatedClusterSpec{}
+		if err := json.Unmarshal([]byte(data), restored); err != nil {
+			return fmt.Errorf("unmarshal annotation %s failed, err=%s", ConversionDataAnnotation, err.Error())
+		}
+		restoreV1OnlyFields(spec, restored)
+	}
+
+	dst.Spec = *spec
+	dst.Status = *ddc.Status.DeepCopy()
+	return nil
+}
+
+// ConvertFrom converts the hub version v1 to this DorisDisaggregatedCluster.
+func (ddc *DorisDisaggregatedCluster) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*dv1.DorisDisaggregatedCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1 DorisDisaggregatedCluster but got %T", hub)
+	}
+
+	ddc.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	ddc.Spec = *convertSpecFromV1(&src.Spec)
+	ddc.Status = *src.Status.DeepCopy()
+
+	// keep the v1 only fields when the v1 spec can not be rebuilt from v2 spec.
+	if back, err := convertSpecToV1(&ddc.Spec); err != nil || !equality.Semantic.DeepEqual(back, &src.Spec) {
+		data, err := json.Marshal(v1OnlyFields(&src.Spec))
+		if err != nil {
+			return err
+		}
+		if ddc.Annotations == nil {
+			ddc.Annotations = map[string]string{}
+		}
+		ddc.Annotations[ConversionDataAnnotation] = string(data)
+	}
+	return nil
+}
+
+func convertSpecFromV1(src *dv1.DorisDisaggregatedClusterSpec) *DorisDisaggregatedClusterSpec {
+	spec := &DorisDisaggregatedClusterSpec{
+		MetaService: MetaService{
+			ComponentTemplate: templateFromV1(&src.MetaService.CommonSpec),
+			LogNotStore:       src.MetaService.LogNotStore,
+			FDB: FDB{
+				Address:                src.MetaService.FDB.Address,
+				ConfigMapNamespaceName: NamespaceName(src.MetaService.FDB.ConfigMapNamespaceName),
+			},
+		},
+		FeSpec: FeSpec{
+			ComponentTemplate: templateFromV1(&src.FeSpec.CommonSpec),
+			LogNotStore:       src.FeSpec.LogNotStore,
+		},
+		AuthSecret:         src.AuthSecret,
+		EnableDecommission: src.EnableDecommission,
+	}
+	if src.FeSpec.ElectionNumber != nil {
+		en := *src.FeSpec.ElectionNumber
+		spec.FeSpec.ElectionNumber = &en
+	}
+	for i := range src.ComputeGroups {
+		cg := &src.ComputeGroups[i]
+		spec.ComputeGroups = append(spec.ComputeGroups, ComputeGroup{
+			UniqueId:              cg.UniqueId,
+			EnableWorkloadGroup:   cg.EnableWorkloadGroup,
+			ComponentTemplate:     templateFromV1(&cg.CommonSpec),
+			LogNotStore:           cg.LogNotStore,
+			SkipDefaultSystemInit: cg.SkipDefaultSystemInit,
+			AutoResolveLimitCPU:   cg.AutoResolveLimitCPU,
+		})
+	}
+	spec.Monitoring = monitoringFromV1(src.Monitoring)
+	spec.QueryProbe = (*commonv2.QueryProbe)(src.QueryProbe.DeepCopy())
+	if src.PasswordRotation != nil {
+		spec.PasswordRotation = &commonv2.PasswordRotation{Interval: src.PasswordRotation.Interval.DeepCopy()}
+	}
+	if src.KerberosInfo != nil {
+		spec.KerberosInfo = (*commonv2.KerberosInfo)(src.KerberosInfo.DeepCopy())
+	}
+	if src.TLS != nil {
+		spec.TLS = &commonv2.TLS{Enabled: src.TLS.Enabled, CertificateValidity: src.TLS.CertificateValidity.DeepCopy(), RenewBefore: src.TLS.RenewBefore.DeepCopy()}
+		if src.TLS.IssuerRef != nil {
+			spec.TLS.IssuerRef = (*commonv2.CertManagerIssuerRef)(src.TLS.IssuerRef.DeepCopy())
+		}
+	}
+	if src.EnableRestartWhenConfigChange != nil {
+		enable := *src.EnableRestartWhenConfigChange
+		spec.EnableRestartWhenConfigChange = &enable
+	}
+	return spec
+}
+
+func convertSpecToV1(src *DorisDisaggregatedClusterSpec) (*dv1.DorisDisaggregatedClusterSpec, error) {
+	spec := &dv1.DorisDisaggregatedClusterSpec{
+		MetaService: dv1.MetaService{
+			FDB: dv1.FDB{
+				Address:                src.MetaService.FDB.Address,
+				ConfigMapNamespaceName: dv1.NamespaceName(src.MetaService.FDB.ConfigMapNamespaceName),
+			},
+		},
+		AuthSecret:         src.AuthSecret,
+		EnableDecommission: src.EnableDecommission,
+	}
+
+	var errs []error
+	errs = append(errs, templateToV1("metaService", &src.MetaService.ComponentTemplate, &spec.MetaService.CommonSpec)...)
+	spec.MetaService.LogNotStore = src.MetaService.LogNotStore
+	errs = append(errs, templateToV1("feSpec", &src.FeSpec.ComponentTemplate, &spec.FeSpec.CommonSpec)...)
+	spec.FeSpec.LogNotStore = src.FeSpec.LogNotStore
+	if src.FeSpec.ElectionNumber != nil {
+		en := *src.FeSpec.ElectionNumber
+		spec.FeSpec.ElectionNumber = &en
+	}
+	for i := range src.ComputeGroups {
+		cg := &src.ComputeGroups[i]
+		dcg := dv1.ComputeGroup{
+			UniqueId:              cg.UniqueId,
+			EnableWorkloadGroup:   cg.EnableWorkloadGroup,
+			SkipDefaultSystemInit: cg.SkipDefaultSystemInit,
+			AutoResolveLimitCPU:   cg.AutoResolveLimitCPU,
+		}
+		errs = append(errs, templateToV1(fmt.Sprintf("computeGroups[%d]", i), &cg.ComponentTemplate, &dcg.CommonSpec)...)
+		dcg.LogNotStore = cg.LogNotStore
+		spec.ComputeGroups = append(spec.ComputeGroups, dcg)
+	}
+	spec.Monitoring = monitoringToV1(src.Monitoring)
+	spec.QueryProbe = (*dv1.QueryProbe)(src.QueryProbe.DeepCopy())
+	if src.PasswordRotation != nil {
+		spec.PasswordRotation = &dv1.PasswordRotation{Interval: src.PasswordRotation.Interval.DeepCopy()}
+	}
+	if src.KerberosInfo != nil {
+		spec.KerberosInfo = (*dv1.KerberosInfo)(src.KerberosInfo.DeepCopy())
+	}
+	if src.TLS != nil {
+		spec.TLS = &dv1.TLS{Enabled: src.TLS.Enabled, CertificateValidity: src.TLS.CertificateValidity.DeepCopy(), RenewBefore: src.TLS.RenewBefore.DeepCopy()}
+		if src.TLS.IssuerRef != nil {
+			spec.TLS.IssuerRef = (*dv1.CertManagerIssuerRef)(src.TLS.IssuerRef.DeepCopy())
+		}
+	}
+	if src.EnableRestartWhenConfigChange != nil {
+		enable := *src.EnableRestartWhenConfigChange
+		spec.EnableRestartWhenConfigChange = &enable
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("convert DorisDisaggregatedCluster to v1 failed, errs=%v", errs)
+	}
+	return spec, nil
+}
+
+// v1OnlyFields return the spec that only has the fields v2 can not represent. the adminUser is kept with the password for a lossless round trip,
+// the password is already in the spec of v1, the annotation exposes nothing new.
+func v1OnlyFields(src *dv1.DorisDisaggregatedClusterSpec) *dv1.DorisDisaggregatedClusterSpec {
+	spec := &dv1.DorisDisaggregatedClusterSpec{}
+	if src.AdminUser != nil {
+		spec.AdminUser = src.AdminUser.DeepCopy()
+	}
+	spec.MetaService.PersistentVolume = src.MetaService.PersistentVolume.DeepCopy()
+	spec.FeSpec.PersistentVolume = src.FeSpec.PersistentVolume.DeepCopy()
+	for i := range src.ComputeGroups {
+		cg := dv1.ComputeGroup{UniqueId: src.ComputeGroups[i].UniqueId}
+		cg.PersistentVolume = src.ComputeGroups[i].PersistentVolume.DeepCopy()
+		spec.ComputeGroups = append(spec.ComputeGroups, cg)
+	}
+	return spec
+}
+
+// restoreV1OnlyFields restores the fields of v1 that not represented in v2.
+func restoreV1OnlyFields(spec, restored *dv1.DorisDisaggregatedClusterSpec) {
+	spec.AdminUser = restored.AdminUser
+	spec.MetaService.PersistentVolume = restored.MetaService.PersistentVolume
+	spec.FeSpec.PersistentVolume = restored.FeSpec.PersistentVolume
+	for i := range spec.ComputeGroups {
+		for j := range restored.ComputeGroups {
+			if spec.ComputeGroups[i].UniqueId == restored.ComputeGroups[j].UniqueId {
+				spec.ComputeGroups[i].PersistentVolume = restored.ComputeGroups[j].PersistentVolume
+			}
+		}
+	}
+}
+
+func templateFromV1(cs *dv1.CommonSpec) commonv2.ComponentTemplate {
+	cs = cs.DeepCopy()
+	t := commonv2.ComponentTemplate{
+		Replicas:                 cs.Replicas,
+		Image:                    cs.Image,
+		ImagePullSecrets:         cs.ImagePullSecrets,
+		StartTimeout:             cs.StartTimeout,
+		LiveTimeout:              cs.LiveTimeout,
+		ResourceRequirements:     cs.ResourceRequirements,
+		Labels:                   cs.Labels,
+		Annotations:              cs.Annotations,
+		Affinity:                 cs.Affinity,
+		Tolerations:              cs.Tolerations,
+		NodeSelector:             cs.NodeSelector,
+		ServiceAccount:           cs.ServiceAccount,
+		HostAliases:              cs.HostAliases,
+		SecurityContext:          cs.SecurityContext,
+		ContainerSecurityContext: cs.ContainerSecurityContext,
+		EnvVars:                  cs.EnvVars,
+		SystemInitialization:     (*commonv2.SystemInitialization)(cs.SystemInitialization),
+		ReadinessProbePolicy:     (*commonv2.ReadinessProbePolicy)(cs.ReadinessProbePolicy),
+	}
+	for _, s := range cs.Secrets {
+		t.Secrets = append(t.Secrets, commonv2.Secret(s))
+	}
+	for _, cm := range cs.ConfigMaps {
+		t.ConfigMaps = append(t.ConfigMaps, commonv2.ConfigMap(cm))
+	}
+	for _, pv := range cs.PersistentVolumes {
+		cpv := commonv2.PersistentVolume{
+			PersistentVolumeClaimSpec: pv.PersistentVolumeClaimSpec,
+			MountPaths:                pv.MountPaths,
+			Annotations:               pv.Annotations,
+			AutoExpansion:             (*commonv2.PVCAutoExpansion)(pv.AutoExpansion),
+		}
+		if pv.PVCProvisioner == dv1.PVCProvisionerOperator {
+			cpv.Provisioner = commonv2.PVCProvisionerOperator
+		}
+		t.PersistentVolumes = append(t.PersistentVolumes, cpv)
+	}
+	if cs.PersistentVolumeClaimRetentionPolicy != nil {
+		t.PersistentVolumeClaimRetentionPolicy = &commonv2.PersistentVolumeClaimRetentionPolicy{
+			WhenScaled:  string(cs.PersistentVolumeClaimRetentionPolicy.WhenScaled),
+			WhenDeleted: string(cs.PersistentVolumeClaimRetentionPolicy.WhenDeleted),
+		}
+	}
+	if cs.Service != nil {
+		t.Service = &commonv2.ExportService{Type: cs.Service.Type, Annotations: cs.Service.Annotations}
+		for _, pm := range cs.Service.PortMaps {
+			t.Service.PortMaps = append(t.Service.PortMaps, commonv2.PortMap(pm))
+		}
+	}
+	return t
+}
+
+// templateToV1 converts the template to the CommonSpec of v1, the values that v1 not supported are returned as errors.
+func templateToV1(component string, t *commonv2.ComponentTemplate, cs *dv1.CommonSpec) []error {
+	t = t.DeepCopy()
+	cs.Replicas = t.Replicas
+	cs.Image = t.Image
+	cs.ImagePullSecrets = t.ImagePullSecrets
+	cs.StartTimeout = t.StartTimeout
+	cs.LiveTimeout = t.LiveTimeout
+	cs.ResourceRequirements = t.ResourceRequirements
+	cs.Labels = t.Labels
+	cs.Annotations = t.Annotations
+	cs.Affinity = t.Affinity
+	cs.Tolerations = t.Tolerations
+	cs.NodeSelector = t.NodeSelector
+	cs.ServiceAccount = t.ServiceAccount
+	cs.HostAliases = t.HostAliases
+	cs.SecurityContext = t.SecurityContext
+	cs.ContainerSecurityContext = t.ContainerSecurityContext
+	cs.EnvVars = t.EnvVars
+	cs.SystemInitialization = (*dv1.SystemInitialization)(t.SystemInitialization)
+	cs.ReadinessProbePolicy = (*dv1.ReadinessProbePolicy)(t.ReadinessProbePolicy)
+	for _, s := range t.Secrets {
+		cs.Secrets = append(cs.Secrets, dv1.Secret(s))
+	}
+	for _, cm := range t.ConfigMaps {
+		cs.ConfigMaps = append(cs.ConfigMaps, dv1.ConfigMap(cm))
+	}
+
+	var errs []error
+	for i, pv := range t.PersistentVolumes {
+		if pv.Name != "" || pv.StorageMedium != "" || pv.SpillStorage {
+			errs = append(errs, fmt.Errorf("%s.persistentVolumes[%d]: name, storageMedium and spillStorage are not supported by DorisDisaggregatedCluster", component, i))
+			continue
+		}
+		dpv := dv1.PersistentVolume{
+			PersistentVolumeClaimSpec: pv.PersistentVolumeClaimSpec,
+			MountPaths:                pv.MountPaths,
+			Annotations:               pv.Annotations,
+			AutoExpansion:             (*dv1.PVCAutoExpansion)(pv.AutoExpansion),
+		}
+		if pv.Provisioner == commonv2.PVCProvisionerOperator {
+			dpv.PVCProvisioner = dv1.PVCProvisionerOperator
+		}
+		cs.PersistentVolumes = append(cs.PersistentVolumes, dpv)
+	}
+	if t.PersistentVolumeClaimRetentionPolicy != nil {
+		cs.PersistentVolumeClaimRetentionPolicy = &dv1.PersistentVolumeClaimRetentionPolicy{
+			WhenScaled:  dv1.PersistentVolumeClaimRetentionPolicyType(t.PersistentVolumeClaimRetentionPolicy.WhenScaled),
+			WhenDeleted: dv1.PersistentVolumeClaimRetentionPolicyType(t.PersistentVolumeClaimRetentionPolicy.WhenDeleted),
+		}
+	}
+	if t.Service != nil {
+		if t.Service.LoadBalancerIP != "" {
+			errs = append(errs, fmt.Errorf("%s.service.loadBalancerIP: not supported by DorisDisaggregatedCluster", component))
+		}
+		cs.Service = &dv1.ExportService{Type: t.Service.Type, Annotations: t.Service.Annotations}
+		for _, pm := range t.Service.PortMaps {
+			cs.Service.PortMaps = append(cs.Service.PortMaps, dv1.PortMap(pm))
+		}
+	}
+	return errs
+}
+
+func monitoringFromV1(m *dv1.Monitoring) *commonv2.Monitoring {
+	if m == nil {
+		return nil
+	}
+	m = m.DeepCopy()
+	return &commonv2.Monitoring{
+		Enabled:       m.Enabled,
+		Kind:          m.Kind,
+		Interval:      m.Interval,
+		ScrapeTimeout: m.ScrapeTimeout,
+		Labels:        m.Labels,
+		Rules:         (*commonv2.MonitoringRules)(m.Rules),
+	}
+}
+
+func monitoringToV1(m *commonv2.Monitoring) *dv1.Monitoring {
+	if m == nil {
+		return nil
+	}
+	m = m.DeepCopy()
+	return &dv1.Monitoring{
+		Enabled:       m.Enabled,
+		Kind:          m.Kind,
+		Interval:      m.Interval,
+		ScrapeTimeout: m.ScrapeTimeout,
+		Labels:        m.Labels,
+		Rules:         (*dv1.MonitoringRules)(m.Rules),
+	}
+}
@@ -0,0 +1,154 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newV1DisaggregatedCluster() *dv1.DorisDisaggregatedCluster {
+	replicas := int32(3)
+	pvcSpec := corev1.PersistentVolumeClaimSpec{
+		Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}},
+	}
+	return &dv1.DorisDisaggregatedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: dv1.DorisDisaggregatedClusterSpec{
+			MetaService: dv1.MetaService{
+				CommonSpec: dv1.CommonSpec{Image: "apache/doris:ms-3.0.3"},
+				FDB:        dv1.FDB{ConfigMapNamespaceName: dv1.NamespaceName{Namespace: "default", Name: "fdb-config"}},
+			},
+			FeSpec: dv1.FeSpec{
+				ElectionNumber: &replicas,
+				CommonSpec: dv1.CommonSpec{
+					Replicas:          &replicas,
+					Image:             "apache/doris:fe-3.0.3",
+					ConfigMaps:        []dv1.ConfigMap{{Name: "fe-conf", MountPath: "/etc/doris"}},
+					PersistentVolumes: []dv1.PersistentVolume{{MountPaths: []string{"/opt/apache-doris/fe/doris-meta"}, PersistentVolumeClaimSpec: pvcSpec, PVCProvisioner: dv1.PVCProvisionerOperator}},
+				},
+			},
+			ComputeGroups: []dv1.ComputeGroup{{
+				UniqueId:   "cg1",
+				CommonSpec: dv1.CommonSpec{Replicas: &replicas, Image: "apache/doris:be-3.0.3", Service: &dv1.ExportService{Type: corev1.ServiceTypeNodePort, PortMaps: []dv1.PortMap{{NodePort: 31040, TargetPort: 8040}}}},
+			}},
+			EnableDecommission: true,
+			Monitoring:         &dv1.Monitoring{Enabled: true, Interval: &metav1.Duration{Duration: 30 * time.Second}, Rules: &dv1.MonitoringRules{Enabled: true}},
+		},
+		Status: dv1.DorisDisaggregatedClusterStatus{ClusterHealth: dv1.ClusterHealth{Health: dv1.Green}},
+	}
+}
+
+func TestDorisDisaggregatedClusterConvertRoundTrip(t *testing.T) {
+	src := newV1DisaggregatedCluster()
+	v2ddc := &DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	if _, ok := v2ddc.Annotations[ConversionDataAnnotation]; ok {
+		t.Errorf("expected no conversion annotation when the conversion is lossless")
+	}
+	if v2ddc.Spec.FeSpec.PersistentVolumes[0].Provisioner != "Operator" {
+		t.Errorf("expected provisioner Operator, got %s", v2ddc.Spec.FeSpec.PersistentVolumes[0].Provisioner)
+	}
+
+	dst := &dv1.DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisDisaggregatedCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+}
+
+func TestDorisDisaggregatedClusterConvertDeprecatedFields(t *testing.T) {
+	src := newV1DisaggregatedCluster()
+	src.Spec.AdminUser = &dv1.AdminUser{Name: "root"}
+	src.Spec.ComputeGroups[0].PersistentVolume = &dv1.PersistentVolume{MountPaths: []string{"/opt/apache-doris/be/file_cache"}, LogNotStore: true}
+
+	v2ddc := &DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	if _, ok := v2ddc.Annotations[ConversionDataAnnotation]; !ok {
+		t.Fatalf("expected the v1 spec stored in annotation %s", ConversionDataAnnotation)
+	}
+
+	dst := &dv1.DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisDisaggregatedCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+
+	// the deprecated fields are kept when the v2 spec is modified.
+	v2ddc.Spec.ComputeGroups[0].Image = "apache/doris:be-3.0.4"
+	dst = &dv1.DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertTo(dst); err != nil {
+		t.Fatalf("convert modified v2 to v1 failed, err=%s", err.Error())
+	}
+	if dst.Spec.ComputeGroups[0].Image != "apache/doris:be-3.0.4" {
+		t.Errorf("expected the modified image, got %s", dst.Spec.ComputeGroups[0].Image)
+	}
+	if dst.Spec.AdminUser == nil || dst.Spec.ComputeGroups[0].PersistentVolume == nil {
+		t.Errorf("expected deprecated fields restored, got %+v", dst.Spec)
+	}
+}
+
+func TestDorisDisaggregatedClusterConvertRoundTripWithPassword(t *testing.T) {
+	src := newV1DisaggregatedCluster()
+	src.Spec.AdminUser = &dv1.AdminUser{Name: "root", Password: "secret-password"}
+
+	v2ddc := &DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+
+	// a read-modify-write through v2 keeps the password of adminUser.
+	replicas := int32(5)
+	v2ddc.Spec.FeSpec.Replicas = &replicas
+	dst := &dv1.DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if dst.Spec.AdminUser == nil || dst.Spec.AdminUser.Name != "root" || dst.Spec.AdminUser.Password != "secret-password" {
+		t.Errorf("expected the admin user restored with password, got %+v", dst.Spec.AdminUser)
+	}
+	dst.Spec.FeSpec.Replicas = src.Spec.FeSpec.Replicas
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisDisaggregatedCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+}
+
+func TestDorisDisaggregatedClusterConvertToRejectsUnsupportedFields(t *testing.T) {
+	v2ddc := &DorisDisaggregatedCluster{}
+	if err := v2ddc.ConvertFrom(newV1DisaggregatedCluster()); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	v2ddc.Spec.FeSpec.PersistentVolumes[0].StorageMedium = "SSD"
+	if err := v2ddc.ConvertTo(&dv1.DorisDisaggregatedCluster{}); err == nil {
+		t.Errorf("expected error when storageMedium is set")
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	commonv2 "github.com/apache/doris-operator/api/common/v2"
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DorisDisaggregatedClusterSpec defines the desired state of DorisDisaggregatedCluster, the components share the pod template with DorisCluster.
+type DorisDisaggregatedClusterSpec struct {
+	// MetaService describe the metaservice that cluster want to storage metadata.
+	MetaService MetaService `json:"metaService,omitempty"`
+
+	// FeSpec describe the fe specification of doris disaggregated cluster.
+	FeSpec FeSpec `json:"feSpec,omitempty"`
+
+	// ComputeGroups describe a list of ComputeGroup, ComputeGroup is a group of compute node to do same thing.
+	ComputeGroups []ComputeGroup `json:"computeGroups,omitempty"`
+
+	// AuthSecret is the name of `kubernetes.io/basic-auth` secret that contains the username and password of management user.
+	AuthSecret string `json:"authSecret,omitempty"`
+
+	// PasswordRotation enables operator rotating the password of the management user in AuthSecret.
+	PasswordRotation *commonv2.PasswordRotation `json:"passwordRotation,omitempty"`
+
+	// EnableDecommission decommissions the compute nodes when scaling down, in place of dropping them.
+	EnableDecommission bool `json:"enableDecommission,omitempty"`
+
+	// KerberosInfo contains a series of access key files, Provides access to kerberos.
+	KerberosInfo *commonv2.KerberosInfo `json:"kerberosInfo,omitempty"`
+
+	// TLS specifies the certificates issued by operator for fe and compute groups.
+	TLS *commonv2.TLS `json:"tls,omitempty"`
+
+	// Monitoring makes operator create the Prometheus Operator objects for scraping the metrics of components.
+	Monitoring *commonv2.Monitoring `json:"monitoring,omitempty"`
+
+	// QueryProbe makes operator run a synthetic query on every fe and in every compute group by `USE @<compute group>` at an interval.
+	QueryProbe *commonv2.QueryProbe `json:"queryProbe,omitempty"`
+
+	// EnableRestartWhenConfigChange applies the changed configs of components, the configs that can not be modified at runtime restart the pods.
+	EnableRestartWhenConfigChange *bool `json:"enableRestartWhenConfigChange,omitempty"`
+}
+
+type MetaService struct {
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// LogNotStore not mounts a pvc to store the logs of metaservice.
+	LogNotStore bool `json:"logNotStore,omitempty"`
+
+	// FDB is the fdb that metaservice stores data.
+	FDB FDB `json:"fdb,omitempty"`
+}
+
+type FDB struct {
+	// Address specify the fdb connection string.
+	Address string `json:"address,omitempty"`
+
+	// ConfigMapNamespaceName is the configmap that generated by fdb-kubernetes-operator, contains the connection string.
+	ConfigMapNamespaceName NamespaceName `json:"configMapNamespaceName,omitempty"`
+}
+
+type NamespaceName struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+type FeSpec struct {
+	// the number of fe in election. electionNumber <= replicas, left as observers. default value=3
+	ElectionNumber *int32 `json:"electionNumber,omitempty"`
+
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// LogNotStore not mounts a pvc to store the logs of fe.
+	LogNotStore bool `json:"logNotStore,omitempty"`
+}
+
+// ComputeGroup describe the specification that a group of compute node.
+type ComputeGroup struct {
+	// the unique identifier of compute group, first register in fe will use UniqueId as cluster name.
+	UniqueId string `json:"uniqueId"`
+
+	// EnableWorkloadGroup starts the container in privileged mode for the workload group of doris.
+	EnableWorkloadGroup bool `json:"enableWorkloadGroup,omitempty"`
+
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// LogNotStore not mounts a pvc to store the logs of compute node.
+	LogNotStore bool `json:"logNotStore,omitempty"`
+
+	// SkipDefaultSystemInit skips the default initialization of system parameters required by compute node.
+	SkipDefaultSystemInit bool `json:"skipDefaultSystemInit,omitempty"`
+
+	// AutoResolveLimitCPU sets the cpu limit of compute node by the requests.cpu of pod.
+	AutoResolveLimitCPU bool `json:"autoResolveLimitCPU,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ddc
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="ClusterHealth",type=string,JSONPath=`.status.clusterHealth.health`
+// +kubebuilder:printcolumn:name="MSPhase",type=string,JSONPath=`.status.metaServiceStatus.phase`
+// +kubebuilder:printcolumn:name="FEPhase",type=string,JSONPath=`.status.feStatus.phase`
+// +kubebuilder:printcolumn:name="CGCount",type=integer,JSONPath=`.status.clusterHealth.cgCount`
+// +kubebuilder:printcolumn:name="CGAvailableCount",type=integer,JSONPath=`.status.clusterHealth.cgAvailableCount`
+// +kubebuilder:printcolumn:name="CGFullAvailableCount",type=integer,JSONPath=`.status.clusterHealth.cgFullAvailableCount`
+// DorisDisaggregatedCluster is the v2 version of DorisDisaggregatedCluster, it is converted from the storage version v1 by the conversion webhook of operator.
+// v2 is not served by the crd as shipped, the operator serves it and configures the conversion webhook only when the webhook enabled.
+type DorisDisaggregatedCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DorisDisaggregatedClusterSpec       `json:"spec,omitempty"`
+	Status dv1.DorisDisaggregatedClusterStatus `json:"status,omitempty"`
+}
+
+// DorisDisaggregatedClusterList contains a list of DorisDisaggregatedCluster
+// +kubebuilder:object:root=true
+type DorisDisaggregatedClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DorisDisaggregatedCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DorisDisaggregatedCluster{}, &DorisDisaggregatedClusterList{})
+}
@@ -31,6 +31,9 @@ const (
 
 	FERestartAt string = "apache.doris.fe/restartedAt"
 	BERestartAt string = "apache.doris.be/restartedAt"
+
+	// BECancelDecommission cancel the decommission of be nodes in progress when annotated on DorisCluster, the be pods will be kept.
+	BECancelDecommission string = "apache.doris.be/cancelDecommission"
 )
 
 // the labels key
@@ -408,3 +411,8 @@ func (dcr *DorisCluster) GetComponentStatus(componentType ComponentType) *Compon
 	}
 	return nil
 }
+
+// IsTLSEnabled returns true when operator should issue certificates for the cluster.
+func (dcr *DorisCluster) IsTLSEnabled() bool {
+	return dcr.Spec.TLS != nil && dcr.Spec.TLS.Enabled
+}
@@ -36,15 +36,33 @@ package v1
 import (
 	"context"
 	"fmt"
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"strings"
+	"time"
 )
 
+// RejectPlaintextAdminPassword rejects the new plaintext `adminUser.password`, the credentials of management user should be configured by `authSecret`.
+var RejectPlaintextAdminPassword bool
+
+// ConfigValidator validates the configs in the configmaps referenced by the cluster, the errors reject the request and the warnings are returned to user.
+// it is registered by controller when the webhook enabled, as the configmaps should be fetched from kubernetes.
+var ConfigValidator func(ctx context.Context, cluster *DorisCluster) ([]string, []error)
+
+// MaxReplicationNumGetter queries the max number of replicas of tables from fe, the be replicas can not be reduced below it.
+// it is registered by controller when the webhook enabled, the check is skipped when it not registered or fe not reachable.
+var MaxReplicationNumGetter func(ctx context.Context, cluster *DorisCluster) (int32, error)
+
+// the timeout of querying fe in webhook, less than the default timeout of webhook.
+const maxReplicationNumTimeout = 5 * time.Second
+
 // log is for logging in this package.
 
 func (r *DorisCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -78,11 +96,17 @@ func (r *DorisCluster) ValidateCreate(ctx context.Context, obj runtime.Object) (
 	}
 	klog.Info("validate create", "name", cluster.Name)
 
-	if errs := cluster.validateManagementUser(); len(errs) != 0 {
-		return nil, kerrors.NewAggregate(errs)
+	errs := cluster.validateManagementUser()
+	errs = append(errs, cluster.validateAdminUserPassword(nil)...)
+	errs = append(errs, cluster.validateTLS()...)
+	errs = append(errs, cluster.validateQueryProbe()...)
+	warnings, cerrs := cluster.validateConfigs(ctx)
+	errs = append(errs, cerrs...)
+	if len(errs) != 0 {
+		return warnings, kerrors.NewAggregate(errs)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a unnamedwatches will be registered for the type
@@ -91,19 +115,29 @@ func (r *DorisCluster) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 	if !ok {
 		return nil, fmt.Errorf("expected a DorisCluster but got %T", newObj)
 	}
+	old, ok := oldObj.(*DorisCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a DorisCluster but got %T", oldObj)
+	}
 	klog.Info("validate update", "name", cluster.Name)
 	var errors []error
 	errors = append(errors, cluster.validateManagementUser()...)
+	errors = append(errors, cluster.validateAdminUserPassword(old)...)
+	errors = append(errors, cluster.validateTLS()...)
+	errors = append(errors, cluster.validateQueryProbe()...)
+	errors = append(errors, cluster.validateUpdate(ctx, old)...)
 	// fe FeSpec.Replicas must greater than or equal to FeSpec.ElectionNumber
-	if cluster.Spec.FeSpec.Replicas != nil && *cluster.Spec.FeSpec.Replicas < cluster.GetElectionNumber() {
+	if cluster.Spec.FeSpec != nil && cluster.Spec.FeSpec.Replicas != nil && *cluster.Spec.FeSpec.Replicas < cluster.GetElectionNumber() {
 		errors = append(errors, fmt.Errorf("'FeSpec.Replicas' error: the number of FeSpec.Replicas should greater than or equal to FeSpec.ElectionNumber"))
 	}
+	warnings, cerrs := cluster.validateConfigs(ctx)
+	errors = append(errors, cerrs...)
 
 	if len(errors) != 0 {
-		return nil, kerrors.NewAggregate(errors)
+		return warnings, kerrors.NewAggregate(errors)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a unnamedwatches will be registered for the type
@@ -121,3 +155,163 @@ func (r *DorisCluster) validateManagementUser() []error {
 
 	return []error{fmt.Errorf("'adminUser.name' error: admin is not supported as management user, use root or a dedicated user with NODE_PRIV")}
 }
+
+// validateAdminUserPassword rejects the new plaintext `adminUser.password` when RejectPlaintextAdminPassword enabled.
+// the unchanged password of existing cluster is allowed, it will be moved into `authSecret` by operator.
+func (r *DorisCluster) validateAdminUserPassword(old *DorisCluster) []error {
+	if !RejectPlaintextAdminPassword || r.Spec.AdminUser == nil || r.Spec.AdminUser.Password == "" {
+		return nil
+	}
+	if old != nil && old.Spec.AdminUser != nil && old.Spec.AdminUser.Password == r.Spec.AdminUser.Password {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'adminUser.password' error: plaintext password is not allowed, use authSecret with a kubernetes.io/basic-auth secret")}
+}
+
+// validateQueryProbe rejects the query of probing that is not a single SELECT statement, as the query runs with the credentials of management user.
+func (r *DorisCluster) validateQueryProbe() []error {
+	if r.Spec.QueryProbe == nil || r.Spec.QueryProbe.Query == "" || doris.IsSelectQuery(r.Spec.QueryProbe.Query) {
+		return nil
+	}
+
+	return []error{fmt.Errorf("'queryProbe.query' error: only a single SELECT statement is allowed")}
+}
+
+// validateTLS rejects the tls enabled cluster that the fe, be or cn has no configmap of core config file mounted at `/etc/doris`,
+// as the tls configs are rendered into the core config file by operator.
+func (r *DorisCluster) validateTLS() []error {
+	if !r.IsTLSEnabled() {
+		return nil
+	}
+
+	var errs []error
+	if r.Spec.FeSpec != nil && !hasCoreConfigMap(r.Spec.FeSpec.ConfigMapInfo) {
+		errs = append(errs, fmt.Errorf("'feSpec.configMapInfo' error: tls enabled requires the configmap of fe.conf mounted at %s", defaultConfigMountPath))
+	}
+	if r.Spec.BeSpec != nil && !hasCoreConfigMap(r.Spec.BeSpec.ConfigMapInfo) {
+		errs = append(errs, fmt.Errorf("'beSpec.configMapInfo' error: tls enabled requires the configmap of be.conf mounted at %s", defaultConfigMountPath))
+	}
+	if r.Spec.CnSpec != nil && !hasCoreConfigMap(r.Spec.CnSpec.ConfigMapInfo) {
+		errs = append(errs, fmt.Errorf("'cnSpec.configMapInfo' error: tls enabled requires the configmap of be.conf mounted at %s", defaultConfigMountPath))
+	}
+	return errs
+}
+
+// defaultConfigMountPath is the directory that the core config files of components mounted.
+const defaultConfigMountPath = "/etc/doris"
+
+// hasCoreConfigMap returns true when a configmap mounted at the default config directory, the same as the core configmap resolved by operator.
+func hasCoreConfigMap(info ConfigMapInfo) bool {
+	if info.ConfigMapName != "" {
+		return true
+	}
+	for _, cm := range info.ConfigMaps {
+		if cm.ConfigMapName != "" && (cm.MountPath == "" || cm.MountPath == defaultConfigMountPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateConfigs validates the configs of components by ConfigValidator, skipped when the validator not registered.
+func (r *DorisCluster) validateConfigs(ctx context.Context) (admission.Warnings, []error) {
+	if ConfigValidator == nil {
+		return nil, nil
+	}
+
+	warnings, errs := ConfigValidator(ctx, r)
+	return warnings, errs
+}
+
+// validateUpdate checks the changes from old cluster that can not be applied to the deployed cluster safely.
+func (r *DorisCluster) validateUpdate(ctx context.Context, old *DorisCluster) []error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if old.Spec.FeSpec != nil && r.Spec.FeSpec == nil {
+		errs = append(errs, field.Required(specPath.Child("feSpec"), "feSpec can not be removed from the deployed cluster"))
+	}
+
+	if old.Spec.FeSpec != nil && r.Spec.FeSpec != nil {
+		errs = append(errs, validatePersistentVolumesUpdate(specPath.Child("feSpec", "persistentVolumes"), old.Spec.FeSpec.PersistentVolumes, r.Spec.FeSpec.PersistentVolumes)...)
+	}
+	if old.Spec.BeSpec != nil && r.Spec.BeSpec != nil {
+		errs = append(errs, validatePersistentVolumesUpdate(specPath.Child("beSpec", "persistentVolumes"), old.Spec.BeSpec.PersistentVolumes, r.Spec.BeSpec.PersistentVolumes)...)
+		errs = append(errs, r.validateBeReplicasUpdate(ctx, old)...)
+	}
+	if old.Spec.CnSpec != nil && r.Spec.CnSpec != nil {
+		errs = append(errs, validatePersistentVolumesUpdate(specPath.Child("cnSpec", "persistentVolumes"), old.Spec.CnSpec.PersistentVolumes, r.Spec.CnSpec.PersistentVolumes)...)
+	}
+	if old.Spec.BrokerSpec != nil && r.Spec.BrokerSpec != nil {
+		errs = append(errs, validatePersistentVolumesUpdate(specPath.Child("brokerSpec", "persistentVolumes"), old.Spec.BrokerSpec.PersistentVolumes, r.Spec.BrokerSpec.PersistentVolumes)...)
+	}
+	errs = append(errs, r.validateSharedPersistentVolumeClaimsUpdate(old)...)
+
+	var res []error
+	for _, err := range errs {
+		res = append(res, err)
+	}
+	return res
+}
+
+// validatePersistentVolumesUpdate rejects shrinking the storage and changing the storage class of the existing volumes, the volumes are matched by name.
+func validatePersistentVolumesUpdate(path *field.Path, oldPVs, pvs []PersistentVolume) field.ErrorList {
+	oldPVMap := map[string]*PersistentVolume{}
+	for i := range oldPVs {
+		oldPVMap[oldPVs[i].Name] = &oldPVs[i]
+	}
+
+	var errs field.ErrorList
+	for i := range pvs {
+		opv, ok := oldPVMap[pvs[i].Name]
+		if !ok {
+			continue
+		}
+		pvPath := path.Index(i).Child("persistentVolumeClaimSpec")
+		oldSize, ook := opv.Resources.Requests[corev1.ResourceStorage]
+		size, nok := pvs[i].Resources.Requests[corev1.ResourceStorage]
+		if ook && nok && size.Cmp(oldSize) < 0 {
+			errs = append(errs, field.Forbidden(pvPath.Child("resources", "requests", "storage"), fmt.Sprintf("the storage of volume %s can not be shrunk from %s to %s", pvs[i].Name, oldSize.String(), size.String())))
+		}
+		if opv.StorageClassName != nil && pvs[i].StorageClassName != nil && *opv.StorageClassName != *pvs[i].StorageClassName {
+			errs = append(errs, field.Forbidden(pvPath.Child("storageClassName"), fmt.Sprintf("the storage class of volume %s can not be changed from %s to %s", pvs[i].Name, *opv.StorageClassName, *pvs[i].StorageClassName)))
+		}
+	}
+	return errs
+}
+
+// validateBeReplicasUpdate rejects reducing be replicas below the max replication number of tables, the tables can not be repaired when the replicas of them lost.
+func (r *DorisCluster) validateBeReplicasUpdate(ctx context.Context, old *DorisCluster) field.ErrorList {
+	if MaxReplicationNumGetter == nil || old.Spec.BeSpec.Replicas == nil || r.Spec.BeSpec.Replicas == nil || *r.Spec.BeSpec.Replicas >= *old.Spec.BeSpec.Replicas {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxReplicationNumTimeout)
+	defer cancel()
+	maxNum, err := MaxReplicationNumGetter(ctx, old)
+	if err != nil {
+		klog.Infof("validate update name=%s, skip checking be replicas as the max replication number of tables not queried, err=%s", r.Name, err.Error())
+		return nil
+	}
+	if *r.Spec.BeSpec.Replicas < maxNum {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "beSpec", "replicas"), fmt.Sprintf("be replicas can not be reduced to %d, less than the max replication number %d of tables", *r.Spec.BeSpec.Replicas, maxNum))}
+	}
+	return nil
+}
+
+// validateSharedPersistentVolumeClaimsUpdate rejects changing the mount path of the mounted shared pvc in place.
+func (r *DorisCluster) validateSharedPersistentVolumeClaimsUpdate(old *DorisCluster) field.ErrorList {
+	oldMountPaths := map[string]string{}
+	for _, spvc := range old.Spec.SharedPersistentVolumeClaims {
+		oldMountPaths[spvc.PersistentVolumeClaimName] = spvc.MountPath
+	}
+
+	var errs field.ErrorList
+	for i, spvc := range r.Spec.SharedPersistentVolumeClaims {
+		if mountPath, ok := oldMountPaths[spvc.PersistentVolumeClaimName]; ok && mountPath != spvc.MountPath {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "sharedPersistentVolumeClaims").Index(i).Child("mountPath"),
+				fmt.Sprintf("the mount path of shared pvc %s can not be changed from %s to %s in place", spvc.PersistentVolumeClaimName, mountPath, spvc.MountPath)))
+		}
+	}
+	return errs
+}
@@ -19,7 +19,12 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestDorisClusterRejectsAdminManagementUser(t *testing.T) {
@@ -59,3 +64,161 @@ func TestDorisClusterAllowsNonAdminManagementUser(t *testing.T) {
 		t.Fatalf("expected non-admin management user to be allowed on update: %v", err)
 	}
 }
+
+func TestDorisClusterRejectsPlaintextAdminPassword(t *testing.T) {
+	RejectPlaintextAdminPassword = true
+	defer func() { RejectPlaintextAdminPassword = false }()
+
+	validator := &DorisCluster{}
+	replicas := int32(3)
+	old := &DorisCluster{
+		Spec: DorisClusterSpec{
+			AdminUser: &AdminUser{Name: "root", Password: "old"},
+			FeSpec:    &FeSpec{BaseSpec: BaseSpec{Replicas: &replicas}},
+		},
+	}
+	if _, err := validator.ValidateCreate(context.Background(), old); err == nil {
+		t.Fatal("expected plaintext password to be rejected on create")
+	}
+	if _, err := validator.ValidateUpdate(context.Background(), old, old); err != nil {
+		t.Fatalf("expected unchanged plaintext password to be allowed on update: %v", err)
+	}
+
+	cluster := old.DeepCopy()
+	cluster.Spec.AdminUser.Password = "new"
+	if _, err := validator.ValidateUpdate(context.Background(), old, cluster); err == nil {
+		t.Fatal("expected changed plaintext password to be rejected on update")
+	}
+
+	cluster.Spec.AdminUser = nil
+	cluster.Spec.AuthSecret = "doris-auth"
+	if _, err := validator.ValidateUpdate(context.Background(), old, cluster); err != nil {
+		t.Fatalf("expected authSecret to be allowed on update: %v", err)
+	}
+}
+
+func TestDorisClusterValidateTLS(t *testing.T) {
+	validator := &DorisCluster{}
+	replicas := int32(3)
+	cluster := &DorisCluster{
+		Spec: DorisClusterSpec{
+			TLS:    &TLS{Enabled: true},
+			FeSpec: &FeSpec{BaseSpec: BaseSpec{Replicas: &replicas, ConfigMapInfo: ConfigMapInfo{ConfigMapName: "fe-conf"}}},
+			BeSpec: &BeSpec{BaseSpec: BaseSpec{ConfigMapInfo: ConfigMapInfo{ConfigMaps: []MountConfigMapInfo{{ConfigMapName: "hdfs-conf", MountPath: "/etc/hdfs"}}}}},
+		},
+	}
+	if _, err := validator.ValidateCreate(context.Background(), cluster); err == nil {
+		t.Fatal("expected tls enabled without the core configmap of be to be rejected on create")
+	}
+	if _, err := validator.ValidateUpdate(context.Background(), cluster, cluster); err == nil {
+		t.Fatal("expected tls enabled without the core configmap of be to be rejected on update")
+	}
+
+	cluster.Spec.BeSpec.ConfigMapInfo.ConfigMaps = append(cluster.Spec.BeSpec.ConfigMapInfo.ConfigMaps, MountConfigMapInfo{ConfigMapName: "be-conf", MountPath: "/etc/doris"})
+	if _, err := validator.ValidateCreate(context.Background(), cluster); err != nil {
+		t.Fatalf("expected tls enabled with the core configmaps to pass, got %v", err)
+	}
+}
+
+func TestDorisClusterValidateQueryProbe(t *testing.T) {
+	cluster := &DorisCluster{Spec: DorisClusterSpec{QueryProbe: &QueryProbe{Enabled: true, Query: "SELECT 1; DROP DATABASE db"}}}
+	if errs := cluster.validateQueryProbe(); len(errs) != 1 {
+		t.Fatalf("expected the query not a single SELECT statement to be rejected, got %v", errs)
+	}
+
+	cluster.Spec.QueryProbe.Query = "select count(*) from db.t"
+	if errs := cluster.validateQueryProbe(); len(errs) != 0 {
+		t.Fatalf("expected the SELECT statement to pass, got %v", errs)
+	}
+}
+
+func TestDorisClusterValidateConfigs(t *testing.T) {
+	ConfigValidator = func(ctx context.Context, cluster *DorisCluster) ([]string, []error) {
+		return []string{"unknown config"}, []error{errors.New("invalid port")}
+	}
+	defer func() { ConfigValidator = nil }()
+
+	validator := &DorisCluster{}
+	cluster := &DorisCluster{Spec: DorisClusterSpec{FeSpec: &FeSpec{}}}
+	warnings, err := validator.ValidateCreate(context.Background(), cluster)
+	if err == nil || len(warnings) != 1 {
+		t.Fatalf("expected the config errors rejected with warnings on create, warnings=%v, err=%v", warnings, err)
+	}
+	warnings, err = validator.ValidateUpdate(context.Background(), cluster, cluster)
+	if err == nil || len(warnings) != 1 {
+		t.Fatalf("expected the config errors rejected with warnings on update, warnings=%v, err=%v", warnings, err)
+	}
+}
+
+func TestDorisClusterValidateUpdate(t *testing.T) {
+	pvs := func(size, storageClass string) []PersistentVolume {
+		return []PersistentVolume{{
+			Name:      "be-storage",
+			MountPath: "/opt/apache-doris/be/storage",
+			PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}},
+			},
+		}}
+	}
+	replicas := func(r int32) *int32 { return &r }
+	newCluster := func() *DorisCluster {
+		return &DorisCluster{
+			Spec: DorisClusterSpec{
+				FeSpec:                       &FeSpec{},
+				BeSpec:                       &BeSpec{BaseSpec: BaseSpec{Replicas: replicas(5), PersistentVolumes: pvs("100Gi", "standard")}},
+				SharedPersistentVolumeClaims: []SharedPersistentVolumeClaim{{MountPath: "/opt/share", PersistentVolumeClaimName: "share"}},
+			},
+		}
+	}
+	old := newCluster()
+	MaxReplicationNumGetter = func(ctx context.Context, cluster *DorisCluster) (int32, error) { return 3, nil }
+	defer func() { MaxReplicationNumGetter = nil }()
+
+	tests := []struct {
+		name   string
+		mutate func(dcr *DorisCluster)
+		field  string
+	}{
+		{"unchanged", func(dcr *DorisCluster) {}, ""},
+		{"remove fe", func(dcr *DorisCluster) { dcr.Spec.FeSpec = nil }, "spec.feSpec"},
+		{"shrink storage", func(dcr *DorisCluster) { dcr.Spec.BeSpec.PersistentVolumes = pvs("50Gi", "standard") },
+			"spec.beSpec.persistentVolumes[0].persistentVolumeClaimSpec.resources.requests.storage"},
+		{"expand storage", func(dcr *DorisCluster) { dcr.Spec.BeSpec.PersistentVolumes = pvs("200Gi", "standard") }, ""},
+		{"change storage class", func(dcr *DorisCluster) { dcr.Spec.BeSpec.PersistentVolumes = pvs("100Gi", "fast") },
+			"spec.beSpec.persistentVolumes[0].persistentVolumeClaimSpec.storageClassName"},
+		{"reduce be replicas", func(dcr *DorisCluster) { dcr.Spec.BeSpec.Replicas = replicas(3) }, ""},
+		{"reduce be replicas below replication number", func(dcr *DorisCluster) { dcr.Spec.BeSpec.Replicas = replicas(2) }, "spec.beSpec.replicas"},
+		{"change shared pvc mount path", func(dcr *DorisCluster) { dcr.Spec.SharedPersistentVolumeClaims[0].MountPath = "/opt/share2" },
+			"spec.sharedPersistentVolumeClaims[0].mountPath"},
+	}
+
+	for _, test := range tests {
+		dcr := newCluster()
+		test.mutate(dcr)
+		errs := dcr.validateUpdate(context.Background(), old)
+		if test.field == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: expected no errors, got %v", test.name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), test.field+":") {
+			t.Errorf("%s: expected error of %s, got %v", test.name, test.field, errs)
+		}
+	}
+}
+
+func TestDorisClusterValidateUpdateFeNotReachable(t *testing.T) {
+	MaxReplicationNumGetter = func(ctx context.Context, cluster *DorisCluster) (int32, error) {
+		return 0, errors.New("fe not reachable")
+	}
+	defer func() { MaxReplicationNumGetter = nil }()
+
+	replicas := func(r int32) *int32 { return &r }
+	old := &DorisCluster{Spec: DorisClusterSpec{FeSpec: &FeSpec{}, BeSpec: &BeSpec{BaseSpec: BaseSpec{Replicas: replicas(3)}}}}
+	dcr := &DorisCluster{Spec: DorisClusterSpec{FeSpec: &FeSpec{}, BeSpec: &BeSpec{BaseSpec: BaseSpec{Replicas: replicas(1)}}}}
+	if errs := dcr.validateUpdate(context.Background(), old); len(errs) != 0 {
+		t.Errorf("expected be replicas not checked when fe not reachable, errs=%v", errs)
+	}
+}
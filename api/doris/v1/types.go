@@ -19,6 +19,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,14 +45,22 @@ type DorisClusterSpec struct {
 
 	//administrator for register or drop component from fe cluster. adminUser for all component register and operator drop component.
 	//+Deprecated, from 1.4.1 please use secret config username and password.
+	//operator moves the adminUser into a generated `kubernetes.io/basic-auth` secret named `{clusterName}-admin-user`, sets it as authSecret and removes adminUser from spec.
+	//the pods are rolling restarted once, as the credentials are mounted from the secret instead of env. the secret is kept when the cluster deleted.
 	AdminUser *AdminUser `json:"adminUser,omitempty"`
 
 	// the name of secret that type is `kubernetes.io/basic-auth` and contains keys username, password for management doris node in cluster as fe, be register.
 	// the password key is `password`. the username defaults to `root` and is omitempty.
 	AuthSecret string `json:"authSecret,omitempty"`
 
+	// PasswordRotation enables operator rotating the password of the management user in AuthSecret.
+	// when the password in AuthSecret changed or the rotation interval elapsed, operator logs in with the old password, sets the new password
+	// and verifies the login with it, then uses the new password for managing the cluster.
+	PasswordRotation *PasswordRotation `json:"passwordRotation,omitempty"`
+
 	// EnableRestartWhenConfigChange configmap monitoring, default is false.
-	// When EnableRestartWhenConfigChange is true, changing the doris core configmap will cause a rolling restart of the corresponding node
+	// When EnableRestartWhenConfigChange is true, changing the doris core configmap will cause a rolling restart of the corresponding node,
+	// but when all changed configs are mutable at runtime, they are applied by `ADMIN SET FRONTEND CONFIG` on fe and `/api/update_config` on be without restart.
 	EnableRestartWhenConfigChange bool `json:"enableRestartWhenConfigChange,omitempty"`
 
 	// KerberosInfo contains a series of access key files, Provides access to kerberos.
@@ -59,6 +68,124 @@ type DorisClusterSpec struct {
 
 	// SharedPersistentVolumeClaims used to configure the shared pvc that needs to be mounted on the pod
 	SharedPersistentVolumeClaims []SharedPersistentVolumeClaim `json:"sharedPersistentVolumeClaims,omitempty"`
+
+	// TLS specifies the certificates issued by operator for fe, be and cn.
+	// the tls configs are rendered into the core configmap, fe, be and cn should mount the configmap of fe.conf or be.conf when enabled.
+	TLS *TLS `json:"tls,omitempty"`
+
+	// Monitoring makes operator create the Prometheus Operator objects for scraping the metrics of fe, be and cn.
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// QueryProbe makes operator run a synthetic query on every fe at an interval, the latency and result are displayed in status and metrics.
+	QueryProbe *QueryProbe `json:"queryProbe,omitempty"`
+}
+
+// PasswordRotation describes the rotation of the password of the management user.
+type PasswordRotation struct {
+	// Interval is the period of rotating the password by operator, e.g. 720h. operator generates a random password and writes it back to AuthSecret when the interval elapsed.
+	// empty means the password is only rotated when the password in AuthSecret changed.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// QueryProbe describes the synthetic query that operator runs for checking the queries really work, pod readiness not represents that.
+type QueryProbe struct {
+	// Enabled enables operator probing by the query.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Query is the sql executed by probing, it should be lightweight. default `SELECT 1`.
+	// only a single SELECT statement is allowed, as it runs with the credentials of management user.
+	Query string `json:"query,omitempty"`
+
+	// Interval is the period of probing, default 1m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout of a probe, default 5s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes for regarding the target as unavailable, default 3.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// TLS describes the certificates issued by operator for the mysql and http endpoints of doris.
+type TLS struct {
+	// Enabled represents operator issue a ca for the cluster and certificates for components.
+	// the certificates are mounted in pods, and `enable_tls` with the paths of certificates are rendered into the core configmap of components.
+	// the core configmap of component must be mounted when enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CertificateValidity is the validity duration of issued certificates, default 8760h.
+	CertificateValidity *metav1.Duration `json:"certificateValidity,omitempty"`
+
+	// RenewBefore is how long before expiry the certificates are rotated, default 720h.
+	// the pods will be restarted for using the rotated certificates.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer that issues the certificates of components.
+	// when configured, the certificates are requested by cert-manager `Certificate` in place of the ca issued by operator.
+	// operator waits for the secrets of certificates ready, and restarts pods when cert-manager rotated the certificates.
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager issuer.
+type CertManagerIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, `Issuer` or `ClusterIssuer`. default `Issuer`.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, default `cert-manager.io`.
+	Group string `json:"group,omitempty"`
+}
+
+// Monitoring describes the Prometheus Operator objects created by operator for scraping the metrics of fe, be and cn.
+type Monitoring struct {
+	// Enabled represents operator create a `ServiceMonitor` or `PodMonitor` for every component.
+	// the crds of Prometheus Operator must be installed in the kubernetes cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Kind of the monitor objects, `ServiceMonitor` or `PodMonitor`. default `ServiceMonitor`.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	Kind string `json:"kind,omitempty"`
+
+	// Interval at which metrics should be scraped, default is the global scrape interval of Prometheus.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout is the timeout of scraping, default is the global scrape timeout of Prometheus.
+	ScrapeTimeout *metav1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// Labels are added on the monitor objects, used by the `serviceMonitorSelector` or `podMonitorSelector` of Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Rules makes operator create a `PrometheusRule` with the curated alerts of the cluster, the alerts select the metrics by the labels added by monitors.
+	Rules *MonitoringRules `json:"rules,omitempty"`
+}
+
+// MonitoringRules describes the Prometheus Operator `PrometheusRule` with the alerts of fe master missing, fe or be nodes not alive, heartbeat failures,
+// high disk usage, high compaction score and long-running decommission.
+type MonitoringRules struct {
+	// Enabled represents operator create a `PrometheusRule` for the cluster, the PrometheusRule is deleted when disabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are added on the PrometheusRule, used by the `ruleSelector` of Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// For is how long the condition of alerts must be true before firing, default 5m.
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// DiskUsagePercentThreshold fires the alert when the used percent of a be disk exceeds it, default 85.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	DiskUsagePercentThreshold *int32 `json:"diskUsagePercentThreshold,omitempty"`
+
+	// CompactionScoreThreshold fires the alert when the max base or cumulative compaction score of tablets on a be exceeds it, default 100.
+	// +kubebuilder:validation:Minimum=1
+	CompactionScoreThreshold *int32 `json:"compactionScoreThreshold,omitempty"`
+
+	// DecommissionTimeout fires the alert when backends are still decommissioning after it, default 2h.
+	DecommissionTimeout *metav1.Duration `json:"decommissionTimeout,omitempty"`
 }
 
 type SharedPersistentVolumeClaim struct {
@@ -135,6 +262,11 @@ type BeSpec struct {
 	// Enabling this configuration means injecting an ENV named BE_CPU_LIMIT with the value requests.cpu into the pod. This configuration will also appear in the 'be.conf' file inside the BE container.
 	// Changing this configuration will cause a BE rolling restart.
 	AutoResolveLimitCPU bool `json:"autoResolveLimitCPU,omitempty"`
+
+	// decommission be or not when scale down. default value is false.
+	// if true, operator will decommission the be nodes that will be removed and wait the tablets migrated before shrinking the statefulset.
+	// if false, the be statefulset will be scaled down directly.
+	EnableDecommission bool `json:"enableDecommission,omitempty"`
 }
 
 // FeAddress specify the fe address, please set it when you deploy fe outside k8s or deploy components use crd except fe, if not set .
@@ -280,6 +412,9 @@ type BaseSpec struct {
 
 	PersistentVolumes []PersistentVolume `json:"persistentVolumes,omitempty"`
 
+	//PersistentVolumeClaimRetentionPolicy describes whether the pvcs are deleted when scaling down the component or deleting the DorisCluster.
+	PersistentVolumeClaimRetentionPolicy *PersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
 	//SystemInitialization for fe, be and cn setting system parameters.
 	SystemInitialization *SystemInitialization `json:"systemInitialization,omitempty"`
 
@@ -330,8 +465,69 @@ type PersistentVolume struct {
 
 	//defines pvc provisioner
 	PVCProvisioner PVCProvisioner `json:"provisioner,omitempty"`
+
+	//StorageMedium marks the volume as a data directory of be on SSD or HDD. the mountPath is rendered into `storage_root_path` of be.conf
+	//with the medium and the capacity of pvc, the `storage_root_path` configured in be.conf will be overwritten.
+	//the capacity is not rendered when autoExpansion configured.
+	// +kubebuilder:validation:Enum=SSD;HDD
+	StorageMedium StorageMedium `json:"storageMedium,omitempty"`
+
+	//SpillStorage marks the volume as a spill directory of be, the mountPath is rendered into `spill_storage_root_path` of be.conf.
+	SpillStorage bool `json:"spillStorage,omitempty"`
+
+	//AutoExpansion expands the storage request of pvc automatically when the disk usage reported by be crosses the threshold.
+	//It only takes effect on be when the provisioner is `Operator`, and the StorageClass of pvc must allow volume expansion.
+	AutoExpansion *PVCAutoExpansion `json:"autoExpansion,omitempty"`
+}
+
+// StorageMedium defines the storage medium of be data directory.
+type StorageMedium string
+
+const (
+	StorageMediumSSD StorageMedium = "SSD"
+	StorageMediumHDD StorageMedium = "HDD"
+)
+
+// PVCAutoExpansion defines the policy of growing the storage request of pvc in steps when the disk usage crosses the threshold.
+type PVCAutoExpansion struct {
+	// UsedPercentThreshold is the disk used percent that triggers an expansion, default is 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	UsedPercentThreshold int32 `json:"usedPercentThreshold,omitempty"`
+
+	// Step is the storage size added to the pvc in one expansion, eg: 50Gi.
+	Step resource.Quantity `json:"step"`
+
+	// MaxCapacity is the ceiling of storage size that the pvc can be expanded to.
+	MaxCapacity resource.Quantity `json:"maxCapacity"`
 }
 
+// PersistentVolumeClaimRetentionPolicy describes the lifecycle of pvcs of the component.
+type PersistentVolumeClaimRetentionPolicy struct {
+	// WhenScaled specifies what happens to the pvcs of pods removed by scaling down.
+	// if not set, the pvcs of fe are deleted and the pvcs of be and cn are retained.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	WhenScaled PersistentVolumeClaimRetentionPolicyType `json:"whenScaled,omitempty"`
+
+	// WhenDeleted specifies what happens to the pvcs when the DorisCluster is deleted, default is `Retain`.
+	// the retained pvcs are re-attached when a DorisCluster with the same name is recreated in the namespace.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	WhenDeleted PersistentVolumeClaimRetentionPolicyType `json:"whenDeleted,omitempty"`
+}
+
+// PersistentVolumeClaimRetentionPolicyType is the action applied on pvcs when the pods removed or the cluster deleted.
+type PersistentVolumeClaimRetentionPolicyType string
+
+const (
+	// RetainPersistentVolumeClaimRetentionPolicyType keeps the pvcs, the pvcs are re-attached when the pods recreated.
+	RetainPersistentVolumeClaimRetentionPolicyType PersistentVolumeClaimRetentionPolicyType = "Retain"
+	// DeletePersistentVolumeClaimRetentionPolicyType deletes the pvcs that not used by pods.
+	DeletePersistentVolumeClaimRetentionPolicyType PersistentVolumeClaimRetentionPolicyType = "Delete"
+)
+
 // PVCProvisioner defines PVC provisioner
 type PVCProvisioner string
 
@@ -447,6 +643,156 @@ type DorisClusterStatus struct {
 
 	//describe broker cluster status, record running, creating and failed pods.
 	BrokerStatus *ComponentStatus `json:"brokerStatus,omitempty"`
+
+	// ClusterHealth represents the aggregated health of cluster computed from fe quorum, fe master, alive backends, cn and broker.
+	// +optional
+	ClusterHealth *ClusterHealth `json:"clusterHealth,omitempty"`
+
+	// Conditions represent the latest observations of cluster, the types are `Ready`, `Progressing`, `Degraded` and `VersionSkew`.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Nodes map the pods to the fe and backend nodes in doris, queried from fe master at a bounded interval.
+	// +optional
+	Nodes *NodesStatus `json:"nodes,omitempty"`
+
+	// Versions are the distinct versions of doris that the nodes run, more than one represents the version skew of nodes.
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+
+	// VersionSkewSince is the time the nodes are first observed running different versions, cleared when all nodes run the same version.
+	// +optional
+	VersionSkewSince *metav1.Time `json:"versionSkewSince,omitempty"`
+
+	// QueryProbe is the results of the synthetic query probing the fe nodes, displayed when QueryProbe enabled.
+	// +optional
+	QueryProbe *QueryProbeStatus `json:"queryProbe,omitempty"`
+}
+
+// NodeStatus describes a doris node registered in fe and the pod that runs it.
+type NodeStatus struct {
+	// PodName is the pod that runs the node, resolved from the host of node.
+	PodName string `json:"podName,omitempty"`
+
+	// Host is the address of node registered in fe.
+	Host string `json:"host,omitempty"`
+
+	// Role is `FOLLOWER` or `OBSERVER` for fe, `mix` or `computation` for backends.
+	Role string `json:"role,omitempty"`
+
+	// IsMaster represents the fe is master.
+	IsMaster bool `json:"isMaster,omitempty"`
+
+	// Alive represents the node is alive in the view of fe master.
+	Alive bool `json:"alive"`
+
+	// Version is the version of doris that the node runs.
+	Version string `json:"version,omitempty"`
+
+	// LastStartTime is the last time the node started.
+	LastStartTime string `json:"lastStartTime,omitempty"`
+
+	// TabletNum is the number of tablets on backend.
+	TabletNum int64 `json:"tabletNum,omitempty"`
+
+	// DataUsedCapacity is the size of data on backend.
+	DataUsedCapacity string `json:"dataUsedCapacity,omitempty"`
+
+	// Decommissioned represents the backend is in decommissioning or decommissioned.
+	Decommissioned bool `json:"decommissioned,omitempty"`
+}
+
+// QueryProbeStatus is the results of the synthetic query probing the fe nodes.
+type QueryProbeStatus struct {
+	// LastProbeTime is the last time the fe nodes probed.
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// Frontends are the results of probing every fe.
+	Frontends []QueryProbeResult `json:"frontends,omitempty"`
+}
+
+// QueryProbeResult is the result of the synthetic query on a target.
+type QueryProbeResult struct {
+	// Target is the host of fe or the name of compute group probed.
+	Target string `json:"target,omitempty"`
+
+	// PodName is the pod of fe probed.
+	PodName string `json:"podName,omitempty"`
+
+	// Succeeded represents the last probe succeeded.
+	Succeeded bool `json:"succeeded"`
+
+	// LatencyMilliseconds is the latency of the last probe.
+	LatencyMilliseconds int64 `json:"latencyMilliseconds,omitempty"`
+
+	// ConsecutiveFailures is the number of failed probes since the last succeeded.
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastProbeTime is the last time the target probed.
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// Message is the error of the last failed probe.
+	Message string `json:"message,omitempty"`
+}
+
+// NodesStatus is the view of fe and backend nodes queried from fe, it is refreshed at a bounded interval.
+type NodesStatus struct {
+	// LastRefreshTime is the last time the nodes are queried from fe.
+	LastRefreshTime metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// Frontends are the fe nodes displayed by `show frontends`.
+	Frontends []NodeStatus `json:"frontends,omitempty"`
+
+	// Backends are the backend nodes displayed by `show backends`.
+	Backends []NodeStatus `json:"backends,omitempty"`
+}
+
+type Health string
+
+const (
+	Green  Health = "green"
+	Yellow Health = "yellow"
+	Red    Health = "red"
+)
+
+// the types of conditions in the status of cluster.
+const (
+	ConditionReady       = "Ready"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+	ConditionVersionSkew = "VersionSkew"
+)
+
+// ClusterHealth describes the aggregated health of DorisCluster.
+type ClusterHealth struct {
+	// Health is red when fe master missing, fe followers lost quorum or no backend alive, yellow when some members of components not available, otherwise green.
+	Health Health `json:"health,omitempty"`
+
+	// FeMasterAvailable represents the master of fe exists and reachable by operator.
+	FeMasterAvailable bool `json:"feMasterAvailable,omitempty"`
+
+	// FeFollowerCount is the number of fe followers, the master is included.
+	FeFollowerCount int32 `json:"feFollowerCount,omitempty"`
+
+	// FeAliveFollowerCount is the number of alive fe followers.
+	FeAliveFollowerCount int32 `json:"feAliveFollowerCount,omitempty"`
+
+	// BeCount is the number of backends registered in fe.
+	BeCount int32 `json:"beCount,omitempty"`
+
+	// BeAliveCount is the number of alive backends.
+	BeAliveCount int32 `json:"beAliveCount,omitempty"`
+
+	// CnCount is the number of compute nodes registered in fe.
+	CnCount int32 `json:"cnCount,omitempty"`
+
+	// CnAliveCount is the number of alive compute nodes.
+	CnAliveCount int32 `json:"cnAliveCount,omitempty"`
+
+	// BrokerReady represents all pods of broker are ready.
+	BrokerReady bool `json:"brokerReady,omitempty"`
 }
 
 type CnStatus struct {
@@ -480,6 +826,56 @@ type ComponentStatus struct {
 	RunningMembers []string `json:"runningInstances,omitempty"`
 
 	ComponentCondition ComponentCondition `json:"componentCondition"`
+
+	// DecommissionStatus records the progress of be nodes in decommissioning when scale down with decommission.
+	// +optional
+	DecommissionStatus *DecommissionStatus `json:"decommissionStatus,omitempty"`
+}
+
+// DecommissionStatus describes the tablets migration progress of decommissioning backends.
+type DecommissionStatus struct {
+	// StartTime is the time that operator started to decommission backends.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// LastProgressTime is the last time the tablets number of decommissioning backends decreased.
+	LastProgressTime metav1.Time `json:"lastProgressTime,omitempty"`
+
+	// EstimatedCompletionTime is estimated by the observed tablets migration rate since StartTime.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// InitialTabletNum is the total tablets number of decommissioning backends when decommission started.
+	InitialTabletNum int64 `json:"initialTabletNum,omitempty"`
+
+	// RemainingTabletNum is the total tablets number that not migrated from decommissioning backends.
+	RemainingTabletNum int64 `json:"remainingTabletNum,omitempty"`
+
+	// Stalled means the tablets number of decommissioning backends has not decreased for a long time.
+	Stalled bool `json:"stalled,omitempty"`
+
+	// Backends display the decommission progress of every backend.
+	Backends []BackendDecommissionStatus `json:"backends,omitempty"`
+}
+
+// BackendDecommissionStatus describes the decommission progress of one backend.
+type BackendDecommissionStatus struct {
+	// BackendId is the id of backend in doris.
+	BackendId string `json:"backendId,omitempty"`
+
+	// Host is the address of backend registered in fe.
+	Host string `json:"host,omitempty"`
+
+	// InitialTabletNum is the tablets number on backend when decommission started.
+	InitialTabletNum int64 `json:"initialTabletNum,omitempty"`
+
+	// TabletNum is the current tablets number on backend.
+	TabletNum int64 `json:"tabletNum,omitempty"`
+
+	// InitialDataUsedCapacity is the data size on backend when decommission started.
+	InitialDataUsedCapacity string `json:"initialDataUsedCapacity,omitempty"`
+
+	// DataUsedCapacity is the current data size on backend.
+	DataUsedCapacity string `json:"dataUsedCapacity,omitempty"`
 }
 
 type ComponentCondition struct {
@@ -505,6 +901,7 @@ const (
 	Upgrading        ComponentPhase = "upgrading"
 	Scaling          ComponentPhase = "scaling"
 	Restarting       ComponentPhase = "restarting"
+	Decommissioning  ComponentPhase = "decommissioning"
 )
 
 // +genclient
@@ -513,6 +910,7 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=dcr
+// +kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.clusterHealth.health`
 // +kubebuilder:printcolumn:name="FeStatus",type=string,JSONPath=`.status.feStatus.componentCondition.phase`
 // +kubebuilder:printcolumn:name="BeStatus",type=string,JSONPath=`.status.beStatus.componentCondition.phase`
 // +kubebuilder:printcolumn:name="CnStatus",type=string,JSONPath=`.status.cnStatus.componentCondition.phase`
@@ -0,0 +1,416 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	commonv2 "github.com/apache/doris-operator/api/common/v2"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation stores the fields of v1 spec that v2 can not represent in the v2 object, eg: the deprecated fields.
+// the fields are restored from it when the v2 spec is converted back.
+const ConversionDataAnnotation = "apache.doris.org/v1-spec"
+
+var _ conversion.Convertible = &DorisCluster{}
+
+// ConvertTo converts this DorisCluster to the hub version v1.
+func (dcr *DorisCluster) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*dorisv1.DorisCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1 DorisCluster but got %T", hub)
+	}
+
+	spec, err := convertSpecToV1(&dcr.Spec)
+	if err != nil {
+		return err
+	}
+	dst.ObjectMeta = *dcr.ObjectMeta.DeepCopy()
+	if data, ok := dst.Annotations[ConversionDataAnnotation]; ok {
+		delete(dst.Annotations, ConversionDataAnnotation)
+		restored := &dorisv1.DorisClusterSpec{}
+		if err := json.Unmarshal([]byte(data), restored); err != nil {
+			return fmt.Errorf("unmarshal annotation %s failed, err=%s", ConversionDataAnnotation, err.Error())
+		}
+		restoreV1OnlyFields(spec, restored)
+	}
+
+	dst.Spec = *spec
+	dst.Status = *dcr.Status.DeepCopy()
+	return nil
+}
+
+// ConvertFrom converts the hub version v1 to this DorisCluster.
+func (dcr *DorisCluster) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*dorisv1.DorisCluster)
+	if !ok {
+		return fmt.Errorf("expected a v1 DorisCluster but got %T", hub)
+	}
+
+	dcr.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dcr.Spec = *convertSpecFromV1(&src.Spec)
+	dcr.Status = *src.Status.DeepCopy()
+
+	// keep the v1 only fields when the v1 spec can not be rebuilt from v2 spec.
+	if back, err := convertSpecToV1(&dcr.Spec); err != nil || !equality.Semantic.DeepEqual(back, &src.Spec) {
+		data, err := json.Marshal(v1OnlyFields(&src.Spec))
+		if err != nil {
+			return err
+		}
+		if dcr.Annotations == nil {
+			dcr.Annotations = map[string]string{}
+		}
+		dcr.Annotations[ConversionDataAnnotation] = string(data)
+	}
+	return nil
+}
+
+func convertSpecFromV1(src *dorisv1.DorisClusterSpec) *DorisClusterSpec {
+	spec := &DorisClusterSpec{
+		AuthSecret:                    src.AuthSecret,
+		EnableRestartWhenConfigChange: src.EnableRestartWhenConfigChange,
+	}
+	spec.Monitoring = monitoringFromV1(src.Monitoring)
+	spec.QueryProbe = (*commonv2.QueryProbe)(src.QueryProbe.DeepCopy())
+	if src.PasswordRotation != nil {
+		spec.PasswordRotation = &commonv2.PasswordRotation{Interval: src.PasswordRotation.Interval.DeepCopy()}
+	}
+	if src.KerberosInfo != nil {
+		spec.KerberosInfo = &commonv2.KerberosInfo{Krb5ConfigMap: src.KerberosInfo.Krb5ConfigMap, KeytabSecretName: src.KerberosInfo.KeytabSecretName, KeytabPath: src.KerberosInfo.KeytabPath}
+	}
+	for _, spvc := range src.SharedPersistentVolumeClaims {
+		spec.SharedPersistentVolumeClaims = append(spec.SharedPersistentVolumeClaims, SharedPersistentVolumeClaim{
+			MountPath:                 spvc.MountPath,
+			PersistentVolumeClaimName: spvc.PersistentVolumeClaimName,
+			SupportComponents:         append([]dorisv1.ComponentType(nil), spvc.SupportComponents...),
+		})
+	}
+	if src.TLS != nil {
+		spec.TLS = &commonv2.TLS{Enabled: src.TLS.Enabled, CertificateValidity: src.TLS.CertificateValidity.DeepCopy(), RenewBefore: src.TLS.RenewBefore.DeepCopy()}
+		if src.TLS.IssuerRef != nil {
+			spec.TLS.IssuerRef = &commonv2.CertManagerIssuerRef{Name: src.TLS.IssuerRef.Name, Kind: src.TLS.IssuerRef.Kind, Group: src.TLS.IssuerRef.Group}
+		}
+	}
+
+	if src.FeSpec != nil {
+		spec.FeSpec = &FeSpec{ComponentTemplate: templateFromV1(&src.FeSpec.BaseSpec)}
+		if src.FeSpec.ElectionNumber != nil {
+			en := *src.FeSpec.ElectionNumber
+			spec.FeSpec.ElectionNumber = &en
+		}
+	}
+	if src.BeSpec != nil {
+		spec.BeSpec = &BeSpec{
+			ComponentTemplate:     templateFromV1(&src.BeSpec.BaseSpec),
+			EnableWorkloadGroup:   src.BeSpec.EnableWorkloadGroup,
+			SkipDefaultSystemInit: src.BeSpec.SkipDefaultSystemInit,
+			EnableFeAffinity:      src.BeSpec.EnableFeAffinity,
+			AutoResolveLimitCPU:   src.BeSpec.AutoResolveLimitCPU,
+			EnableDecommission:    src.BeSpec.EnableDecommission,
+		}
+	}
+	if src.CnSpec != nil {
+		spec.CnSpec = &CnSpec{
+			ComponentTemplate:     templateFromV1(&src.CnSpec.BaseSpec),
+			SkipDefaultSystemInit: src.CnSpec.SkipDefaultSystemInit,
+			AutoScalingPolicy:     src.CnSpec.AutoScalingPolicy.DeepCopy(),
+			AutoResolveLimitCPU:   src.CnSpec.AutoResolveLimitCPU,
+		}
+	}
+	if src.BrokerSpec != nil {
+		spec.BrokerSpec = &BrokerSpec{
+			ComponentTemplate: templateFromV1(&src.BrokerSpec.BaseSpec),
+			KickOffAffinityBe: src.BrokerSpec.KickOffAffinityBe,
+		}
+	}
+	return spec
+}
+
+func convertSpecToV1(src *DorisClusterSpec) (*dorisv1.DorisClusterSpec, error) {
+	spec := &dorisv1.DorisClusterSpec{
+		AuthSecret:                    src.AuthSecret,
+		EnableRestartWhenConfigChange: src.EnableRestartWhenConfigChange,
+	}
+	spec.Monitoring = monitoringToV1(src.Monitoring)
+	spec.QueryProbe = (*dorisv1.QueryProbe)(src.QueryProbe.DeepCopy())
+	if src.PasswordRotation != nil {
+		spec.PasswordRotation = &dorisv1.PasswordRotation{Interval: src.PasswordRotation.Interval.DeepCopy()}
+	}
+	if src.KerberosInfo != nil {
+		spec.KerberosInfo = &dorisv1.KerberosInfo{Krb5ConfigMap: src.KerberosInfo.Krb5ConfigMap, KeytabSecretName: src.KerberosInfo.KeytabSecretName, KeytabPath: src.KerberosInfo.KeytabPath}
+	}
+	for _, spvc := range src.SharedPersistentVolumeClaims {
+		spec.SharedPersistentVolumeClaims = append(spec.SharedPersistentVolumeClaims, dorisv1.SharedPersistentVolumeClaim{
+			MountPath:                 spvc.MountPath,
+			PersistentVolumeClaimName: spvc.PersistentVolumeClaimName,
+			SupportComponents:         append([]dorisv1.ComponentType(nil), spvc.SupportComponents...),
+		})
+	}
+	if src.TLS != nil {
+		spec.TLS = &dorisv1.TLS{Enabled: src.TLS.Enabled, CertificateValidity: src.TLS.CertificateValidity.DeepCopy(), RenewBefore: src.TLS.RenewBefore.DeepCopy()}
+		if src.TLS.IssuerRef != nil {
+			spec.TLS.IssuerRef = &dorisv1.CertManagerIssuerRef{Name: src.TLS.IssuerRef.Name, Kind: src.TLS.IssuerRef.Kind, Group: src.TLS.IssuerRef.Group}
+		}
+	}
+
+	var errs []error
+	if src.FeSpec != nil {
+		spec.FeSpec = &dorisv1.FeSpec{}
+		if src.FeSpec.ElectionNumber != nil {
+			en := *src.FeSpec.ElectionNumber
+			spec.FeSpec.ElectionNumber = &en
+		}
+		errs = append(errs, templateToV1("feSpec", &src.FeSpec.ComponentTemplate, &spec.FeSpec.BaseSpec)...)
+	}
+	if src.BeSpec != nil {
+		spec.BeSpec = &dorisv1.BeSpec{
+			EnableWorkloadGroup:   src.BeSpec.EnableWorkloadGroup,
+			SkipDefaultSystemInit: src.BeSpec.SkipDefaultSystemInit,
+			EnableFeAffinity:      src.BeSpec.EnableFeAffinity,
+			AutoResolveLimitCPU:   src.BeSpec.AutoResolveLimitCPU,
+			EnableDecommission:    src.BeSpec.EnableDecommission,
+		}
+		errs = append(errs, templateToV1("beSpec", &src.BeSpec.ComponentTemplate, &spec.BeSpec.BaseSpec)...)
+	}
+	if src.CnSpec != nil {
+		spec.CnSpec = &dorisv1.CnSpec{
+			SkipDefaultSystemInit: src.CnSpec.SkipDefaultSystemInit,
+			AutoScalingPolicy:     src.CnSpec.AutoScalingPolicy.DeepCopy(),
+			AutoResolveLimitCPU:   src.CnSpec.AutoResolveLimitCPU,
+		}
+		errs = append(errs, templateToV1("cnSpec", &src.CnSpec.ComponentTemplate, &spec.CnSpec.BaseSpec)...)
+	}
+	if src.BrokerSpec != nil {
+		spec.BrokerSpec = &dorisv1.BrokerSpec{KickOffAffinityBe: src.BrokerSpec.KickOffAffinityBe}
+		errs = append(errs, templateToV1("brokerSpec", &src.BrokerSpec.ComponentTemplate, &spec.BrokerSpec.BaseSpec)...)
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("convert DorisCluster to v1 failed, errs=%v", errs)
+	}
+	return spec, nil
+}
+
+// v1OnlyFields return the spec that only has the fields v2 can not represent. the adminUser is kept with the password for a lossless round trip,
+// the password is already in the spec of v1, the annotation exposes nothing new.
+func v1OnlyFields(src *dorisv1.DorisClusterSpec) *dorisv1.DorisClusterSpec {
+	spec := &dorisv1.DorisClusterSpec{}
+	if src.AdminUser != nil {
+		spec.AdminUser = src.AdminUser.DeepCopy()
+	}
+	keep := func(bs *dorisv1.BaseSpec) dorisv1.BaseSpec {
+		return dorisv1.BaseSpec{FeAddress: bs.FeAddress.DeepCopy(), ConfigMapInfo: dorisv1.ConfigMapInfo{ResolveKey: bs.ConfigMapInfo.ResolveKey}}
+	}
+	if src.FeSpec != nil {
+		spec.FeSpec = &dorisv1.FeSpec{BaseSpec: keep(&src.FeSpec.BaseSpec)}
+	}
+	if src.BeSpec != nil {
+		spec.BeSpec = &dorisv1.BeSpec{BaseSpec: keep(&src.BeSpec.BaseSpec)}
+	}
+	if src.CnSpec != nil {
+		spec.CnSpec = &dorisv1.CnSpec{BaseSpec: keep(&src.CnSpec.BaseSpec)}
+	}
+	if src.BrokerSpec != nil {
+		spec.BrokerSpec = &dorisv1.BrokerSpec{BaseSpec: keep(&src.BrokerSpec.BaseSpec)}
+	}
+	return spec
+}
+
+// restoreV1OnlyFields restores the fields of v1 that not represented in v2.
+func restoreV1OnlyFields(spec, restored *dorisv1.DorisClusterSpec) {
+	spec.AdminUser = restored.AdminUser
+	restore := func(bs, rbs *dorisv1.BaseSpec) {
+		bs.FeAddress = rbs.FeAddress
+		bs.ConfigMapInfo.ResolveKey = rbs.ConfigMapInfo.ResolveKey
+	}
+	if spec.FeSpec != nil && restored.FeSpec != nil {
+		restore(&spec.FeSpec.BaseSpec, &restored.FeSpec.BaseSpec)
+	}
+	if spec.BeSpec != nil && restored.BeSpec != nil {
+		restore(&spec.BeSpec.BaseSpec, &restored.BeSpec.BaseSpec)
+	}
+	if spec.CnSpec != nil && restored.CnSpec != nil {
+		restore(&spec.CnSpec.BaseSpec, &restored.CnSpec.BaseSpec)
+	}
+	if spec.BrokerSpec != nil && restored.BrokerSpec != nil {
+		restore(&spec.BrokerSpec.BaseSpec, &restored.BrokerSpec.BaseSpec)
+	}
+}
+
+func templateFromV1(bs *dorisv1.BaseSpec) commonv2.ComponentTemplate {
+	bs = bs.DeepCopy()
+	t := commonv2.ComponentTemplate{
+		Replicas:                 bs.Replicas,
+		Image:                    bs.Image,
+		ImagePullSecrets:         bs.ImagePullSecrets,
+		StartTimeout:             bs.StartTimeout,
+		LiveTimeout:              bs.LiveTimeout,
+		ResourceRequirements:     bs.ResourceRequirements,
+		Labels:                   bs.PodLabels,
+		Annotations:              bs.Annotations,
+		Affinity:                 bs.Affinity,
+		Tolerations:              bs.Tolerations,
+		NodeSelector:             bs.NodeSelector,
+		ServiceAccount:           bs.ServiceAccount,
+		HostAliases:              bs.HostAliases,
+		SecurityContext:          bs.SecurityContext,
+		ContainerSecurityContext: bs.ContainerSecurityContext,
+		EnvVars:                  bs.EnvVars,
+		SystemInitialization:     (*commonv2.SystemInitialization)(bs.SystemInitialization),
+		ReadinessProbePolicy:     (*commonv2.ReadinessProbePolicy)(bs.ReadinessProbePolicy),
+	}
+	for _, s := range bs.Secrets {
+		t.Secrets = append(t.Secrets, commonv2.Secret(s))
+	}
+	if bs.ConfigMapInfo.ConfigMapName != "" {
+		t.ConfigMaps = append(t.ConfigMaps, commonv2.ConfigMap{Name: bs.ConfigMapInfo.ConfigMapName})
+	}
+	for _, cm := range bs.ConfigMapInfo.ConfigMaps {
+		t.ConfigMaps = append(t.ConfigMaps, commonv2.ConfigMap{Name: cm.ConfigMapName, MountPath: cm.MountPath})
+	}
+	for _, pv := range bs.PersistentVolumes {
+		cpv := commonv2.PersistentVolume{
+			Name:                      pv.Name,
+			PersistentVolumeClaimSpec: pv.PersistentVolumeClaimSpec,
+			Annotations:               pv.Annotations,
+			Provisioner:               commonv2.PVCProvisioner(pv.PVCProvisioner),
+			StorageMedium:             string(pv.StorageMedium),
+			SpillStorage:              pv.SpillStorage,
+			AutoExpansion:             (*commonv2.PVCAutoExpansion)(pv.AutoExpansion),
+		}
+		if pv.MountPath != "" {
+			cpv.MountPaths = []string{pv.MountPath}
+		}
+		t.PersistentVolumes = append(t.PersistentVolumes, cpv)
+	}
+	if bs.PersistentVolumeClaimRetentionPolicy != nil {
+		t.PersistentVolumeClaimRetentionPolicy = &commonv2.PersistentVolumeClaimRetentionPolicy{
+			WhenScaled:  string(bs.PersistentVolumeClaimRetentionPolicy.WhenScaled),
+			WhenDeleted: string(bs.PersistentVolumeClaimRetentionPolicy.WhenDeleted),
+		}
+	}
+	if bs.Service != nil {
+		t.Service = &commonv2.ExportService{Type: bs.Service.Type, Annotations: bs.Service.Annotations, LoadBalancerIP: bs.Service.LoadBalancerIP}
+		for _, sp := range bs.Service.ServicePorts {
+			t.Service.PortMaps = append(t.Service.PortMaps, commonv2.PortMap(sp))
+		}
+	}
+	return t
+}
+
+// templateToV1 converts the template to the BaseSpec of v1, the values that v1 not supported are returned as errors.
+func templateToV1(component string, t *commonv2.ComponentTemplate, bs *dorisv1.BaseSpec) []error {
+	t = t.DeepCopy()
+	bs.Replicas = t.Replicas
+	bs.Image = t.Image
+	bs.ImagePullSecrets = t.ImagePullSecrets
+	bs.StartTimeout = t.StartTimeout
+	bs.LiveTimeout = t.LiveTimeout
+	bs.ResourceRequirements = t.ResourceRequirements
+	bs.PodLabels = t.Labels
+	bs.Annotations = t.Annotations
+	bs.Affinity = t.Affinity
+	bs.Tolerations = t.Tolerations
+	bs.NodeSelector = t.NodeSelector
+	bs.ServiceAccount = t.ServiceAccount
+	bs.HostAliases = t.HostAliases
+	bs.SecurityContext = t.SecurityContext
+	bs.ContainerSecurityContext = t.ContainerSecurityContext
+	bs.EnvVars = t.EnvVars
+	bs.SystemInitialization = (*dorisv1.SystemInitialization)(t.SystemInitialization)
+	bs.ReadinessProbePolicy = (*dorisv1.ReadinessProbePolicy)(t.ReadinessProbePolicy)
+	for _, s := range t.Secrets {
+		bs.Secrets = append(bs.Secrets, dorisv1.Secret(s))
+	}
+	for _, cm := range t.ConfigMaps {
+		// the first configmap without mount path is the configmap of config file.
+		if cm.MountPath == "" && bs.ConfigMapInfo.ConfigMapName == "" {
+			bs.ConfigMapInfo.ConfigMapName = cm.Name
+			continue
+		}
+		bs.ConfigMapInfo.ConfigMaps = append(bs.ConfigMapInfo.ConfigMaps, dorisv1.MountConfigMapInfo{ConfigMapName: cm.Name, MountPath: cm.MountPath})
+	}
+
+	var errs []error
+	for i, pv := range t.PersistentVolumes {
+		if len(pv.MountPaths) > 1 {
+			errs = append(errs, fmt.Errorf("%s.persistentVolumes[%d].mountPaths: DorisCluster supports one mount path for a volume", component, i))
+			continue
+		}
+		dpv := dorisv1.PersistentVolume{
+			Name:                      pv.Name,
+			PersistentVolumeClaimSpec: pv.PersistentVolumeClaimSpec,
+			Annotations:               pv.Annotations,
+			PVCProvisioner:            dorisv1.PVCProvisioner(pv.Provisioner),
+			StorageMedium:             dorisv1.StorageMedium(pv.StorageMedium),
+			SpillStorage:              pv.SpillStorage,
+			AutoExpansion:             (*dorisv1.PVCAutoExpansion)(pv.AutoExpansion),
+		}
+		if len(pv.MountPaths) == 1 {
+			dpv.MountPath = pv.MountPaths[0]
+		}
+		bs.PersistentVolumes = append(bs.PersistentVolumes, dpv)
+	}
+	if t.PersistentVolumeClaimRetentionPolicy != nil {
+		bs.PersistentVolumeClaimRetentionPolicy = &dorisv1.PersistentVolumeClaimRetentionPolicy{
+			WhenScaled:  dorisv1.PersistentVolumeClaimRetentionPolicyType(t.PersistentVolumeClaimRetentionPolicy.WhenScaled),
+			WhenDeleted: dorisv1.PersistentVolumeClaimRetentionPolicyType(t.PersistentVolumeClaimRetentionPolicy.WhenDeleted),
+		}
+	}
+	if t.Service != nil {
+		bs.Service = &dorisv1.ExportService{Type: t.Service.Type, Annotations: t.Service.Annotations, LoadBalancerIP: t.Service.LoadBalancerIP}
+		for _, pm := range t.Service.PortMaps {
+			bs.Service.ServicePorts = append(bs.Service.ServicePorts, dorisv1.DorisServicePort(pm))
+		}
+	}
+	return errs
+}
+
+func monitoringFromV1(m *dorisv1.Monitoring) *commonv2.Monitoring {
+	if m == nil {
+		return nil
+	}
+	m = m.DeepCopy()
+	return &commonv2.Monitoring{
+		Enabled:       m.Enabled,
+		Kind:          m.Kind,
+		Interval:      m.Interval,
+		ScrapeTimeout: m.ScrapeTimeout,
+		Labels:        m.Labels,
+		Rules:         (*commonv2.MonitoringRules)(m.Rules),
+	}
+}
+
+func monitoringToV1(m *commonv2.Monitoring) *dorisv1.Monitoring {
+	if m == nil {
+		return nil
+	}
+	m = m.DeepCopy()
+	return &dorisv1.Monitoring{
+		Enabled:       m.Enabled,
+		Kind:          m.Kind,
+		Interval:      m.Interval,
+		ScrapeTimeout: m.ScrapeTimeout,
+		Labels:        m.Labels,
+		Rules:         (*dorisv1.MonitoringRules)(m.Rules),
+	}
+}
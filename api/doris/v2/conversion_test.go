@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newV1DorisCluster() *dorisv1.DorisCluster {
+	replicas := int32(3)
+	return &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: dorisv1.DorisClusterSpec{
+			FeSpec: &dorisv1.FeSpec{
+				ElectionNumber: &replicas,
+				BaseSpec: dorisv1.BaseSpec{
+					Replicas:      &replicas,
+					Image:         "apache/doris:fe-2.1.8",
+					PodLabels:     map[string]string{"app": "fe"},
+					ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "fe-conf", ConfigMaps: []dorisv1.MountConfigMapInfo{{ConfigMapName: "extra", MountPath: "/opt/extra"}}},
+					PersistentVolumes: []dorisv1.PersistentVolume{{
+						Name:      "meta",
+						MountPath: "/opt/apache-doris/fe/doris-meta",
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}},
+						},
+						PVCProvisioner: dorisv1.PVCProvisionerOperator,
+					}},
+					PersistentVolumeClaimRetentionPolicy: &dorisv1.PersistentVolumeClaimRetentionPolicy{WhenDeleted: dorisv1.DeletePersistentVolumeClaimRetentionPolicyType},
+					Service:                              &dorisv1.ExportService{Type: corev1.ServiceTypeNodePort, ServicePorts: []dorisv1.DorisServicePort{{NodePort: 31001, TargetPort: 9030}}},
+				},
+			},
+			BeSpec: &dorisv1.BeSpec{
+				BaseSpec:            dorisv1.BaseSpec{Replicas: &replicas, Image: "apache/doris:be-2.1.8"},
+				EnableWorkloadGroup: true,
+			},
+			AuthSecret: "doris-auth",
+			Monitoring: &dorisv1.Monitoring{Enabled: true, Kind: "PodMonitor", Labels: map[string]string{"release": "prometheus"},
+				Rules: &dorisv1.MonitoringRules{Enabled: true, DecommissionTimeout: &metav1.Duration{Duration: time.Hour}}},
+		},
+		Status: dorisv1.DorisClusterStatus{FEStatus: &dorisv1.ComponentStatus{AccessService: "test-fe-service"}},
+	}
+}
+
+func TestDorisClusterConvertRoundTrip(t *testing.T) {
+	src := newV1DorisCluster()
+	v2dcr := &DorisCluster{}
+	if err := v2dcr.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	if _, ok := v2dcr.Annotations[ConversionDataAnnotation]; ok {
+		t.Errorf("expected no conversion annotation when the conversion is lossless")
+	}
+	if v2dcr.Spec.FeSpec.Labels["app"] != "fe" || len(v2dcr.Spec.FeSpec.ConfigMaps) != 2 || v2dcr.Spec.FeSpec.PersistentVolumes[0].MountPaths[0] != "/opt/apache-doris/fe/doris-meta" {
+		t.Errorf("unexpected v2 fe spec %+v", v2dcr.Spec.FeSpec)
+	}
+
+	dst := &dorisv1.DorisCluster{}
+	if err := v2dcr.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+}
+
+func TestDorisClusterConvertV1OnlyFields(t *testing.T) {
+	src := newV1DorisCluster()
+	src.Spec.AdminUser = &dorisv1.AdminUser{Name: "root"}
+	src.Spec.BeSpec.ConfigMapInfo.ResolveKey = "be.conf"
+	src.Spec.BeSpec.FeAddress = &dorisv1.FeAddress{ServiceName: "fe-service"}
+
+	v2dcr := &DorisCluster{}
+	if err := v2dcr.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	if _, ok := v2dcr.Annotations[ConversionDataAnnotation]; !ok {
+		t.Fatalf("expected the v1 spec stored in annotation %s", ConversionDataAnnotation)
+	}
+
+	dst := &dorisv1.DorisCluster{}
+	if err := v2dcr.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+
+	// the v1 only fields are kept when the v2 spec is modified.
+	replicas := int32(5)
+	v2dcr.Spec.BeSpec.Replicas = &replicas
+	dst = &dorisv1.DorisCluster{}
+	if err := v2dcr.ConvertTo(dst); err != nil {
+		t.Fatalf("convert modified v2 to v1 failed, err=%s", err.Error())
+	}
+	if *dst.Spec.BeSpec.Replicas != 5 {
+		t.Errorf("expected be replicas 5, got %d", *dst.Spec.BeSpec.Replicas)
+	}
+	if dst.Spec.AdminUser == nil || dst.Spec.BeSpec.ConfigMapInfo.ResolveKey != "be.conf" || dst.Spec.BeSpec.FeAddress == nil {
+		t.Errorf("expected v1 only fields restored, got %+v", dst.Spec)
+	}
+	if _, ok := dst.Annotations[ConversionDataAnnotation]; ok {
+		t.Errorf("expected annotation %s removed from v1", ConversionDataAnnotation)
+	}
+}
+
+func TestDorisClusterConvertRoundTripWithPassword(t *testing.T) {
+	src := newV1DorisCluster()
+	src.Spec.AdminUser = &dorisv1.AdminUser{Name: "root", Password: "secret-password"}
+
+	v2dcr := &DorisCluster{}
+	if err := v2dcr.ConvertFrom(src); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+
+	// a read-modify-write through v2 keeps the password of adminUser.
+	replicas := int32(5)
+	v2dcr.Spec.FeSpec.Replicas = &replicas
+	dst := &dorisv1.DorisCluster{}
+	if err := v2dcr.ConvertTo(dst); err != nil {
+		t.Fatalf("convert to v1 failed, err=%s", err.Error())
+	}
+	if dst.Spec.AdminUser == nil || dst.Spec.AdminUser.Name != "root" || dst.Spec.AdminUser.Password != "secret-password" {
+		t.Errorf("expected the admin user restored with password, got %+v", dst.Spec.AdminUser)
+	}
+	dst.Spec.FeSpec.Replicas = src.Spec.FeSpec.Replicas
+	if !equality.Semantic.DeepEqual(src, dst) {
+		t.Errorf("round trip changed the DorisCluster, src=%+v, dst=%+v", src.Spec, dst.Spec)
+	}
+}
+
+func TestDorisClusterConvertToRejectsMultipleMountPaths(t *testing.T) {
+	v2dcr := &DorisCluster{}
+	if err := v2dcr.ConvertFrom(newV1DorisCluster()); err != nil {
+		t.Fatalf("convert from v1 failed, err=%s", err.Error())
+	}
+	v2dcr.Spec.FeSpec.PersistentVolumes[0].MountPaths = append(v2dcr.Spec.FeSpec.PersistentVolumes[0].MountPaths, "/opt/other")
+	if err := v2dcr.ConvertTo(&dorisv1.DorisCluster{}); err == nil {
+		t.Errorf("expected error when a volume has multiple mount paths")
+	}
+}
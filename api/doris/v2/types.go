@@ -0,0 +1,158 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v2
+
+import (
+	commonv2 "github.com/apache/doris-operator/api/common/v2"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DorisClusterSpec defines the desired state of DorisCluster, the components share the pod template with DorisDisaggregatedCluster.
+type DorisClusterSpec struct {
+	// FeSpec defines the fe cluster state that will be created by operator.
+	FeSpec *FeSpec `json:"feSpec,omitempty"`
+
+	// BeSpec defines the be cluster state pod that will be created by operator.
+	BeSpec *BeSpec `json:"beSpec,omitempty"`
+
+	// CnSpec defines the cn cluster state that will be created by operator.
+	CnSpec *CnSpec `json:"cnSpec,omitempty"`
+
+	// BrokerSpec defines the broker state that will be created by operator.
+	BrokerSpec *BrokerSpec `json:"brokerSpec,omitempty"`
+
+	// AuthSecret is the name of `kubernetes.io/basic-auth` secret that contains the username and password of management user.
+	AuthSecret string `json:"authSecret,omitempty"`
+
+	// PasswordRotation enables operator rotating the password of the management user in AuthSecret.
+	PasswordRotation *commonv2.PasswordRotation `json:"passwordRotation,omitempty"`
+
+	// EnableRestartWhenConfigChange applies the changed configs of fe.conf and be.conf, the configs that can not be modified at runtime restart the pods.
+	EnableRestartWhenConfigChange bool `json:"enableRestartWhenConfigChange,omitempty"`
+
+	// KerberosInfo contains a series of access key files, Provides access to kerberos.
+	KerberosInfo *commonv2.KerberosInfo `json:"kerberosInfo,omitempty"`
+
+	// SharedPersistentVolumeClaims used to configure the shared pvc that needs to be mounted on the pod.
+	SharedPersistentVolumeClaims []SharedPersistentVolumeClaim `json:"sharedPersistentVolumeClaims,omitempty"`
+
+	// TLS specifies the certificates issued by operator for fe, be and cn.
+	TLS *commonv2.TLS `json:"tls,omitempty"`
+
+	// Monitoring makes operator create the Prometheus Operator objects for scraping the metrics of components.
+	Monitoring *commonv2.Monitoring `json:"monitoring,omitempty"`
+
+	// QueryProbe makes operator run a synthetic query on every fe at an interval, the latency and result are displayed in status and metrics.
+	QueryProbe *commonv2.QueryProbe `json:"queryProbe,omitempty"`
+}
+
+// SharedPersistentVolumeClaim is the ReadWriteMany pvc mounted by the pods of components.
+type SharedPersistentVolumeClaim struct {
+	// MountPath must be an absolute path.
+	MountPath string `json:"mountPath,omitempty"`
+
+	// PersistentVolumeClaimName is the name of shared pvc, the AccessModes must include ReadWriteMany.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// SupportComponents are the components that mount the pvc, all components mount it when empty.
+	SupportComponents []dorisv1.ComponentType `json:"supportComponents,omitempty"`
+}
+
+// FeSpec describes a template for creating copies of a fe software service.
+type FeSpec struct {
+	// the number of fe in election. electionNumber <= replicas, left as observers. default value=3
+	ElectionNumber *int32 `json:"electionNumber,omitempty"`
+
+	commonv2.ComponentTemplate `json:",inline"`
+}
+
+// BeSpec describes a template for creating copies of a be software service.
+type BeSpec struct {
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// EnableWorkloadGroup starts the container in privileged mode for the workload group of doris.
+	EnableWorkloadGroup bool `json:"enableWorkloadGroup,omitempty"`
+
+	// SkipDefaultSystemInit skips the default initialization of system parameters required by be.
+	SkipDefaultSystemInit bool `json:"skipDefaultSystemInit,omitempty"`
+
+	// EnableFeAffinity prefers scheduling be pods on the nodes without fe pods.
+	EnableFeAffinity bool `json:"enableFeAffinity,omitempty"`
+
+	// AutoResolveLimitCPU sets the cpu limit of be by the requests.cpu of pod.
+	AutoResolveLimitCPU bool `json:"autoResolveLimitCPU,omitempty"`
+
+	// EnableDecommission decommissions be nodes when scaling down, in place of dropping them.
+	EnableDecommission bool `json:"enableDecommission,omitempty"`
+}
+
+// CnSpec describes a template for creating copies of a cn software service.
+type CnSpec struct {
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// SkipDefaultSystemInit skips the default initialization of system parameters required by cn.
+	SkipDefaultSystemInit bool `json:"skipDefaultSystemInit,omitempty"`
+
+	// AutoScalingPolicy auto scaling strategy.
+	AutoScalingPolicy *dorisv1.AutoScalingPolicy `json:"autoScalingPolicy,omitempty"`
+
+	// AutoResolveLimitCPU sets the cpu limit of cn by the requests.cpu of pod.
+	AutoResolveLimitCPU bool `json:"autoResolveLimitCPU,omitempty"`
+}
+
+// BrokerSpec describes a template for creating copies of a broker software service.
+type BrokerSpec struct {
+	commonv2.ComponentTemplate `json:",inline"`
+
+	// KickOffAffinityBe not deploys broker on the nodes of be.
+	KickOffAffinityBe bool `json:"kickOffAffinityBe,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=dcr
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.clusterHealth.health`
+// +kubebuilder:printcolumn:name="FeStatus",type=string,JSONPath=`.status.feStatus.componentCondition.phase`
+// +kubebuilder:printcolumn:name="BeStatus",type=string,JSONPath=`.status.beStatus.componentCondition.phase`
+// +kubebuilder:printcolumn:name="CnStatus",type=string,JSONPath=`.status.cnStatus.componentCondition.phase`
+// +kubebuilder:printcolumn:name="BrokerStatus",type=string,JSONPath=`.status.brokerStatus.componentCondition.phase`
+// DorisCluster is the v2 version of DorisCluster, it is converted from the storage version v1 by the conversion webhook of operator.
+// v2 is not served by the crd as shipped, the operator serves it and configures the conversion webhook only when the webhook enabled.
+type DorisCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DorisClusterSpec           `json:"spec,omitempty"`
+	Status dorisv1.DorisClusterStatus `json:"status,omitempty"`
+}
+
+// DorisClusterList contains a list of DorisCluster
+// +kubebuilder:object:root=true
+type DorisClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DorisCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DorisCluster{}, &DorisClusterList{})
+}
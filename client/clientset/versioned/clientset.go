@@ -19,11 +19,13 @@
 package versioned
 
 import (
-	"fmt"
-	"net/http"
+	fmt "fmt"
+	http "net/http"
 
 	disaggregatedv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v1"
+	disaggregatedv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v2"
 	dorisv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v1"
+	dorisv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v2"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -32,14 +34,18 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	DisaggregatedV1() disaggregatedv1.DisaggregatedV1Interface
+	DisaggregatedV2() disaggregatedv2.DisaggregatedV2Interface
 	DorisV1() dorisv1.DorisV1Interface
+	DorisV2() dorisv2.DorisV2Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	disaggregatedV1 *disaggregatedv1.DisaggregatedV1Client
+	disaggregatedV2 *disaggregatedv2.DisaggregatedV2Client
 	dorisV1         *dorisv1.DorisV1Client
+	dorisV2         *dorisv2.DorisV2Client
 }
 
 // DisaggregatedV1 retrieves the DisaggregatedV1Client
@@ -47,11 +53,21 @@ func (c *Clientset) DisaggregatedV1() disaggregatedv1.DisaggregatedV1Interface {
 	return c.disaggregatedV1
 }
 
+// DisaggregatedV2 retrieves the DisaggregatedV2Client
+func (c *Clientset) DisaggregatedV2() disaggregatedv2.DisaggregatedV2Interface {
+	return c.disaggregatedV2
+}
+
 // DorisV1 retrieves the DorisV1Client
 func (c *Clientset) DorisV1() dorisv1.DorisV1Interface {
 	return c.dorisV1
 }
 
+// DorisV2 retrieves the DorisV2Client
+func (c *Clientset) DorisV2() dorisv2.DorisV2Interface {
+	return c.dorisV2
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -100,10 +116,18 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.disaggregatedV2, err = disaggregatedv2.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 	cs.dorisV1, err = dorisv1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	cs.dorisV2, err = dorisv2.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -126,7 +150,9 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.disaggregatedV1 = disaggregatedv1.New(c)
+	cs.disaggregatedV2 = disaggregatedv2.New(c)
 	cs.dorisV1 = dorisv1.New(c)
+	cs.dorisV2 = dorisv2.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -22,8 +22,12 @@ import (
 	clientset "github.com/apache/doris-operator/client/clientset/versioned"
 	disaggregatedv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v1"
 	fakedisaggregatedv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v1/fake"
+	disaggregatedv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v2"
+	fakedisaggregatedv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/disaggregated/v2/fake"
 	dorisv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v1"
 	fakedorisv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v1/fake"
+	dorisv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v2"
+	fakedorisv2 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v2/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -33,8 +37,12 @@ import (
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
 // It's backed by a very simple object tracker that processes creates, updates and deletions as-is,
-// without applying any validations and/or defaults. It shouldn't be considered a replacement
+// without applying any field management, validations and/or defaults. It shouldn't be considered a replacement
 // for a real clientset and is mostly useful in simple unit tests.
+//
+// DEPRECATED: NewClientset replaces this with support for field management, which significantly improves
+// server side apply testing. NewClientset is only available when apply configurations are generated (e.g.
+// via --with-applyconfig).
 func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 	o := testing.NewObjectTracker(scheme, codecs.UniversalDecoder())
 	for _, obj := range objects {
@@ -86,7 +94,17 @@ func (c *Clientset) DisaggregatedV1() disaggregatedv1.DisaggregatedV1Interface {
 	return &fakedisaggregatedv1.FakeDisaggregatedV1{Fake: &c.Fake}
 }
 
+// DisaggregatedV2 retrieves the DisaggregatedV2Client
+func (c *Clientset) DisaggregatedV2() disaggregatedv2.DisaggregatedV2Interface {
+	return &fakedisaggregatedv2.FakeDisaggregatedV2{Fake: &c.Fake}
+}
+
 // DorisV1 retrieves the DorisV1Client
 func (c *Clientset) DorisV1() dorisv1.DorisV1Interface {
 	return &fakedorisv1.FakeDorisV1{Fake: &c.Fake}
 }
+
+// DorisV2 retrieves the DorisV2Client
+func (c *Clientset) DorisV2() dorisv2.DorisV2Interface {
+	return &fakedorisv2.FakeDorisV2{Fake: &c.Fake}
+}
@@ -20,7 +20,9 @@ package scheme
 
 import (
 	disaggregatedv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	disaggregatedv2 "github.com/apache/doris-operator/api/disaggregated/v2"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	dorisv2 "github.com/apache/doris-operator/api/doris/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,7 +35,9 @@ var Codecs = serializer.NewCodecFactory(Scheme)
 var ParameterCodec = runtime.NewParameterCodec(Scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	disaggregatedv1.AddToScheme,
+	disaggregatedv2.AddToScheme,
 	dorisv1.AddToScheme,
+	dorisv2.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
@@ -19,15 +19,14 @@
 package v1
 
 import (
-	"context"
-	"time"
+	context "context"
 
-	v1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	disaggregatedv1 "github.com/apache/doris-operator/api/disaggregated/v1"
 	scheme "github.com/apache/doris-operator/client/clientset/versioned/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
-	rest "k8s.io/client-go/rest"
+	gentype "k8s.io/client-go/gentype"
 )
 
 // DorisDisaggregatedClustersGetter has a method to return a DorisDisaggregatedClusterInterface.
@@ -38,158 +37,36 @@ type DorisDisaggregatedClustersGetter interface {
 
 // DorisDisaggregatedClusterInterface has methods to work with DorisDisaggregatedCluster resources.
 type DorisDisaggregatedClusterInterface interface {
-	Create(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.CreateOptions) (*v1.DorisDisaggregatedCluster, error)
-	Update(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (*v1.DorisDisaggregatedCluster, error)
-	UpdateStatus(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (*v1.DorisDisaggregatedCluster, error)
+	Create(ctx context.Context, dorisDisaggregatedCluster *disaggregatedv1.DorisDisaggregatedCluster, opts metav1.CreateOptions) (*disaggregatedv1.DorisDisaggregatedCluster, error)
+	Update(ctx context.Context, dorisDisaggregatedCluster *disaggregatedv1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (*disaggregatedv1.DorisDisaggregatedCluster, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, dorisDisaggregatedCluster *disaggregatedv1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (*disaggregatedv1.DorisDisaggregatedCluster, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
-	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.DorisDisaggregatedCluster, error)
-	List(ctx context.Context, opts metav1.ListOptions) (*v1.DorisDisaggregatedClusterList, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*disaggregatedv1.DorisDisaggregatedCluster, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*disaggregatedv1.DorisDisaggregatedClusterList, error)
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
-	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DorisDisaggregatedCluster, err error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *disaggregatedv1.DorisDisaggregatedCluster, err error)
 	DorisDisaggregatedClusterExpansion
 }
 
 // dorisDisaggregatedClusters implements DorisDisaggregatedClusterInterface
 type dorisDisaggregatedClusters struct {
-	client rest.Interface
-	ns     string
+	*gentype.ClientWithList[*disaggregatedv1.DorisDisaggregatedCluster, *disaggregatedv1.DorisDisaggregatedClusterList]
 }
 
 // newDorisDisaggregatedClusters returns a DorisDisaggregatedClusters
 func newDorisDisaggregatedClusters(c *DisaggregatedV1Client, namespace string) *dorisDisaggregatedClusters {
 	return &dorisDisaggregatedClusters{
-		client: c.RESTClient(),
-		ns:     namespace,
+		gentype.NewClientWithList[*disaggregatedv1.DorisDisaggregatedCluster, *disaggregatedv1.DorisDisaggregatedClusterList](
+			"dorisdisaggregatedclusters",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *disaggregatedv1.DorisDisaggregatedCluster { return &disaggregatedv1.DorisDisaggregatedCluster{} },
+			func() *disaggregatedv1.DorisDisaggregatedClusterList {
+				return &disaggregatedv1.DorisDisaggregatedClusterList{}
+			},
+		),
 	}
 }
-
-// Get takes name of the dorisDisaggregatedCluster, and returns the corresponding dorisDisaggregatedCluster object, and an error if there is any.
-func (c *dorisDisaggregatedClusters) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.DorisDisaggregatedCluster, err error) {
-	result = &v1.DorisDisaggregatedCluster{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		Name(name).
-		VersionedParams(&options, scheme.ParameterCodec).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// List takes label and field selectors, and returns the list of DorisDisaggregatedClusters that match those selectors.
-func (c *dorisDisaggregatedClusters) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DorisDisaggregatedClusterList, err error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	result = &v1.DorisDisaggregatedClusterList{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Watch returns a watch.Interface that watches the requested dorisDisaggregatedClusters.
-func (c *dorisDisaggregatedClusters) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	opts.Watch = true
-	return c.client.Get().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Watch(ctx)
-}
-
-// Create takes the representation of a dorisDisaggregatedCluster and creates it.  Returns the server's representation of the dorisDisaggregatedCluster, and an error, if there is any.
-func (c *dorisDisaggregatedClusters) Create(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.CreateOptions) (result *v1.DorisDisaggregatedCluster, err error) {
-	result = &v1.DorisDisaggregatedCluster{}
-	err = c.client.Post().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(dorisDisaggregatedCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Update takes the representation of a dorisDisaggregatedCluster and updates it. Returns the server's representation of the dorisDisaggregatedCluster, and an error, if there is any.
-func (c *dorisDisaggregatedClusters) Update(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (result *v1.DorisDisaggregatedCluster, err error) {
-	result = &v1.DorisDisaggregatedCluster{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		Name(dorisDisaggregatedCluster.Name).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(dorisDisaggregatedCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// UpdateStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *dorisDisaggregatedClusters) UpdateStatus(ctx context.Context, dorisDisaggregatedCluster *v1.DorisDisaggregatedCluster, opts metav1.UpdateOptions) (result *v1.DorisDisaggregatedCluster, err error) {
-	result = &v1.DorisDisaggregatedCluster{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		Name(dorisDisaggregatedCluster.Name).
-		SubResource("status").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(dorisDisaggregatedCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Delete takes name of the dorisDisaggregatedCluster and deletes it. Returns an error if one occurs.
-func (c *dorisDisaggregatedClusters) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		Name(name).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// DeleteCollection deletes a collection of objects.
-func (c *dorisDisaggregatedClusters) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	var timeout time.Duration
-	if listOpts.TimeoutSeconds != nil {
-		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
-	}
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		VersionedParams(&listOpts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// Patch applies the patch and returns the patched dorisDisaggregatedCluster.
-func (c *dorisDisaggregatedClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DorisDisaggregatedCluster, err error) {
-	result = &v1.DorisDisaggregatedCluster{}
-	err = c.client.Patch(pt).
-		Namespace(c.ns).
-		Resource("dorisdisaggregatedclusters").
-		Name(name).
-		SubResource(subresources...).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
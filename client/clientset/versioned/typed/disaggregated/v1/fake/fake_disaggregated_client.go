@@ -29,7 +29,7 @@ type FakeDisaggregatedV1 struct {
 }
 
 func (c *FakeDisaggregatedV1) DorisDisaggregatedClusters(namespace string) v1.DorisDisaggregatedClusterInterface {
-	return &FakeDorisDisaggregatedClusters{c, namespace}
+	return newFakeDorisDisaggregatedClusters(c, namespace)
 }
 
 // RESTClient returns a RESTClient that is used to communicate
@@ -29,7 +29,7 @@ type FakeDorisV1 struct {
 }
 
 func (c *FakeDorisV1) DorisClusters(namespace string) v1.DorisClusterInterface {
-	return &FakeDorisClusters{c, namespace}
+	return newFakeDorisClusters(c, namespace)
 }
 
 // RESTClient returns a RESTClient that is used to communicate
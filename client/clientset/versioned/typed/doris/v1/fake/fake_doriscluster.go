@@ -19,123 +19,32 @@
 package fake
 
 import (
-	"context"
-
 	v1 "github.com/apache/doris-operator/api/doris/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	labels "k8s.io/apimachinery/pkg/labels"
-	types "k8s.io/apimachinery/pkg/types"
-	watch "k8s.io/apimachinery/pkg/watch"
-	testing "k8s.io/client-go/testing"
+	dorisv1 "github.com/apache/doris-operator/client/clientset/versioned/typed/doris/v1"
+	gentype "k8s.io/client-go/gentype"
 )
 
-// FakeDorisClusters implements DorisClusterInterface
-type FakeDorisClusters struct {
+// fakeDorisClusters implements DorisClusterInterface
+type fakeDorisClusters struct {
+	*gentype.FakeClientWithList[*v1.DorisCluster, *v1.DorisClusterList]
 	Fake *FakeDorisV1
-	ns   string
-}
-
-var dorisclustersResource = v1.SchemeGroupVersion.WithResource("dorisclusters")
-
-var dorisclustersKind = v1.SchemeGroupVersion.WithKind("DorisCluster")
-
-// Get takes name of the dorisCluster, and returns the corresponding dorisCluster object, and an error if there is any.
-func (c *FakeDorisClusters) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.DorisCluster, err error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(dorisclustersResource, c.ns, name), &v1.DorisCluster{})
-
-	if obj == nil {
-		return nil, err
-	}
-	return obj.(*v1.DorisCluster), err
-}
-
-// List takes label and field selectors, and returns the list of DorisClusters that match those selectors.
-func (c *FakeDorisClusters) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DorisClusterList, err error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewListAction(dorisclustersResource, dorisclustersKind, c.ns, opts), &v1.DorisClusterList{})
-
-	if obj == nil {
-		return nil, err
-	}
-
-	label, _, _ := testing.ExtractFromListOptions(opts)
-	if label == nil {
-		label = labels.Everything()
-	}
-	list := &v1.DorisClusterList{ListMeta: obj.(*v1.DorisClusterList).ListMeta}
-	for _, item := range obj.(*v1.DorisClusterList).Items {
-		if label.Matches(labels.Set(item.Labels)) {
-			list.Items = append(list.Items, item)
-		}
-	}
-	return list, err
-}
-
-// Watch returns a watch.Interface that watches the requested dorisClusters.
-func (c *FakeDorisClusters) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-	return c.Fake.
-		InvokesWatch(testing.NewWatchAction(dorisclustersResource, c.ns, opts))
-
-}
-
-// Create takes the representation of a dorisCluster and creates it.  Returns the server's representation of the dorisCluster, and an error, if there is any.
-func (c *FakeDorisClusters) Create(ctx context.Context, dorisCluster *v1.DorisCluster, opts metav1.CreateOptions) (result *v1.DorisCluster, err error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(dorisclustersResource, c.ns, dorisCluster), &v1.DorisCluster{})
-
-	if obj == nil {
-		return nil, err
-	}
-	return obj.(*v1.DorisCluster), err
-}
-
-// Update takes the representation of a dorisCluster and updates it. Returns the server's representation of the dorisCluster, and an error, if there is any.
-func (c *FakeDorisClusters) Update(ctx context.Context, dorisCluster *v1.DorisCluster, opts metav1.UpdateOptions) (result *v1.DorisCluster, err error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(dorisclustersResource, c.ns, dorisCluster), &v1.DorisCluster{})
-
-	if obj == nil {
-		return nil, err
-	}
-	return obj.(*v1.DorisCluster), err
-}
-
-// UpdateStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeDorisClusters) UpdateStatus(ctx context.Context, dorisCluster *v1.DorisCluster, opts metav1.UpdateOptions) (*v1.DorisCluster, error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(dorisclustersResource, "status", c.ns, dorisCluster), &v1.DorisCluster{})
-
-	if obj == nil {
-		return nil, err
-	}
-	return obj.(*v1.DorisCluster), err
-}
-
-// Delete takes name of the dorisCluster and deletes it. Returns an error if one occurs.
-func (c *FakeDorisClusters) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
-	_, err := c.Fake.
-		Invokes(testing.NewDeleteActionWithOptions(dorisclustersResource, c.ns, name, opts), &v1.DorisCluster{})
-
-	return err
 }
 
-// DeleteCollection deletes a collection of objects.
-func (c *FakeDorisClusters) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(dorisclustersResource, c.ns, listOpts)
-
-	_, err := c.Fake.Invokes(action, &v1.DorisClusterList{})
-	return err
-}
-
-// Patch applies the patch and returns the patched dorisCluster.
-func (c *FakeDorisClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DorisCluster, err error) {
-	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(dorisclustersResource, c.ns, name, pt, data, subresources...), &v1.DorisCluster{})
-
-	if obj == nil {
-		return nil, err
+func newFakeDorisClusters(fake *FakeDorisV1, namespace string) dorisv1.DorisClusterInterface {
+	return &fakeDorisClusters{
+		gentype.NewFakeClientWithList[*v1.DorisCluster, *v1.DorisClusterList](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("dorisclusters"),
+			v1.SchemeGroupVersion.WithKind("DorisCluster"),
+			func() *v1.DorisCluster { return &v1.DorisCluster{} },
+			func() *v1.DorisClusterList { return &v1.DorisClusterList{} },
+			func(dst, src *v1.DorisClusterList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.DorisClusterList) []*v1.DorisCluster { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.DorisClusterList, items []*v1.DorisCluster) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
 	}
-	return obj.(*v1.DorisCluster), err
 }
@@ -19,13 +19,13 @@
 package v1
 
 import (
-	"context"
+	context "context"
 	time "time"
 
-	disaggregatedv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	apidisaggregatedv1 "github.com/apache/doris-operator/api/disaggregated/v1"
 	versioned "github.com/apache/doris-operator/client/clientset/versioned"
 	internalinterfaces "github.com/apache/doris-operator/client/informers/externalversions/internalinterfaces"
-	v1 "github.com/apache/doris-operator/client/listers/disaggregated/v1"
+	disaggregatedv1 "github.com/apache/doris-operator/client/listers/disaggregated/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	watch "k8s.io/apimachinery/pkg/watch"
@@ -36,7 +36,7 @@ import (
 // DorisDisaggregatedClusters.
 type DorisDisaggregatedClusterInformer interface {
 	Informer() cache.SharedIndexInformer
-	Lister() v1.DorisDisaggregatedClusterLister
+	Lister() disaggregatedv1.DorisDisaggregatedClusterLister
 }
 
 type dorisDisaggregatedClusterInformer struct {
@@ -71,7 +71,7 @@ func NewFilteredDorisDisaggregatedClusterInformer(client versioned.Interface, na
 				return client.DisaggregatedV1().DorisDisaggregatedClusters(namespace).Watch(context.TODO(), options)
 			},
 		},
-		&disaggregatedv1.DorisDisaggregatedCluster{},
+		&apidisaggregatedv1.DorisDisaggregatedCluster{},
 		resyncPeriod,
 		indexers,
 	)
@@ -82,9 +82,9 @@ func (f *dorisDisaggregatedClusterInformer) defaultInformer(client versioned.Int
 }
 
 func (f *dorisDisaggregatedClusterInformer) Informer() cache.SharedIndexInformer {
-	return f.factory.InformerFor(&disaggregatedv1.DorisDisaggregatedCluster{}, f.defaultInformer)
+	return f.factory.InformerFor(&apidisaggregatedv1.DorisDisaggregatedCluster{}, f.defaultInformer)
 }
 
-func (f *dorisDisaggregatedClusterInformer) Lister() v1.DorisDisaggregatedClusterLister {
-	return v1.NewDorisDisaggregatedClusterLister(f.Informer().GetIndexer())
+func (f *dorisDisaggregatedClusterInformer) Lister() disaggregatedv1.DorisDisaggregatedClusterLister {
+	return disaggregatedv1.NewDorisDisaggregatedClusterLister(f.Informer().GetIndexer())
 }
@@ -19,13 +19,13 @@
 package v1
 
 import (
-	"context"
+	context "context"
 	time "time"
 
-	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	apidorisv1 "github.com/apache/doris-operator/api/doris/v1"
 	versioned "github.com/apache/doris-operator/client/clientset/versioned"
 	internalinterfaces "github.com/apache/doris-operator/client/informers/externalversions/internalinterfaces"
-	v1 "github.com/apache/doris-operator/client/listers/doris/v1"
+	dorisv1 "github.com/apache/doris-operator/client/listers/doris/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	watch "k8s.io/apimachinery/pkg/watch"
@@ -36,7 +36,7 @@ import (
 // DorisClusters.
 type DorisClusterInformer interface {
 	Informer() cache.SharedIndexInformer
-	Lister() v1.DorisClusterLister
+	Lister() dorisv1.DorisClusterLister
 }
 
 type dorisClusterInformer struct {
@@ -71,7 +71,7 @@ func NewFilteredDorisClusterInformer(client versioned.Interface, namespace strin
 				return client.DorisV1().DorisClusters(namespace).Watch(context.TODO(), options)
 			},
 		},
-		&dorisv1.DorisCluster{},
+		&apidorisv1.DorisCluster{},
 		resyncPeriod,
 		indexers,
 	)
@@ -82,9 +82,9 @@ func (f *dorisClusterInformer) defaultInformer(client versioned.Interface, resyn
 }
 
 func (f *dorisClusterInformer) Informer() cache.SharedIndexInformer {
-	return f.factory.InformerFor(&dorisv1.DorisCluster{}, f.defaultInformer)
+	return f.factory.InformerFor(&apidorisv1.DorisCluster{}, f.defaultInformer)
 }
 
-func (f *dorisClusterInformer) Lister() v1.DorisClusterLister {
-	return v1.NewDorisClusterLister(f.Informer().GetIndexer())
+func (f *dorisClusterInformer) Lister() dorisv1.DorisClusterLister {
+	return dorisv1.NewDorisClusterLister(f.Informer().GetIndexer())
 }
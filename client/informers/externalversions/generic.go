@@ -19,10 +19,12 @@
 package externalversions
 
 import (
-	"fmt"
+	fmt "fmt"
 
 	v1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	v2 "github.com/apache/doris-operator/api/disaggregated/v2"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	dorisv2 "github.com/apache/doris-operator/api/doris/v2"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -57,10 +59,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1.SchemeGroupVersion.WithResource("dorisdisaggregatedclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Disaggregated().V1().DorisDisaggregatedClusters().Informer()}, nil
 
+		// Group=disaggregated.cluster.doris.com, Version=v2
+	case v2.SchemeGroupVersion.WithResource("dorisdisaggregatedclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Disaggregated().V2().DorisDisaggregatedClusters().Informer()}, nil
+
 		// Group=doris.selectdb.com, Version=v1
 	case dorisv1.SchemeGroupVersion.WithResource("dorisclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Doris().V1().DorisClusters().Informer()}, nil
 
+		// Group=doris.selectdb.com, Version=v2
+	case dorisv2.SchemeGroupVersion.WithResource("dorisclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Doris().V2().DorisClusters().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -19,10 +19,10 @@
 package v1
 
 import (
-	v1 "github.com/apache/doris-operator/api/disaggregated/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/tools/cache"
+	disaggregatedv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
 )
 
 // DorisDisaggregatedClusterLister helps list DorisDisaggregatedClusters.
@@ -30,7 +30,7 @@ import (
 type DorisDisaggregatedClusterLister interface {
 	// List lists all DorisDisaggregatedClusters in the indexer.
 	// Objects returned here must be treated as read-only.
-	List(selector labels.Selector) (ret []*v1.DorisDisaggregatedCluster, err error)
+	List(selector labels.Selector) (ret []*disaggregatedv1.DorisDisaggregatedCluster, err error)
 	// DorisDisaggregatedClusters returns an object that can list and get DorisDisaggregatedClusters.
 	DorisDisaggregatedClusters(namespace string) DorisDisaggregatedClusterNamespaceLister
 	DorisDisaggregatedClusterListerExpansion
@@ -38,25 +38,17 @@ type DorisDisaggregatedClusterLister interface {
 
 // dorisDisaggregatedClusterLister implements the DorisDisaggregatedClusterLister interface.
 type dorisDisaggregatedClusterLister struct {
-	indexer cache.Indexer
+	listers.ResourceIndexer[*disaggregatedv1.DorisDisaggregatedCluster]
 }
 
 // NewDorisDisaggregatedClusterLister returns a new DorisDisaggregatedClusterLister.
 func NewDorisDisaggregatedClusterLister(indexer cache.Indexer) DorisDisaggregatedClusterLister {
-	return &dorisDisaggregatedClusterLister{indexer: indexer}
-}
-
-// List lists all DorisDisaggregatedClusters in the indexer.
-func (s *dorisDisaggregatedClusterLister) List(selector labels.Selector) (ret []*v1.DorisDisaggregatedCluster, err error) {
-	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.DorisDisaggregatedCluster))
-	})
-	return ret, err
+	return &dorisDisaggregatedClusterLister{listers.New[*disaggregatedv1.DorisDisaggregatedCluster](indexer, disaggregatedv1.Resource("dorisdisaggregatedcluster"))}
 }
 
 // DorisDisaggregatedClusters returns an object that can list and get DorisDisaggregatedClusters.
 func (s *dorisDisaggregatedClusterLister) DorisDisaggregatedClusters(namespace string) DorisDisaggregatedClusterNamespaceLister {
-	return dorisDisaggregatedClusterNamespaceLister{indexer: s.indexer, namespace: namespace}
+	return dorisDisaggregatedClusterNamespaceLister{listers.NewNamespaced[*disaggregatedv1.DorisDisaggregatedCluster](s.ResourceIndexer, namespace)}
 }
 
 // DorisDisaggregatedClusterNamespaceLister helps list and get DorisDisaggregatedClusters.
@@ -64,36 +56,15 @@ func (s *dorisDisaggregatedClusterLister) DorisDisaggregatedClusters(namespace s
 type DorisDisaggregatedClusterNamespaceLister interface {
 	// List lists all DorisDisaggregatedClusters in the indexer for a given namespace.
 	// Objects returned here must be treated as read-only.
-	List(selector labels.Selector) (ret []*v1.DorisDisaggregatedCluster, err error)
+	List(selector labels.Selector) (ret []*disaggregatedv1.DorisDisaggregatedCluster, err error)
 	// Get retrieves the DorisDisaggregatedCluster from the indexer for a given namespace and name.
 	// Objects returned here must be treated as read-only.
-	Get(name string) (*v1.DorisDisaggregatedCluster, error)
+	Get(name string) (*disaggregatedv1.DorisDisaggregatedCluster, error)
 	DorisDisaggregatedClusterNamespaceListerExpansion
 }
 
 // dorisDisaggregatedClusterNamespaceLister implements the DorisDisaggregatedClusterNamespaceLister
 // interface.
 type dorisDisaggregatedClusterNamespaceLister struct {
-	indexer   cache.Indexer
-	namespace string
-}
-
-// List lists all DorisDisaggregatedClusters in the indexer for a given namespace.
-func (s dorisDisaggregatedClusterNamespaceLister) List(selector labels.Selector) (ret []*v1.DorisDisaggregatedCluster, err error) {
-	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.DorisDisaggregatedCluster))
-	})
-	return ret, err
-}
-
-// Get retrieves the DorisDisaggregatedCluster from the indexer for a given namespace and name.
-func (s dorisDisaggregatedClusterNamespaceLister) Get(name string) (*v1.DorisDisaggregatedCluster, error) {
-	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.NewNotFound(v1.Resource("dorisdisaggregatedcluster"), name)
-	}
-	return obj.(*v1.DorisDisaggregatedCluster), nil
+	listers.ResourceIndexer[*disaggregatedv1.DorisDisaggregatedCluster]
 }
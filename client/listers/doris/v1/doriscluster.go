@@ -19,10 +19,10 @@
 package v1
 
 import (
-	v1 "github.com/apache/doris-operator/api/doris/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/tools/cache"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
 )
 
 // DorisClusterLister helps list DorisClusters.
@@ -30,7 +30,7 @@ import (
 type DorisClusterLister interface {
 	// List lists all DorisClusters in the indexer.
 	// Objects returned here must be treated as read-only.
-	List(selector labels.Selector) (ret []*v1.DorisCluster, err error)
+	List(selector labels.Selector) (ret []*dorisv1.DorisCluster, err error)
 	// DorisClusters returns an object that can list and get DorisClusters.
 	DorisClusters(namespace string) DorisClusterNamespaceLister
 	DorisClusterListerExpansion
@@ -38,25 +38,17 @@ type DorisClusterLister interface {
 
 // dorisClusterLister implements the DorisClusterLister interface.
 type dorisClusterLister struct {
-	indexer cache.Indexer
+	listers.ResourceIndexer[*dorisv1.DorisCluster]
 }
 
 // NewDorisClusterLister returns a new DorisClusterLister.
 func NewDorisClusterLister(indexer cache.Indexer) DorisClusterLister {
-	return &dorisClusterLister{indexer: indexer}
-}
-
-// List lists all DorisClusters in the indexer.
-func (s *dorisClusterLister) List(selector labels.Selector) (ret []*v1.DorisCluster, err error) {
-	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.DorisCluster))
-	})
-	return ret, err
+	return &dorisClusterLister{listers.New[*dorisv1.DorisCluster](indexer, dorisv1.Resource("doriscluster"))}
 }
 
 // DorisClusters returns an object that can list and get DorisClusters.
 func (s *dorisClusterLister) DorisClusters(namespace string) DorisClusterNamespaceLister {
-	return dorisClusterNamespaceLister{indexer: s.indexer, namespace: namespace}
+	return dorisClusterNamespaceLister{listers.NewNamespaced[*dorisv1.DorisCluster](s.ResourceIndexer, namespace)}
 }
 
 // DorisClusterNamespaceLister helps list and get DorisClusters.
@@ -64,36 +56,15 @@ func (s *dorisClusterLister) DorisClusters(namespace string) DorisClusterNamespa
 type DorisClusterNamespaceLister interface {
 	// List lists all DorisClusters in the indexer for a given namespace.
 	// Objects returned here must be treated as read-only.
-	List(selector labels.Selector) (ret []*v1.DorisCluster, err error)
+	List(selector labels.Selector) (ret []*dorisv1.DorisCluster, err error)
 	// Get retrieves the DorisCluster from the indexer for a given namespace and name.
 	// Objects returned here must be treated as read-only.
-	Get(name string) (*v1.DorisCluster, error)
+	Get(name string) (*dorisv1.DorisCluster, error)
 	DorisClusterNamespaceListerExpansion
 }
 
 // dorisClusterNamespaceLister implements the DorisClusterNamespaceLister
 // interface.
 type dorisClusterNamespaceLister struct {
-	indexer   cache.Indexer
-	namespace string
-}
-
-// List lists all DorisClusters in the indexer for a given namespace.
-func (s dorisClusterNamespaceLister) List(selector labels.Selector) (ret []*v1.DorisCluster, err error) {
-	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.DorisCluster))
-	})
-	return ret, err
-}
-
-// Get retrieves the DorisCluster from the indexer for a given namespace and name.
-func (s dorisClusterNamespaceLister) Get(name string) (*v1.DorisCluster, error) {
-	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.NewNotFound(v1.Resource("doriscluster"), name)
-	}
-	return obj.(*v1.DorisCluster), nil
+	listers.ResourceIndexer[*dorisv1.DorisCluster]
 }
@@ -29,6 +29,11 @@ type EnvVariables struct {
 	OperatorNamespace string
 	OperatorName      string
 	ServiceName       string
+	// the cert-manager issuer for webhook certificate.
+	WebhookCertManagerIssuer     string
+	WebhookCertManagerIssuerKind string
+	// reject the plaintext password of adminUser in validating webhook.
+	RejectPlaintextAdminPassword bool
 }
 
 // get envs
@@ -52,6 +57,12 @@ func ParseEnvs() *EnvVariables {
 	if ev.ServiceName == "" {
 		ev.ServiceName = "doris-operator-service"
 	}
+
+	ev.WebhookCertManagerIssuer = os.Getenv("WEBHOOK_CERT_MANAGER_ISSUER")
+	ev.WebhookCertManagerIssuerKind = os.Getenv("WEBHOOK_CERT_MANAGER_ISSUER_KIND")
+	if os.Getenv("REJECT_PLAINTEXT_ADMIN_PASSWORD") == "true" {
+		ev.RejectPlaintextAdminPassword = true
+	}
 	return ev
 }
 
@@ -63,5 +74,9 @@ func NewControllerOptions(envs *EnvVariables) *controller.Options {
 		SecretName:     Default_Secret_Name,
 		Namespace:      envs.OperatorNamespace,
 		WebhookService: envs.ServiceName,
+
+		WebhookCertManagerIssuer:     envs.WebhookCertManagerIssuer,
+		WebhookCertManagerIssuerKind: envs.WebhookCertManagerIssuerKind,
+		RejectPlaintextAdminPassword: envs.RejectPlaintextAdminPassword,
 	}
 }
@@ -19,6 +19,8 @@ package conf
 
 import (
 	"flag"
+	"github.com/apache/doris-operator/pkg/common/logging"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
@@ -32,6 +34,12 @@ type Flag struct {
 	PrintVar             bool
 	EnableWebhook        bool
 	Opts                 zap.Options
+	// the format of logs, console or json.
+	LogFormat string
+	// the address the log level endpoint binds to.
+	LogLevelAddr string
+	// the options of exporting the spans of reconciling.
+	Tracing tracing.Options
 }
 
 func ParseFlags() *Flag {
@@ -46,6 +54,16 @@ func ParseFlags() *Flag {
 
 	// check switch unnamedwatches on or off, if 'true' passed from console or config in env, will start unnamedwatches operator.
 	flag.BoolVar(&f.EnableWebhook, "enable-unnamedwatches", true, "start the unnamedwatches.")
+
+	// tracing is disabled when the endpoint of otlp not configured.
+	flag.StringVar(&f.Tracing.Endpoint, "tracing-otlp-endpoint", "", "The OTLP gRPC endpoint the spans of reconciling exported to, e.g. otel-collector.monitoring:4317. Tracing is disabled when empty.")
+	flag.BoolVar(&f.Tracing.Insecure, "tracing-otlp-insecure", false, "Export spans to the OTLP endpoint without transport security.")
+	flag.Float64Var(&f.Tracing.SampleRatio, "tracing-sample-ratio", 1, "The ratio of reconciles traced, in [0, 1].")
+	flag.StringVar(&f.Tracing.ServiceName, "tracing-service-name", "doris-operator", "The service name of exported spans.")
+	// the logs are written in console format for development by default, `--log-format=json` writes the structured logs in json.
+	flag.StringVar(&f.LogFormat, "log-format", logging.FormatConsole, "The format of logs, console or json.")
+	// the level endpoint is not authenticated, it only listens on localhost by default.
+	flag.StringVar(&f.LogLevelAddr, "log-level-bind-address", "127.0.0.1:8082", "The address the log level endpoint binds to, set 0 to disable it.")
 	f.Opts = zap.Options{
 		Development: true,
 	}
@@ -37,12 +37,17 @@ import (
 	"context"
 	"fmt"
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	ddcv2 "github.com/apache/doris-operator/api/disaggregated/v2"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	dorisv2 "github.com/apache/doris-operator/api/doris/v2"
 	"github.com/apache/doris-operator/cmd/operator/conf"
+	"github.com/apache/doris-operator/pkg/common/logging"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/apache/doris-operator/pkg/common/utils/certificate"
 	"github.com/apache/doris-operator/pkg/controller"
 	"github.com/apache/doris-operator/pkg/controller/unnamedwatches"
 	"io"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/pointer"
 	"os"
@@ -62,7 +67,6 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -92,6 +96,11 @@ func init() {
 
 	utilruntime.Must(dorisv1.AddToScheme(scheme))
 	utilruntime.Must(dv1.AddToScheme(scheme))
+	//v2 is converted from v1 by the conversion webhook.
+	utilruntime.Must(dorisv2.AddToScheme(scheme))
+	utilruntime.Must(ddcv2.AddToScheme(scheme))
+	//watch the crds for configuring the conversion webhook.
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	//deprecated:
 	//utilruntime.Must(dmsv1.AddToScheme(scheme))
 	//add foundationdb scheme
@@ -113,7 +122,12 @@ func main() {
 	//print version infos.
 	printVersionInfos(f.PrintVar)
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&f.Opts)))
+	logging.Setup(&f.Opts, f.LogFormat)
+	shutdownTracing, err := tracing.Setup(context.Background(), f.Tracing)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
 	webhookServer := webhook.NewServer(webhook.Options{
 		Port: 9443,
 	})
@@ -156,6 +170,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// change the log level at runtime, the endpoint is not authenticated so it is not served on the metrics server.
+	if f.LogLevelAddr != "0" {
+		if err := mgr.Add(logging.NewLevelServer(f.LogLevelAddr)); err != nil {
+			setupLog.Error(err, "unable to set up log level server")
+			os.Exit(1)
+		}
+	}
+
 	options := conf.NewControllerOptions(envs)
 	//initial all controllers
 	for _, c := range controller.Controllers {
@@ -200,7 +222,14 @@ func main() {
 		}
 	}
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// flush the spans in buffer before exit.
+	sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if serr := shutdownTracing(sctx); serr != nil {
+		setupLog.Error(serr, "shutdown tracing failed")
+	}
+	cancel()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,176 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package logging configures the structured logger of operator, the logs of klog and controller-runtime are written by the same zap logger,
+// and the verbosity can be changed at runtime by the level endpoint.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// the keys of values in the logs of reconciling, `namespace` and `name` of the reconciled object are added by controller-runtime.
+const (
+	ClusterKey      = "cluster"
+	ComponentKey    = "component"
+	ComputeGroupKey = "computeGroup"
+)
+
+// LevelPath is the path of level endpoint served by the level server of operator, the server only listens on localhost by default as the endpoint is not authenticated.
+// `GET` return the current level, `PUT` with body `{"level":"debug"}` change the level, the level is one of `debug`, `info`, `error` or an integer > 0 of verbosity.
+const LevelPath = "/log-level"
+
+// the formats of logs, the logs are written in console format for development by default, json is opt-in.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+var (
+	level = uberzap.NewAtomicLevelAt(zapcore.InfoLevel)
+	mu    sync.Mutex
+)
+
+// Setup build the logger by opts and set it as the logger of controller-runtime and klog, the level in opts is the initial level which can be changed by SetLevel.
+// the logs are written in json when format is `json`, otherwise the encoder of opts is used.
+func Setup(opts *zap.Options, format string) logr.Logger {
+	if format == FormatJSON {
+		opts.Development = false
+		zap.JSONEncoder()(opts)
+	}
+	if opts.Level != nil {
+		if al, ok := opts.Level.(uberzap.AtomicLevel); ok {
+			setLevel(al.Level())
+		}
+	} else if opts.Development {
+		setLevel(zapcore.DebugLevel)
+	}
+	opts.Level = level
+	logger := zap.New(zap.UseFlagOptions(opts))
+	ctrl.SetLogger(logger)
+	klog.SetLogger(logger)
+	return logger
+}
+
+// SetLevel change the level of logger, text is one of `debug`, `info`, `error` or an integer > 0 of verbosity, e.g. `4` enables `klog.V(4)`.
+func SetLevel(text string) error {
+	text = strings.ToLower(strings.TrimSpace(text))
+	switch text {
+	case "debug":
+		setLevel(zapcore.DebugLevel)
+	case "info":
+		setLevel(zapcore.InfoLevel)
+	case "error":
+		setLevel(zapcore.ErrorLevel)
+	default:
+		v, err := strconv.Atoi(text)
+		if err != nil || v <= 0 || v > 127 {
+			return fmt.Errorf("invalid log level %q, should be one of debug, info, error or an integer in (0, 127]", text)
+		}
+		setLevel(zapcore.Level(-v))
+	}
+	return nil
+}
+
+// GetLevel return the current level, the levels of verbosity more than debug are displayed as integers, e.g. `4`.
+func GetLevel() string {
+	l := level.Level()
+	if l < zapcore.DebugLevel {
+		return strconv.Itoa(-int(l))
+	}
+	return l.String()
+}
+
+// setLevel set the level of zap and the verbosity of klog consistently, the `V(n)` logs of klog are only written when both enabled.
+func setLevel(l zapcore.Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level.SetLevel(l)
+	verbosity := 0
+	if l < zapcore.InfoLevel {
+		verbosity = -int(l)
+	}
+	var kl klog.Level
+	_ = kl.Set(strconv.Itoa(verbosity))
+}
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler serve the level of logger, `GET` return the level and `PUT` change it.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var p levelPayload
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "decode request failed, " + err.Error()})
+				return
+			}
+			if err := SetLevel(p.Level); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			klog.Infof("logging LevelHandler the log level changed to %s.", p.Level)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "only GET and PUT are supported."})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(levelPayload{Level: GetLevel()})
+	})
+}
+
+// NewLevelServer return the server of level endpoint listening on addr, it is added to the manager of controller-runtime as a runnable.
+func NewLevelServer(addr string) *manager.Server {
+	mux := http.NewServeMux()
+	mux.Handle(LevelPath, LevelHandler())
+	return &manager.Server{
+		Name: "log-level",
+		Server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// IntoContext return the ctx carrying the logger of ctx with keysAndValues, the logger is got by `klog.FromContext`.
+func IntoContext(ctx context.Context, keysAndValues ...interface{}) (context.Context, logr.Logger) {
+	logger := klog.FromContext(ctx).WithValues(keysAndValues...)
+	return klog.NewContext(ctx, logger), logger
+}
@@ -0,0 +1,141 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func Test_SetLevel(t *testing.T) {
+	defer SetLevel("info")
+	tests := map[string]string{"debug": "debug", "ERROR": "error", "4": "4", "1": "debug", "info": "info"}
+	for text, expect := range tests {
+		if err := SetLevel(text); err != nil {
+			t.Errorf("set level %s failed, err=%s", text, err.Error())
+		}
+		if l := GetLevel(); l != expect {
+			t.Errorf("the level of %s is %s, expect %s", text, l, expect)
+		}
+	}
+	for _, text := range []string{"verbose", "0", "-1"} {
+		if err := SetLevel(text); err == nil {
+			t.Errorf("the invalid level %s should be rejected.", text)
+		}
+	}
+
+	SetLevel("4")
+	if !klog.V(4).Enabled() || klog.V(5).Enabled() {
+		t.Errorf("the verbosity of klog should be 4.")
+	}
+	SetLevel("info")
+	if klog.V(1).Enabled() {
+		t.Errorf("the verbosity of klog should be 0 in info level.")
+	}
+}
+
+func Test_Setup(t *testing.T) {
+	defer SetLevel("info")
+	var buf bytes.Buffer
+	opts := &zap.Options{Level: uberzap.NewAtomicLevelAt(zapcore.ErrorLevel), DestWriter: &buf}
+	logger := Setup(opts, FormatJSON)
+	if GetLevel() != "error" {
+		t.Errorf("the initial level should be error, actual %s", GetLevel())
+	}
+	logger.Info("not written")
+	SetLevel("info")
+	logger.WithValues(ClusterKey, "test").Info("written")
+
+	out := buf.String()
+	if strings.Contains(out, "not written") {
+		t.Errorf("the info log should not be written in error level.")
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &entry); err != nil {
+		t.Fatalf("the log is not json, %s", out)
+	}
+	if entry["msg"] != "written" || entry[ClusterKey] != "test" {
+		t.Errorf("the structured log not match, %s", out)
+	}
+}
+
+func Test_SetupConsole(t *testing.T) {
+	defer SetLevel("info")
+	var buf bytes.Buffer
+	opts := &zap.Options{Development: true, DestWriter: &buf}
+	logger := Setup(opts, FormatConsole)
+	if GetLevel() != "debug" {
+		t.Errorf("the initial level of development should be debug, actual %s", GetLevel())
+	}
+	logger.Info("written")
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &entry); err == nil {
+		t.Errorf("the log should be written in console format, %s", buf.String())
+	}
+}
+
+func Test_LevelHandler(t *testing.T) {
+	defer SetLevel("info")
+	h := LevelHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, LevelPath, strings.NewReader(`{"level":"debug"}`)))
+	if w.Code != http.StatusOK || GetLevel() != "debug" {
+		t.Errorf("put level failed, code=%d body=%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LevelPath, nil))
+	if strings.TrimSpace(w.Body.String()) != `{"level":"debug"}` {
+		t.Errorf("get level not match, body=%s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, LevelPath, strings.NewReader(`{"level":"verbose"}`)))
+	if w.Code != http.StatusBadRequest || GetLevel() != "debug" {
+		t.Errorf("the invalid level should be rejected, code=%d level=%s", w.Code, GetLevel())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, LevelPath, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("post should not be allowed, code=%d", w.Code)
+	}
+}
+
+func Test_IntoContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zap.New(zap.WriteTo(&buf))
+	ctx := klog.NewContext(context.Background(), logger)
+	ctx, _ = IntoContext(ctx, ClusterKey, "test")
+	ctx, _ = IntoContext(ctx, ComponentKey, "fe")
+	klog.FromContext(ctx).Info("sync")
+	if !strings.Contains(buf.String(), `"cluster":"test"`) || !strings.Contains(buf.String(), `"component":"fe"`) {
+		t.Errorf("the values of context not written, %s", buf.String())
+	}
+}
@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package metrics defines the metrics exported by operator, the metrics are registered in the registry of controller-runtime and served on the metrics endpoint of operator.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// the labels of operator metrics, the names are consistent with the labels added on the scraped metrics of doris, `namespace` is avoided as it is overwritten by the target label of Prometheus.
+const (
+	KindLabel         = "kind"
+	NamespaceLabel    = "doris_namespace"
+	ClusterLabel      = "doris_cluster"
+	ComponentLabel    = "doris_component"
+	ComputeGroupLabel = "doris_compute_group"
+	HealthLabel       = "health"
+	PhaseLabel        = "phase"
+	TypeLabel         = "type"
+	ControllerLabel   = "controller"
+	OperationLabel    = "operation"
+	TargetLabel       = "target"
+)
+
+// the types of targets of the synthetic query probing in the `type` label.
+const (
+	ProbeTargetFE           = "fe"
+	ProbeTargetComputeGroup = "compute_group"
+)
+
+// the kinds of clusters in the `kind` label.
+const (
+	DorisClusterKind              = "DorisCluster"
+	DorisDisaggregatedClusterKind = "DorisDisaggregatedCluster"
+)
+
+const ComputeGroupAvailableName = "doris_operator_compute_group_available"
+
+var (
+	// ClusterHealth is 1 for the current health of cluster and 0 for the others, health is one of green, yellow and red.
+	ClusterHealth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_cluster_health",
+		Help: "The health of doris cluster, 1 for the current health and 0 for the others.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, HealthLabel})
+
+	// ComponentPhase is 1 for the current phase of the component, only the current phase is exported.
+	ComponentPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_component_phase",
+		Help: "The phase of the component of doris cluster, the value is 1 for the current phase.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, ComponentLabel, PhaseLabel})
+
+	// ComputeGroupPhase is 1 for the current phase of the compute group of DorisDisaggregatedCluster.
+	ComputeGroupPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_compute_group_phase",
+		Help: "The phase of the compute group of DorisDisaggregatedCluster, the value is 1 for the current phase.",
+	}, []string{NamespaceLabel, ClusterLabel, ComputeGroupLabel, PhaseLabel})
+
+	// ComputeGroupAvailable is 1 when the compute group of DorisDisaggregatedCluster is available, 0 when the `AvailableStatus` of compute group is UnAvailable.
+	ComputeGroupAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: ComputeGroupAvailableName,
+		Help: "Whether the compute group of DorisDisaggregatedCluster is available, 1 is available and 0 is unavailable.",
+	}, []string{NamespaceLabel, ClusterLabel, ComputeGroupLabel})
+
+	// GracefulActionPhase is 1 for the current phase of the graceful action in progress on the compute group.
+	GracefulActionPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_graceful_action_phase",
+		Help: "The phase of the graceful action in progress on the compute group, the value is 1 for the current phase.",
+	}, []string{NamespaceLabel, ClusterLabel, ComputeGroupLabel, TypeLabel, PhaseLabel})
+
+	// GracefulActionDuration is the seconds since the graceful action of the current pod started.
+	GracefulActionDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_graceful_action_duration_seconds",
+		Help: "Seconds since the graceful action of the current pod in the compute group started.",
+	}, []string{NamespaceLabel, ClusterLabel, ComputeGroupLabel, TypeLabel})
+
+	// DecommissionRemainingTablets is the number of tablets not migrated from the decommissioning backends, the compute group is empty for DorisCluster.
+	DecommissionRemainingTablets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_decommission_remaining_tablets",
+		Help: "The number of tablets not migrated from the decommissioning backends.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, ComputeGroupLabel})
+
+	// DecommissionProgress is the ratio of migrated tablets of the decommissioning backends, from 0 to 1.
+	DecommissionProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_decommission_progress_ratio",
+		Help: "The ratio of tablets migrated from the decommissioning backends, from 0 to 1.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, ComputeGroupLabel})
+
+	// DecommissionStalled is 1 when the tablets number of decommissioning backends has not decreased for a long time.
+	DecommissionStalled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_decommission_stalled",
+		Help: "Whether the decommission of backends is stalled, 1 is stalled.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, ComputeGroupLabel})
+
+	// SQLDuration is the latency of the sql statements executed by operator on doris, the operation is the leading keywords of statement.
+	SQLDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "doris_operator_sql_duration_seconds",
+		Help:    "The latency of the sql statements executed by operator on doris.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{OperationLabel})
+
+	// SQLErrors counts the failed sql statements executed by operator on doris.
+	SQLErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "doris_operator_sql_errors_total",
+		Help: "The number of failed sql statements executed by operator on doris.",
+	}, []string{OperationLabel})
+
+	// QueryProbeSuccess is 1 when the last synthetic query on the fe or in the compute group succeeded, the target is the host of fe or the name of compute group.
+	QueryProbeSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_query_probe_success",
+		Help: "Whether the last synthetic query on the fe or in the compute group succeeded, 1 is succeeded.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, TypeLabel, TargetLabel})
+
+	// QueryProbeLatency is the latency of the last synthetic query on the fe or in the compute group.
+	QueryProbeLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doris_operator_query_probe_latency_seconds",
+		Help: "The latency of the last synthetic query on the fe or in the compute group.",
+	}, []string{KindLabel, NamespaceLabel, ClusterLabel, TypeLabel, TargetLabel})
+
+	// ReconcileErrors counts the errors returned by the sub controllers when reconciling clusters.
+	ReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "doris_operator_reconcile_errors_total",
+		Help: "The number of errors returned by the sub controllers when reconciling doris clusters.",
+	}, []string{KindLabel, ControllerLabel})
+)
+
+// the metrics of clusters, they are deleted when the cluster deleted.
+var clusterMetrics = []*prometheus.GaugeVec{ClusterHealth, ComponentPhase, DecommissionRemainingTablets, DecommissionProgress, DecommissionStalled, QueryProbeSuccess, QueryProbeLatency}
+
+// the metrics of compute groups, they are reset when the status of DorisDisaggregatedCluster recorded.
+var computeGroupMetrics = []*prometheus.GaugeVec{ComputeGroupPhase, ComputeGroupAvailable, GracefulActionPhase, GracefulActionDuration}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(ClusterHealth, ComponentPhase, ComputeGroupPhase, ComputeGroupAvailable, GracefulActionPhase, GracefulActionDuration,
+		DecommissionRemainingTablets, DecommissionProgress, DecommissionStalled, QueryProbeSuccess, QueryProbeLatency, SQLDuration, SQLErrors, ReconcileErrors)
+}
+
+// IncReconcileErrors count the error of sub controller reconciling the cluster of kind.
+func IncReconcileErrors(kind, controller string) {
+	ReconcileErrors.WithLabelValues(kind, controller).Inc()
+}
+
+// DeleteCluster delete the metrics of the cluster when the cluster deleted.
+func DeleteCluster(kind, namespace, cluster string) {
+	for _, m := range clusterMetrics {
+		m.DeletePartialMatch(prometheus.Labels{KindLabel: kind, NamespaceLabel: namespace, ClusterLabel: cluster})
+	}
+	if kind == DorisDisaggregatedClusterKind {
+		deleteComputeGroups(namespace, cluster)
+	}
+}
+
+func deleteComputeGroups(namespace, cluster string) {
+	for _, m := range computeGroupMetrics {
+		m.DeletePartialMatch(prometheus.Labels{NamespaceLabel: namespace, ClusterLabel: cluster})
+	}
+}
+
+// ObserveSQL record the latency of the sql statement started at start, and count it when failed.
+func ObserveSQL(operation string, start time.Time, err error) {
+	SQLDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		SQLErrors.WithLabelValues(operation).Inc()
+	}
+}
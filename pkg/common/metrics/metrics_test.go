@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_RecordDisaggregatedClusterStatus(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status: dv1.DorisDisaggregatedClusterStatus{
+			ClusterHealth:     dv1.ClusterHealth{Health: dv1.Yellow},
+			FEStatus:          dv1.FEStatus{Phase: dv1.Ready},
+			MetaServiceStatus: dv1.MetaServiceStatus{Phase: dv1.Reconciling},
+			ComputeGroupStatuses: []dv1.ComputeGroupStatus{{
+				UniqueId:        "cg1",
+				Phase:           dv1.GracefulRolling,
+				AvailableStatus: dv1.Available,
+				GracefulAction: &dv1.GracefulAction{Type: dv1.GracefulActionRollingUpdate, Phase: dv1.GracefulPhaseWaitDrain,
+					StartedAt: metav1.NewTime(time.Now().Add(-time.Minute))},
+				DecommissionStatus: &dv1.DecommissionStatus{InitialTabletNum: 100, RemainingTabletNum: 25},
+			}, {
+				UniqueId:        "cg2",
+				Phase:           dv1.Reconciling,
+				AvailableStatus: dv1.UnAvailable,
+			}},
+		},
+	}
+
+	RecordDisaggregatedClusterStatus(ddc)
+	kind := DorisDisaggregatedClusterKind
+	if v := testutil.ToFloat64(ClusterHealth.WithLabelValues(kind, "default", "test", "yellow")); v != 1 {
+		t.Errorf("the current health should be 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(ClusterHealth.WithLabelValues(kind, "default", "test", "green")); v != 0 {
+		t.Errorf("the other health should be 0, got %v", v)
+	}
+	if v := testutil.ToFloat64(ComponentPhase.WithLabelValues(kind, "default", "test", "ms", "Reconciling")); v != 1 {
+		t.Errorf("the phase of ms should be Reconciling.")
+	}
+	if v := testutil.ToFloat64(ComputeGroupAvailable.WithLabelValues("default", "test", "cg2")); v != 0 {
+		t.Errorf("the unavailable compute group should be 0, got %v", v)
+	}
+	if v := testutil.ToFloat64(GracefulActionDuration.WithLabelValues("default", "test", "cg1", "RollingUpdate")); v < 60 {
+		t.Errorf("the duration of graceful action should be more than 60s, got %v", v)
+	}
+	if v := testutil.ToFloat64(DecommissionProgress.WithLabelValues(kind, "default", "test", "cg1")); v != 0.75 {
+		t.Errorf("the decommission progress should be 0.75, got %v", v)
+	}
+
+	// the finished graceful action and decommission are not exported.
+	ddc.Status.ComputeGroupStatuses[0].GracefulAction = nil
+	ddc.Status.ComputeGroupStatuses[0].DecommissionStatus = nil
+	ddc.Status.FEStatus.Phase = dv1.Reconciling
+	RecordDisaggregatedClusterStatus(ddc)
+	if n := testutil.CollectAndCount(GracefulActionPhase); n != 0 {
+		t.Errorf("the graceful action phase should be deleted, got %d series", n)
+	}
+	if n := testutil.CollectAndCount(DecommissionProgress); n != 0 {
+		t.Errorf("the decommission progress should be deleted, got %d series", n)
+	}
+	if n := testutil.CollectAndCount(ComponentPhase); n != 2 {
+		t.Errorf("only the current phases of fe and ms should be exported, got %d series", n)
+	}
+
+	DeleteCluster(kind, "default", "test")
+	if n := testutil.CollectAndCount(ComputeGroupAvailable) + testutil.CollectAndCount(ClusterHealth); n != 0 {
+		t.Errorf("the metrics of deleted cluster should be deleted, got %d series", n)
+	}
+}
+
+func Test_RecordDorisClusterStatus(t *testing.T) {
+	dcr := &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status: dorisv1.DorisClusterStatus{
+			FEStatus: &dorisv1.ComponentStatus{ComponentCondition: dorisv1.ComponentCondition{Phase: dorisv1.Available}},
+			BEStatus: &dorisv1.ComponentStatus{ComponentCondition: dorisv1.ComponentCondition{Phase: dorisv1.Scaling},
+				DecommissionStatus: &dorisv1.DecommissionStatus{InitialTabletNum: 10, RemainingTabletNum: 10, Stalled: true}},
+			QueryProbe: &dorisv1.QueryProbeStatus{Frontends: []dorisv1.QueryProbeResult{
+				{Target: "10.0.0.1", Succeeded: true, LatencyMilliseconds: 20},
+				{Target: "10.0.0.2", Succeeded: false, LatencyMilliseconds: 5000},
+			}},
+		},
+	}
+
+	RecordDorisClusterStatus(dcr)
+	kind := DorisClusterKind
+	if v := testutil.ToFloat64(ComponentPhase.WithLabelValues(kind, "default", "test", "be", string(dorisv1.Scaling))); v != 1 {
+		t.Errorf("the phase of be should be exported.")
+	}
+	if v := testutil.ToFloat64(DecommissionStalled.WithLabelValues(kind, "default", "test", "")); v != 1 {
+		t.Errorf("the stalled decommission should be 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(QueryProbeSuccess.WithLabelValues(kind, "default", "test", ProbeTargetFE, "10.0.0.2")); v != 0 {
+		t.Errorf("the failed probe should be 0, got %v", v)
+	}
+	if v := testutil.ToFloat64(QueryProbeLatency.WithLabelValues(kind, "default", "test", ProbeTargetFE, "10.0.0.1")); v != 0.02 {
+		t.Errorf("the latency of probe should be 0.02s, got %v", v)
+	}
+	DeleteCluster(kind, "default", "test")
+	if n := testutil.CollectAndCount(ComponentPhase) + testutil.CollectAndCount(QueryProbeSuccess); n != 0 {
+		t.Errorf("the metrics of deleted cluster should be deleted, got %d series", n)
+	}
+}
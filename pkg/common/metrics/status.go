@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var healths = []string{string(dv1.Green), string(dv1.Yellow), string(dv1.Red)}
+
+// RecordDorisClusterStatus export the health, the phases of components and the decommission progress of be in the status of DorisCluster.
+func RecordDorisClusterStatus(dcr *dorisv1.DorisCluster) {
+	status := &dcr.Status
+	if status.ClusterHealth != nil {
+		setClusterHealth(DorisClusterKind, dcr.Namespace, dcr.Name, string(status.ClusterHealth.Health))
+	}
+	components := map[dorisv1.ComponentType]*dorisv1.ComponentStatus{
+		dorisv1.Component_FE:     status.FEStatus,
+		dorisv1.Component_BE:     status.BEStatus,
+		dorisv1.Component_Broker: status.BrokerStatus,
+	}
+	if status.CnStatus != nil {
+		components[dorisv1.Component_CN] = &status.CnStatus.ComponentStatus
+	} else {
+		components[dorisv1.Component_CN] = nil
+	}
+	for componentType, cs := range components {
+		var phase string
+		if cs != nil {
+			phase = string(cs.ComponentCondition.Phase)
+		}
+		setComponentPhase(DorisClusterKind, dcr.Namespace, dcr.Name, string(componentType), phase)
+	}
+
+	deleteDecommission(DorisClusterKind, dcr.Namespace, dcr.Name)
+	if status.BEStatus != nil && status.BEStatus.DecommissionStatus != nil {
+		ds := status.BEStatus.DecommissionStatus
+		setDecommission(DorisClusterKind, dcr.Namespace, dcr.Name, "", ds.InitialTabletNum, ds.RemainingTabletNum, ds.Stalled)
+	}
+
+	deleteQueryProbe(DorisClusterKind, dcr.Namespace, dcr.Name)
+	if status.QueryProbe != nil {
+		for _, r := range status.QueryProbe.Frontends {
+			setQueryProbe(DorisClusterKind, dcr.Namespace, dcr.Name, ProbeTargetFE, r.Target, r.Succeeded, r.LatencyMilliseconds)
+		}
+	}
+}
+
+// RecordDisaggregatedClusterStatus export the health, the phases of components and compute groups, the graceful actions and decommission progress of compute groups
+// in the status of DorisDisaggregatedCluster.
+func RecordDisaggregatedClusterStatus(ddc *dv1.DorisDisaggregatedCluster) {
+	status := &ddc.Status
+	setClusterHealth(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, string(status.ClusterHealth.Health))
+	setComponentPhase(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, strings.ToLower(string(dv1.DisaggregatedFE)), string(status.FEStatus.Phase))
+	setComponentPhase(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, strings.ToLower(string(dv1.DisaggregatedMS)), string(status.MetaServiceStatus.Phase))
+
+	deleteComputeGroups(ddc.Namespace, ddc.Name)
+	deleteDecommission(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name)
+	deleteQueryProbe(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name)
+	if status.QueryProbe != nil {
+		for _, r := range status.QueryProbe.Frontends {
+			setQueryProbe(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, ProbeTargetFE, r.Target, r.Succeeded, r.LatencyMilliseconds)
+		}
+	}
+	for _, cgs := range status.ComputeGroupStatuses {
+		if cgs.Phase != "" {
+			ComputeGroupPhase.WithLabelValues(ddc.Namespace, ddc.Name, cgs.UniqueId, string(cgs.Phase)).Set(1)
+		}
+		ComputeGroupAvailable.WithLabelValues(ddc.Namespace, ddc.Name, cgs.UniqueId).Set(boolToFloat(cgs.AvailableStatus == dv1.Available))
+
+		if ga := cgs.GracefulAction; ga != nil && ga.Phase != dv1.GracefulPhaseDone {
+			GracefulActionPhase.WithLabelValues(ddc.Namespace, ddc.Name, cgs.UniqueId, string(ga.Type), string(ga.Phase)).Set(1)
+			if !ga.StartedAt.IsZero() {
+				GracefulActionDuration.WithLabelValues(ddc.Namespace, ddc.Name, cgs.UniqueId, string(ga.Type)).Set(time.Since(ga.StartedAt.Time).Seconds())
+			}
+		}
+		if ds := cgs.DecommissionStatus; ds != nil {
+			setDecommission(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, cgs.UniqueId, ds.InitialTabletNum, ds.RemainingTabletNum, ds.Stalled)
+		}
+		if r := cgs.QueryProbe; r != nil {
+			setQueryProbe(DorisDisaggregatedClusterKind, ddc.Namespace, ddc.Name, ProbeTargetComputeGroup, cgs.UniqueId, r.Succeeded, r.LatencyMilliseconds)
+		}
+	}
+}
+
+func setClusterHealth(kind, namespace, cluster, health string) {
+	for _, h := range healths {
+		ClusterHealth.WithLabelValues(kind, namespace, cluster, h).Set(boolToFloat(h == health))
+	}
+}
+
+// setComponentPhase only keep the series of current phase, the component is not exported when phase is empty.
+func setComponentPhase(kind, namespace, cluster, component, phase string) {
+	ComponentPhase.DeletePartialMatch(prometheus.Labels{KindLabel: kind, NamespaceLabel: namespace, ClusterLabel: cluster, ComponentLabel: component})
+	if phase != "" {
+		ComponentPhase.WithLabelValues(kind, namespace, cluster, component, phase).Set(1)
+	}
+}
+
+func setDecommission(kind, namespace, cluster, computeGroup string, initial, remaining int64, stalled bool) {
+	DecommissionRemainingTablets.WithLabelValues(kind, namespace, cluster, computeGroup).Set(float64(remaining))
+	progress := float64(1)
+	if initial > 0 {
+		progress = float64(initial-remaining) / float64(initial)
+	}
+	DecommissionProgress.WithLabelValues(kind, namespace, cluster, computeGroup).Set(progress)
+	DecommissionStalled.WithLabelValues(kind, namespace, cluster, computeGroup).Set(boolToFloat(stalled))
+}
+
+func deleteDecommission(kind, namespace, cluster string) {
+	for _, m := range []*prometheus.GaugeVec{DecommissionRemainingTablets, DecommissionProgress, DecommissionStalled} {
+		m.DeletePartialMatch(prometheus.Labels{KindLabel: kind, NamespaceLabel: namespace, ClusterLabel: cluster})
+	}
+}
+
+func setQueryProbe(kind, namespace, cluster, targetType, target string, succeeded bool, latencyMilliseconds int64) {
+	QueryProbeSuccess.WithLabelValues(kind, namespace, cluster, targetType, target).Set(boolToFloat(succeeded))
+	QueryProbeLatency.WithLabelValues(kind, namespace, cluster, targetType, target).Set(float64(latencyMilliseconds) / 1000)
+}
+
+func deleteQueryProbe(kind, namespace, cluster string) {
+	for _, m := range []*prometheus.GaugeVec{QueryProbeSuccess, QueryProbeLatency} {
+		m.DeletePartialMatch(prometheus.Labels{KindLabel: kind, NamespaceLabel: namespace, ClusterLabel: cluster})
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tracing provides the OpenTelemetry tracing of operator, spans are exported by OTLP when an endpoint configured, otherwise the global no-op tracer is used.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of tracer used by operator.
+const TracerName = "github.com/apache/doris-operator"
+
+// the attributes of spans.
+const (
+	NamespaceKey    = attribute.Key("doris.namespace")
+	ClusterKey      = attribute.Key("doris.cluster")
+	KindKey         = attribute.Key("doris.kind")
+	ControllerKey   = attribute.Key("doris.controller")
+	ComputeGroupKey = attribute.Key("doris.compute_group")
+	PhaseKey        = attribute.Key("doris.graceful_phase")
+	OperationKey    = attribute.Key("db.operation")
+)
+
+// Options configure the exporting of spans.
+type Options struct {
+	// Endpoint is the address of OTLP gRPC receiver, e.g. `otel-collector.monitoring:4317`. tracing is disabled when empty.
+	Endpoint string
+	// Insecure disables the transport security of exporting.
+	Insecure bool
+	// SampleRatio is the ratio of sampled traces in [0, 1], the sampling of parent span is respected.
+	SampleRatio float64
+	// ServiceName is the `service.name` of spans.
+	ServiceName string
+}
+
+// Setup install the tracer provider exporting to the OTLP endpoint as global, the returned shutdown flushes the spans in buffer.
+// Setup is no-op when endpoint not configured.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exOpts = append(exOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exOpts...)
+	if err != nil {
+		return nil, err
+	}
+	tp := NewTracerProvider(exporter, opts)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// NewTracerProvider return the tracer provider exporting spans by exporter in batch, tests can use an in-memory exporter.
+func NewTracerProvider(exporter sdktrace.SpanExporter, opts Options) *sdktrace.TracerProvider {
+	serviceName := opts.ServiceName
+	if serviceName == "" {
+		serviceName = "doris-operator"
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
+
+// Start start a span named name as the child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End record the err on span when not nil, and end the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ClusterAttributes return the attributes identifying the cluster.
+func ClusterAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{KindKey.String(kind), NamespaceKey.String(namespace), ClusterKey.String(name)}
+}
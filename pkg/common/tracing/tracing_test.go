@@ -0,0 +1,74 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Setup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("setup tracing without endpoint failed, err=%s", err.Error())
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown of disabled tracing failed, err=%s", err.Error())
+	}
+}
+
+func Test_StartEnd(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := NewTracerProvider(exporter, Options{SampleRatio: 1})
+	otel.SetTracerProvider(tp)
+	defer tp.Shutdown(context.Background())
+
+	ctx, parent := Start(context.Background(), "DorisCluster.Reconcile", ClusterAttributes("DorisCluster", "default", "test")...)
+	_, child := Start(ctx, "fe.Sync", ControllerKey.String("fe"))
+	End(child, errors.New("sync failed"))
+	End(parent, nil)
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans failed, err=%s", err.Error())
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("the number of spans not match, expected 2, actual %d", len(spans))
+	}
+	c, p := spans[0], spans[1]
+	if c.Name != "fe.Sync" || p.Name != "DorisCluster.Reconcile" {
+		t.Errorf("the names of spans not match, child=%s parent=%s", c.Name, p.Name)
+	}
+	if c.Parent.SpanID() != p.SpanContext.SpanID() {
+		t.Errorf("the sync span is not the child of reconcile span.")
+	}
+	if c.Status.Code != codes.Error || len(c.Events) != 1 {
+		t.Errorf("the error of sync not recorded, status=%v events=%d", c.Status, len(c.Events))
+	}
+	if p.Status.Code == codes.Error {
+		t.Errorf("the reconcile span should not be error.")
+	}
+	if p.Resource.String() != "service.name=doris-operator" {
+		t.Errorf("the resource of spans not match, %s", p.Resource.String())
+	}
+}
@@ -0,0 +1,153 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultRootCAExpireTimeout is the validity of the ca issued for doris cluster.
+var DefaultRootCAExpireTimeout = 10 * 365 * 24 * time.Hour
+
+// NewRootCA create a self-signed ca that can issue certificates for doris components.
+func NewRootCA(subject pkix.Name, validity time.Duration) (*CA, error) {
+	serial, err := rand.Int(rand.Reader, SerialNumberLimit)
+	if err != nil {
+		return nil, errors.New("new root CA failed, " + err.Error())
+	}
+
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.New("generate key failed, " + err.Error())
+	}
+
+	certificate := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-10 * time.Minute),
+		NotAfter:              time.Now().Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, certificate, certificate, &pk.PublicKey, pk)
+	if err != nil {
+		return nil, errors.New("failed to create root certificate, " + err.Error())
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.New("failed to parse root certificate, " + err.Error())
+	}
+
+	return &CA{
+		privateKey:  pemEncode(PrivateKey_Type, x509.MarshalPKCS1PrivateKey(pk)),
+		cert:        pemEncode(Cert_Type, der),
+		PrivateKey:  pk,
+		Certificate: cert,
+	}, nil
+}
+
+// IssueCertificate issue a certificate signed by the ca, the certificate can be used for server and client authentication.
+func (ca *CA) IssueCertificate(options CAOptions, validity time.Duration) (*CA, error) {
+	serial, err := rand.Int(rand.Reader, SerialNumberLimit)
+	if err != nil {
+		return nil, errors.New("issue certificate failed, " + err.Error())
+	}
+
+	pk := options.PrivateKey
+	if pk == nil {
+		if pk, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			return nil, errors.New("generate key failed, " + err.Error())
+		}
+	}
+
+	notAfter := time.Now().Add(validity)
+	// the certificate should not outlive the ca.
+	if notAfter.After(ca.Certificate.NotAfter) {
+		notAfter = ca.Certificate.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               options.Subject,
+		NotBefore:             time.Now().Add(-10 * time.Minute),
+		NotAfter:              notAfter,
+		DNSNames:              options.DnsNames,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, &pk.PublicKey, ca.PrivateKey)
+	if err != nil {
+		return nil, errors.New("failed to issue certificate, " + err.Error())
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.New("failed to parse issued certificate, " + err.Error())
+	}
+
+	return &CA{
+		privateKey:  pemEncode(PrivateKey_Type, x509.MarshalPKCS1PrivateKey(pk)),
+		cert:        pemEncode(Cert_Type, der),
+		PrivateKey:  pk,
+		Certificate: cert,
+	}, nil
+}
+
+// CertificateNeedRenew check the certificate should be reissued or not. the certificate will be reissued when it is not signed by the ca,
+// the dns names changed or it will expire in renewBefore.
+func CertificateNeedRenew(cert *x509.Certificate, ca *x509.Certificate, dnsNames []string, renewBefore time.Duration) bool {
+	if cert == nil || ca == nil {
+		return true
+	}
+
+	if err := cert.CheckSignatureFrom(ca); err != nil {
+		return true
+	}
+
+	if !stringSetEqual(cert.DNSNames, dnsNames) {
+		return true
+	}
+
+	return time.Now().Add(renewBefore).After(cert.NotAfter)
+}
+
+func stringSetEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
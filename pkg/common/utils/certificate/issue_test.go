@@ -0,0 +1,66 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+)
+
+func Test_IssueCertificate(t *testing.T) {
+	ca, err := NewRootCA(pkix.Name{CommonName: "test-ca", Organization: []string{"doris-operator"}}, DefaultRootCAExpireTimeout)
+	if err != nil {
+		t.Fatalf("new root ca failed, err=%s", err.Error())
+	}
+	if !ca.Certificate.IsCA {
+		t.Errorf("root ca is not a ca certificate.")
+	}
+
+	dnsNames := []string{"test-fe-internal", "*.test-fe-internal.default.svc"}
+	cert, err := ca.IssueCertificate(CAOptions{Subject: pkix.Name{CommonName: "test-fe"}, DnsNames: dnsNames}, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("issue certificate failed, err=%s", err.Error())
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Certificate)
+	if _, err := cert.Certificate.Verify(x509.VerifyOptions{DNSName: "test-fe-0.test-fe-internal.default.svc", Roots: pool}); err != nil {
+		t.Errorf("issued certificate verify failed, err=%s", err.Error())
+	}
+
+	parsed, err := ParsePemCert(cert.GetEncodeCert())
+	if err != nil {
+		t.Fatalf("parse issued certificate failed, err=%s", err.Error())
+	}
+	if CertificateNeedRenew(parsed, ca.Certificate, []string{"*.test-fe-internal.default.svc", "test-fe-internal"}, time.Hour) {
+		t.Errorf("the certificate should not be renewed.")
+	}
+	if !CertificateNeedRenew(parsed, ca.Certificate, dnsNames, 25*time.Hour) {
+		t.Errorf("the certificate will expire in renewBefore, should be renewed.")
+	}
+	if !CertificateNeedRenew(parsed, ca.Certificate, []string{"test-fe-internal"}, time.Hour) {
+		t.Errorf("the dns names changed, the certificate should be renewed.")
+	}
+
+	otherCA, _ := NewRootCA(pkix.Name{CommonName: "other-ca"}, DefaultRootCAExpireTimeout)
+	if !CertificateNeedRenew(parsed, otherCA.Certificate, dnsNames, time.Hour) {
+		t.Errorf("the certificate not signed by ca, should be renewed.")
+	}
+}
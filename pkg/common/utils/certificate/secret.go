@@ -27,8 +27,9 @@ import (
 )
 
 const (
-	TlsKeyName  = "tls.key"
-	TLsCertName = "tls.crt"
+	TlsKeyName    = "tls.key"
+	TLsCertName   = "tls.crt"
+	TlsCACertName = "ca.crt"
 )
 
 // build from secret, the secret keys should contains tls.key, tls.
@@ -62,7 +63,7 @@ func BuildCAFromSecret(s *corev1.Secret) *CA {
 
 	return &CA{
 		Certificate: cert,
-		cert:        pkBytes,
+		cert:        caBytes,
 		PrivateKey:  pk,
 		privateKey:  pkBytes,
 	}
@@ -71,6 +72,9 @@ func BuildCAFromSecret(s *corev1.Secret) *CA {
 // parse privateKey, suppose the private key type="RSA PRIVATE KEY"
 func parsePrivateKey(pkBytes []byte) (*rsa.PrivateKey, error) {
 	pkb, _ := pem.Decode(pkBytes)
+	if pkb == nil {
+		return nil, errors.New("have not pem private key")
+	}
 	pk, err := x509.ParsePKCS1PrivateKey(pkb.Bytes)
 	if err != nil {
 		return nil, err
@@ -79,6 +83,20 @@ func parsePrivateKey(pkBytes []byte) (*rsa.PrivateKey, error) {
 	return pk, nil
 }
 
+// GetCABundle returns the ca that signed the certificate in secret. the secret issued by cert-manager have `ca.crt`,
+// the self-signed secret use the certificate as ca.
+func GetCABundle(s *corev1.Secret) []byte {
+	if ca := s.Data[TlsCACertName]; len(ca) != 0 {
+		return ca
+	}
+	return s.Data[TLsCertName]
+}
+
+// ParsePemCert return the first certificate in pem encoded bytes.
+func ParsePemCert(caBytes []byte) (*x509.Certificate, error) {
+	return parsePemCert(caBytes)
+}
+
 // return the first parsed certificate.
 func parsePemCert(caBytes []byte) (*x509.Certificate, error) {
 	b, _ := pem.Decode(caBytes)
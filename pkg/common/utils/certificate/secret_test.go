@@ -76,3 +76,14 @@ func Test_BuildCAFromSecret(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetCABundle(t *testing.T) {
+	s := &corev1.Secret{Data: map[string][]byte{TLsCertName: []byte("cert")}}
+	if string(GetCABundle(s)) != "cert" {
+		t.Errorf("the ca bundle should be tls.crt when ca.crt not exist.")
+	}
+	s.Data[TlsCACertName] = []byte("ca")
+	if string(GetCABundle(s)) != "ca" {
+		t.Errorf("the ca bundle should be ca.crt.")
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the http client for requesting the webserver of be.
+var beHttpClient = &http.Client{Timeout: 10 * time.Second}
+
+// BackendHttpClient requests the http api of be webserver with the management user.
+type BackendHttpClient struct {
+	// the address of be webserver, eg: `http://10.0.0.1:8040`.
+	Endpoint string
+	User     string
+	Password string
+
+	client *http.Client
+}
+
+// NewBackendHttpClient build the client of be webserver by host and http port. tlsConfig is not nil when the webserver serves https
+// (`enable_https = true` in be.conf), the webserver is requested by https and verified with the ca in tlsConfig.
+func NewBackendHttpClient(host string, port int, user, password string, tlsConfig *tls.Config) *BackendHttpClient {
+	c := &BackendHttpClient{
+		Endpoint: "http://" + host + ":" + strconv.Itoa(port),
+		User:     user,
+		Password: password,
+		client:   beHttpClient,
+	}
+	if tlsConfig != nil {
+		c.Endpoint = "https://" + host + ":" + strconv.Itoa(port)
+		c.client = &http.Client{
+			Timeout:   beHttpClient.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+	}
+	return c
+}
+
+// ShowConfigs request `/api/show_config`, returns the configs of be and whether the configs are mutable.
+// every row of response is `[name, type, value, is_mutable]`.
+func (c *BackendHttpClient) ShowConfigs(ctx context.Context) (map[string]bool, error) {
+	body, err := c.do(ctx, http.MethodGet, "/api/show_config")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, fmt.Errorf("unmarshal the response of show_config failed, err=%s", err.Error())
+	}
+	mutableConfigs := map[string]bool{}
+	for _, row := range rows {
+		if len(row) < 4 {
+			continue
+		}
+		mutableConfigs[row[0]] = strings.EqualFold(row[3], "true")
+	}
+	return mutableConfigs, nil
+}
+
+// UpdateConfigs request `/api/update_config` to modify the mutable configs at runtime, the configs are persisted in `be_custom.conf`.
+func (c *BackendHttpClient) UpdateConfigs(ctx context.Context, configs map[string]string) error {
+	query := url.Values{}
+	for key, value := range configs {
+		query.Set(key, value)
+	}
+	query.Set("persist", "true")
+	body, err := c.do(ctx, http.MethodPost, "/api/update_config?"+query.Encode())
+	if err != nil {
+		return err
+	}
+
+	// the response is a list of the status of every config, the old versions response one status.
+	var results []struct {
+		ConfigName string `json:"config_name"`
+		Status     string `json:"status"`
+		Msg        string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil {
+		var result struct {
+			Status string `json:"status"`
+			Msg    string `json:"msg"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return fmt.Errorf("unmarshal the response of update_config failed, err=%s", err.Error())
+		}
+		if result.Status != "OK" {
+			return fmt.Errorf("update_config failed, msg=%s", result.Msg)
+		}
+		return nil
+	}
+
+	for _, result := range results {
+		if result.Status != "OK" {
+			return fmt.Errorf("update config %s failed, msg=%s", result.ConfigName, result.Msg)
+		}
+	}
+	return nil
+}
+
+func (c *BackendHttpClient) do(ctx context.Context, method, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.Endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.User, c.Password)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request %s %s failed, status=%d, body=%s", method, path, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConfigChanges describes the differences between the old and new configs of fe.conf or be.conf.
+type ConfigChanges struct {
+	// the added or modified configs with the new values.
+	Changed map[string]string
+	// the configs removed from the config file, the default values are only used after restart.
+	Removed []string
+}
+
+// DiffConfigs compare the resolved configs of config file.
+func DiffConfigs(oldConfigs, newConfigs map[string]interface{}) ConfigChanges {
+	cc := ConfigChanges{Changed: map[string]string{}}
+	for key, nv := range newConfigs {
+		value := fmt.Sprint(nv)
+		if ov, ok := oldConfigs[key]; !ok || fmt.Sprint(ov) != value {
+			cc.Changed[key] = value
+		}
+	}
+	for key := range oldConfigs {
+		if _, ok := newConfigs[key]; !ok {
+			cc.Removed = append(cc.Removed, key)
+		}
+	}
+	sort.Strings(cc.Removed)
+	return cc
+}
+
+// IsEmpty returns true when no config changed.
+func (cc ConfigChanges) IsEmpty() bool {
+	return len(cc.Changed) == 0 && len(cc.Removed) == 0
+}
+
+// Classify split the changed keys into the keys can be modified at runtime and the keys need restart by the mutable configs reported by doris.
+// the removed keys and the keys unknown to doris always need restart.
+func (cc ConfigChanges) Classify(mutableConfigs map[string]bool) (mutableKeys, immutableKeys []string) {
+	for key := range cc.Changed {
+		if mutableConfigs[key] {
+			mutableKeys = append(mutableKeys, key)
+		} else {
+			immutableKeys = append(immutableKeys, key)
+		}
+	}
+	immutableKeys = append(immutableKeys, cc.Removed...)
+	sort.Strings(mutableKeys)
+	sort.Strings(immutableKeys)
+	return mutableKeys, immutableKeys
+}
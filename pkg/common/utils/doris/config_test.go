@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	oldConfigs := map[string]interface{}{"a": "1", "b": "2", "c": "3"}
+	newConfigs := map[string]interface{}{"a": "1", "b": "20", "d": "4"}
+
+	cc := DiffConfigs(oldConfigs, newConfigs)
+	if !reflect.DeepEqual(cc.Changed, map[string]string{"b": "20", "d": "4"}) || !reflect.DeepEqual(cc.Removed, []string{"c"}) {
+		t.Errorf("diff configs not expected, changes=%v", cc)
+	}
+
+	mutableKeys, immutableKeys := cc.Classify(map[string]bool{"b": true, "c": true})
+	if !reflect.DeepEqual(mutableKeys, []string{"b"}) || !reflect.DeepEqual(immutableKeys, []string{"c", "d"}) {
+		t.Errorf("classify configs not expected, mutable=%v, immutable=%v", mutableKeys, immutableKeys)
+	}
+
+	if !DiffConfigs(oldConfigs, oldConfigs).IsEmpty() {
+		t.Errorf("expected no changes for the same configs")
+	}
+}
+
+func TestBackendHttpClient(t *testing.T) {
+	var updated string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "root" || password != "pwd" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/show_config":
+			w.Write([]byte(`[["disable_auto_compaction","bool","false","true"],["be_port","int32","9060","false"]]`))
+		case "/api/update_config":
+			updated = r.URL.RawQuery
+			w.Write([]byte(`[{"config_name":"disable_auto_compaction","status":"OK","msg":""}]`))
+		}
+	}))
+	defer srv.Close()
+
+	hostPort := strings.Split(strings.TrimPrefix(srv.URL, "http://"), ":")
+	port, _ := strconv.Atoi(hostPort[1])
+	c := NewBackendHttpClient(hostPort[0], port, "root", "pwd", nil)
+	mutableConfigs, err := c.ShowConfigs(context.Background())
+	if err != nil {
+		t.Fatalf("show configs failed, err=%s", err.Error())
+	}
+	if !mutableConfigs["disable_auto_compaction"] || mutableConfigs["be_port"] {
+		t.Errorf("show configs not expected, configs=%v", mutableConfigs)
+	}
+
+	if err := c.UpdateConfigs(context.Background(), map[string]string{"disable_auto_compaction": "true"}); err != nil {
+		t.Fatalf("update configs failed, err=%s", err.Error())
+	}
+	if updated != "disable_auto_compaction=true&persist=true" {
+		t.Errorf("update configs query not expected, query=%s", updated)
+	}
+
+	c.Password = "wrong"
+	if _, err := c.ShowConfigs(context.Background()); err == nil {
+		t.Errorf("expected unauthorized request failed")
+	}
+}
+
+func TestBackendHttpClient_https(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[["disable_auto_compaction","bool","false","true"]]`))
+	}))
+	defer srv.Close()
+
+	hostPort := strings.Split(strings.TrimPrefix(srv.URL, "https://"), ":")
+	port, _ := strconv.Atoi(hostPort[1])
+	if _, err := NewBackendHttpClient(hostPort[0], port, "root", "", nil).ShowConfigs(context.Background()); err == nil {
+		t.Errorf("expected the http request to https webserver failed")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	c := NewBackendHttpClient(hostPort[0], port, "root", "", &tls.Config{RootCAs: pool})
+	if !strings.HasPrefix(c.Endpoint, "https://") {
+		t.Errorf("expected https endpoint, endpoint=%s", c.Endpoint)
+	}
+	if mutableConfigs, err := c.ShowConfigs(context.Background()); err != nil || !mutableConfigs["disable_auto_compaction"] {
+		t.Errorf("show configs by https failed, configs=%v, err=%v", mutableConfigs, err)
+	}
+}
@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import (
+	"bufio"
+	"embed"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the components that have the list of known configs.
+const (
+	ConfigComponentFE = "fe"
+	ConfigComponentBE = "be"
+	ConfigComponentMS = "ms"
+)
+
+// the lists of known configs, the file named `{component}-{version}.txt` lists the configs added in the version.
+//
+//go:embed known_configs/*.txt
+var knownConfigFiles embed.FS
+
+var (
+	versionRegexp = regexp.MustCompile(`(\d+)\.(\d+)`)
+	// the known configs of component by version, the configs of a version contain the configs of earlier versions.
+	knownConfigs = loadKnownConfigs()
+)
+
+// knownConfigVersion is the known configs added in the version.
+type knownConfigVersion struct {
+	major, minor int
+	keys         []string
+}
+
+func loadKnownConfigs() map[string][]knownConfigVersion {
+	res := map[string][]knownConfigVersion{}
+	entries, _ := knownConfigFiles.ReadDir("known_configs")
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+		cv := strings.SplitN(name, "-", 2)
+		if len(cv) != 2 {
+			continue
+		}
+		major, minor, ok := ParseVersion(cv[1])
+		if !ok {
+			continue
+		}
+		content, err := knownConfigFiles.ReadFile("known_configs/" + entry.Name())
+		if err != nil {
+			continue
+		}
+
+		kcv := knownConfigVersion{major: major, minor: minor}
+		scanner := bufio.NewScanner(strings.NewReader(string(content)))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// the keys of resolved configs are lower case.
+			kcv.keys = append(kcv.keys, strings.ToLower(line))
+		}
+		res[cv[0]] = append(res[cv[0]], kcv)
+	}
+
+	for _, versions := range res {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].major < versions[j].major || (versions[i].major == versions[j].major && versions[i].minor < versions[j].minor)
+		})
+	}
+	return res
+}
+
+// ParseVersion parse the major and minor version from the version or the tag of image, eg: `2.1.7`, `apache/doris:fe-2.1.7`.
+func ParseVersion(s string) (int, int, bool) {
+	if i := strings.LastIndex(s, ":"); i != -1 {
+		s = s[i+1:]
+	}
+	m := versionRegexp.FindStringSubmatch(s)
+	if len(m) != 3 {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+// KnownConfigKeys return the known configs of component in the doris version parsed from image, the configs of all versions are returned
+// when the version can not be parsed. returns nil when the component has no list of known configs.
+func KnownConfigKeys(component, image string) map[string]bool {
+	versions := knownConfigs[component]
+	if len(versions) == 0 {
+		return nil
+	}
+
+	major, minor, ok := ParseVersion(image)
+	keys := map[string]bool{}
+	for _, kcv := range versions {
+		if ok && (kcv.major > major || (kcv.major == major && kcv.minor > minor)) {
+			break
+		}
+		for _, key := range kcv.keys {
+			keys[key] = true
+		}
+	}
+	// the version earlier than all lists uses the earliest list.
+	if len(keys) == 0 {
+		for _, key := range versions[0].keys {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// SimilarConfigKey return the known config closest to the key in the typo distance, the lists of known configs are not complete,
+// so only the key close to a known config is considered misspelled. returns false when the key is known or not close to any known config.
+func SimilarConfigKey(key string, known map[string]bool) (string, bool) {
+	if known[key] {
+		return "", false
+	}
+	// the short keys allow less typos for not matching the different configs.
+	maxDistance := 1
+	if len(key) >= 8 {
+		maxDistance = 2
+	}
+
+	similar, minDistance := "", maxDistance+1
+	for k := range known {
+		d := editDistance(key, k)
+		if d < minDistance || (d == minDistance && k < similar) {
+			similar, minDistance = k, d
+		}
+	}
+	return similar, minDistance <= maxDistance
+}
+
+// editDistance return the levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
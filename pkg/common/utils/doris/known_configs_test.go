@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import "testing"
+
+func Test_ParseVersion(t *testing.T) {
+	tests := []struct {
+		s            string
+		major, minor int
+		ok           bool
+	}{
+		{"2.1.7", 2, 1, true},
+		{"apache/doris:fe-3.0.3", 3, 0, true},
+		{"registry:5000/apache/doris:be-2.1.7", 2, 1, true},
+		{"apache/doris:latest", 0, 0, false},
+	}
+
+	for _, test := range tests {
+		major, minor, ok := ParseVersion(test.s)
+		if major != test.major || minor != test.minor || ok != test.ok {
+			t.Errorf("ParseVersion(%s) = %d, %d, %t, expected %d, %d, %t", test.s, major, minor, ok, test.major, test.minor, test.ok)
+		}
+	}
+}
+
+func Test_KnownConfigKeys(t *testing.T) {
+	keys := KnownConfigKeys(ConfigComponentFE, "apache/doris:fe-2.1.7")
+	if !keys["http_port"] || !keys["java_opts"] {
+		t.Errorf("the configs of 2.1 not known, keys=%v", keys)
+	}
+	if keys["deploy_mode"] {
+		t.Errorf("the configs added in 3.0 should not be known by 2.1")
+	}
+
+	if keys = KnownConfigKeys(ConfigComponentFE, "apache/doris:fe-3.0.3"); !keys["http_port"] || !keys["deploy_mode"] {
+		t.Errorf("the configs of 3.0 should contain the configs of earlier versions, keys=%v", keys)
+	}
+	if keys = KnownConfigKeys(ConfigComponentFE, "apache/doris:latest"); !keys["deploy_mode"] {
+		t.Errorf("the configs of all versions should be known when version not parsed")
+	}
+	if keys = KnownConfigKeys(ConfigComponentMS, "apache/doris:ms-2.1.0"); !keys["brpc_listen_port"] {
+		t.Errorf("the earliest configs should be known when version earlier than all lists")
+	}
+	if keys = KnownConfigKeys("broker", "apache/doris:broker-2.1.7"); keys != nil {
+		t.Errorf("the component without known configs should return nil")
+	}
+}
+
+func Test_SimilarConfigKey(t *testing.T) {
+	known := KnownConfigKeys(ConfigComponentFE, "apache/doris:fe-2.1.7")
+	tests := []struct {
+		key     string
+		similar string
+		ok      bool
+	}{
+		{"htp_port", "http_port", true},
+		{"meta_dirr", "meta_dir", true},
+		{"edit_log_prot", "edit_log_port", true},
+		{"http_port", "", false},
+		{"enable_new_feature_not_listed", "", false},
+	}
+
+	for _, test := range tests {
+		similar, ok := SimilarConfigKey(test.key, known)
+		if ok != test.ok || (ok && similar != test.similar) {
+			t.Errorf("SimilarConfigKey(%s) = %s, %t, expected %s, %t", test.key, similar, ok, test.similar, test.ok)
+		}
+	}
+}
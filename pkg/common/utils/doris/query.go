@@ -0,0 +1,38 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	selectPrefix = regexp.MustCompile(`(?i)^select\b`)
+	intoOutfile  = regexp.MustCompile(`(?i)\binto\s+outfile\b`)
+)
+
+// IsSelectQuery returns true when the sql is a single SELECT statement that not exports the result by `INTO OUTFILE`, the trailing `;` is allowed.
+// the queries run by operator with the management user are limited to read only by it.
+func IsSelectQuery(sql string) bool {
+	q := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(sql), ";"))
+	if strings.Contains(q, ";") {
+		return false
+	}
+	return selectPrefix.MatchString(q) && !intoOutfile.MatchString(q)
+}
@@ -0,0 +1,33 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package doris
+
+import "testing"
+
+func Test_IsSelectQuery(t *testing.T) {
+	for _, q := range []string{"SELECT 1", " select count(*) from db.t; ", "SELECT\n1"} {
+		if !IsSelectQuery(q) {
+			t.Errorf("IsSelectQuery should allow %q", q)
+		}
+	}
+	for _, q := range []string{"", "DROP DATABASE db", "SELECT 1; DROP DATABASE db", "selectx 1", "SELECT * FROM t INTO OUTFILE \"s3://bucket/a\"", "/* c */ DELETE FROM t"} {
+		if IsSelectQuery(q) {
+			t.Errorf("IsSelectQuery should reject %q", q)
+		}
+	}
+}
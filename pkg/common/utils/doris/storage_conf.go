@@ -18,12 +18,50 @@
 package doris
 
 import (
+	"strconv"
 	"strings"
 )
 
+// the medium of storage root path.
+const (
+	StorageMediumSSD = "SSD"
+	StorageMediumHDD = "HDD"
+)
+
+// StorageRootPath describes one path of `storage_root_path`, eg: `/home/disk1/doris.SSD,50` or `/home/disk1/doris,medium:ssd,capacity:50`.
+type StorageRootPath struct {
+	Path string
+	// Medium is SSD or HDD, empty represents not specified.
+	Medium string
+	// Capacity is the limit of path in GB, 0 represents not limited.
+	Capacity int64
+}
+
+// String renders the path in format of `storage_root_path`, eg: `/home/disk1/doris.SSD,50`.
+func (srp StorageRootPath) String() string {
+	res := srp.Path
+	if srp.Medium != "" {
+		res = res + "." + srp.Medium
+	}
+	if srp.Capacity > 0 {
+		res = res + "," + strconv.FormatInt(srp.Capacity, 10)
+	}
+	return res
+}
+
 // ResolveStorageRootPath transforms a string of storage paths into a slice of StorageRootPathInfo.
 func ResolveStorageRootPath(configPath string) []string {
 	var res []string
+	for _, srp := range ResolveStorageRootPathInfos(configPath) {
+		res = append(res, srp.Path)
+	}
+
+	return res
+}
+
+// ResolveStorageRootPathInfos parse `storage_root_path` with the medium and capacity of every path.
+func ResolveStorageRootPathInfos(configPath string) []StorageRootPath {
+	var res []StorageRootPath
 
 	if configPath == "" {
 		return res
@@ -34,8 +72,8 @@ func ResolveStorageRootPath(configPath string) []string {
 
 	// Remove empty elements
 	for _, c := range configPathSplit {
-		if path := parseSinglePath(c); path != "" {
-			res = append(res, path)
+		if srp := parseSinglePath(c); srp.Path != "" {
+			res = append(res, srp)
 		}
 	}
 
@@ -43,16 +81,48 @@ func ResolveStorageRootPath(configPath string) []string {
 }
 
 // Resolving a single storage path
-func parseSinglePath(pathConfig string) string {
+func parseSinglePath(pathConfig string) StorageRootPath {
+	var srp StorageRootPath
 	if pathConfig == "" {
-		return ""
+		return srp
+	}
+
+	properties := strings.Split(pathConfig, ",")
+	path := strings.TrimSpace(properties[0])
+	// the medium specified by suffix of path, eg: /home/disk1/doris.HDD
+	if i := strings.LastIndex(path, "."); i != -1 {
+		if medium := strings.ToUpper(path[i+1:]); medium == StorageMediumSSD || medium == StorageMediumHDD {
+			srp.Medium = medium
+			path = path[:i]
+		}
 	}
-	path := strings.Split(strings.Split(pathConfig, ".")[0], ",")[0]
-	path = strings.TrimSpace(path)
 	if strings.HasSuffix(path, "/") {
 		path = path[:len(path)-1]
 	}
-	return path
+	srp.Path = path
+
+	for _, property := range properties[1:] {
+		kv := strings.SplitN(property, ":", 2)
+		if len(kv) == 1 {
+			// the old format, the capacity is specified without key, eg: /home/disk1/doris.HDD,50
+			if capacity, err := strconv.ParseInt(strings.TrimSpace(kv[0]), 10, 64); err == nil {
+				srp.Capacity = capacity
+			}
+			continue
+		}
+
+		value := strings.TrimSpace(kv[1])
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "medium":
+			srp.Medium = strings.ToUpper(value)
+		case "capacity":
+			if capacity, err := strconv.ParseInt(value, 10, 64); err == nil {
+				srp.Capacity = capacity
+			}
+		}
+	}
+
+	return srp
 }
 
 // GetNameOfEachPath is used to parse a set of paths to obtain unique and concise names for each path.
@@ -98,6 +98,38 @@ func TestResolveStorageRootPath(t *testing.T) {
 	}
 }
 
+func TestResolveStorageRootPathInfos(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []StorageRootPath
+	}{
+		{
+			input: "/home/disk1/doris.HDD,50;/home/disk2/doris.v2",
+			want:  []StorageRootPath{{Path: "/home/disk1/doris", Medium: StorageMediumHDD, Capacity: 50}, {Path: "/home/disk2/doris.v2"}},
+		},
+		{
+			input: "/home/disk1/doris, medium:ssd ,capacity:100",
+			want:  []StorageRootPath{{Path: "/home/disk1/doris", Medium: StorageMediumSSD, Capacity: 100}},
+		},
+		{
+			input: "/home/disk1/doris.HDD,medium:ssd",
+			want:  []StorageRootPath{{Path: "/home/disk1/doris", Medium: StorageMediumSSD}},
+		},
+	}
+
+	for _, tt := range tests {
+		got := ResolveStorageRootPathInfos(tt.input)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("input: %s, got %v, Expectation %v", tt.input, got, tt.want)
+		}
+	}
+
+	srp := StorageRootPath{Path: "/home/disk1/doris", Medium: StorageMediumSSD, Capacity: 50}
+	if srp.String() != "/home/disk1/doris.SSD,50" {
+		t.Errorf("StorageRootPath render failed, got %s", srp.String())
+	}
+}
+
 func TestGetNameOfEachPath(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -19,6 +19,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
@@ -29,10 +30,12 @@ import (
 	v1 "k8s.io/api/autoscaling/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -189,6 +192,17 @@ func PatchOrCreate(ctx context.Context, k8sclient client.Client, object client.O
 	return nil
 }
 
+// MergePatchClientObject patch the object with the json merge patch built from patch, the field will be removed when the value is nil.
+// the object is copied for patching, so the changes of object in memory will not be overwritten by the response.
+func MergePatchClientObject(ctx context.Context, k8sclient client.Client, object client.Object, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	return k8sclient.Patch(ctx, object.DeepCopyObject().(client.Object), client.RawPatch(types.MergePatchType, data))
+}
+
 func DeleteClientObject(ctx context.Context, k8sclient client.Client, object client.Object) error {
 	if err := k8sclient.Delete(ctx, object); err != nil {
 		return err
@@ -265,6 +279,19 @@ func GetSecret(ctx context.Context, k8sclient client.Client, namespace, name str
 	return &secret, nil
 }
 
+// StorageClassAllowVolumeExpansion check the StorageClass of the pvc allows volume expansion or not.
+func StorageClassAllowVolumeExpansion(ctx context.Context, k8sclient client.Client, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+
+	var sc storagev1.StorageClass
+	if err := k8sclient.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, &sc); err != nil {
+		return false, err
+	}
+	return sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion, nil
+}
+
 func CreateSecret(ctx context.Context, k8sclient client.Client, secret *corev1.Secret) error {
 	return k8sclient.Create(ctx, secret)
 }
@@ -276,6 +303,15 @@ func UpdateSecret(ctx context.Context, k8sclient client.Client, secret *corev1.S
 	return nil
 }
 
+// DeleteSecret delete the secret, not found is ignored.
+func DeleteSecret(ctx context.Context, k8sclient client.Client, namespace, name string) error {
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if err := k8sclient.Delete(ctx, &secret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // GetConfigMap get the configmap name=name, namespace=namespace.
 func GetConfigMap(ctx context.Context, k8scient client.Client, namespace, name string) (*corev1.ConfigMap, error) {
 	var configMap corev1.ConfigMap
@@ -286,6 +322,41 @@ func GetConfigMap(ctx context.Context, k8scient client.Client, namespace, name s
 	return &configMap, nil
 }
 
+// ApplyConfigMap create the configmap if not exist, or update the data of configmap when changed.
+func ApplyConfigMap(ctx context.Context, k8sclient client.Client, cm *corev1.ConfigMap) error {
+	var ecm corev1.ConfigMap
+	err := k8sclient.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, &ecm)
+	if apierrors.IsNotFound(err) {
+		return k8sclient.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(ecm.Data, cm.Data) {
+		return nil
+	}
+	ecm.Data = cm.Data
+	return k8sclient.Update(ctx, &ecm)
+}
+
+// FieldManager is the field manager of operator in server side apply.
+const FieldManager = "doris-operator"
+
+// ServerSideApply apply the object by server side apply, the operator takes the ownership of fields in object.
+// used for the resources that not registered in scheme, as cert-manager `Certificate`.
+func ServerSideApply(ctx context.Context, k8sclient client.Client, object client.Object) error {
+	return k8sclient.Patch(ctx, object, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// DeleteConfigMap delete the configmap, not found is not an error.
+func DeleteConfigMap(ctx context.Context, k8sclient client.Client, namespace, name string) error {
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if err := k8sclient.Delete(ctx, &cm); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // GetConfigMaps get the configmap by the array of MountConfigMapInfo and namespace.
 func GetConfigMaps(ctx context.Context, k8scient client.Client, namespace string, cms []dorisv1.MountConfigMapInfo) ([]*corev1.ConfigMap, error) {
 	var configMaps []*corev1.ConfigMap
@@ -377,6 +448,7 @@ func GetFoundationDBCluster(ctx context.Context, k8sclient client.Client, namesp
 }
 
 // DeletePVC clean up existing pvc by pvc name, namespace and labels
+// DeletePVC delete the pvc, the finalizer added by operator is removed firstly, otherwise the pvc will be terminating forever.
 func DeletePVC(ctx context.Context, k8sclient client.Client, namespace, pvcName string, labels map[string]string) error {
 	pvc := corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -385,6 +457,12 @@ func DeletePVC(ctx context.Context, k8sclient client.Client, namespace, pvcName
 			Labels:    labels,
 		},
 	}
+	if epvc, err := GetPVC(ctx, k8sclient, pvcName, namespace); err == nil && resource.RemovePVCFinalizers(epvc) {
+		if err := k8sclient.Update(ctx, epvc); err != nil {
+			return err
+		}
+	}
+
 	err := k8sclient.Delete(ctx, &pvc)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return err
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -220,6 +221,14 @@ func Test_DeletePVC(t *testing.T) {
 			},
 			Spec: corev1.PersistentVolumeClaimSpec{},
 		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test2",
+				Namespace:  "test",
+				Finalizers: []string{"apache.doris.org/pvc-finalizer"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{},
+		},
 	}
 	fakeClient := fake.NewClientBuilder().WithObjects(pvcs...).Build()
 
@@ -232,6 +241,10 @@ func Test_DeletePVC(t *testing.T) {
 			Name:      "test1",
 			Namespace: "test",
 		},
+		{
+			Name:      "test2",
+			Namespace: "test",
+		},
 	}
 	for _, nn := range testInnamespaces {
 		err := DeletePVC(context.Background(), fakeClient, nn.Namespace, nn.Name, map[string]string{})
@@ -239,4 +252,60 @@ func Test_DeletePVC(t *testing.T) {
 			t.Errorf("delete pvc failed, pvc name=%s, err=%s", nn.Name, err.Error())
 		}
 	}
+
+	if _, err := GetPVC(context.Background(), fakeClient, "test2", "test"); err == nil {
+		t.Errorf("pvc with operator finalizer should be deleted.")
+	}
+}
+
+func Test_MergePatchClientObject(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{"remove": "true", "keep": "true"},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(cm).Build()
+
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{"remove": nil, "add": "new"},
+		},
+	}
+	if err := MergePatchClientObject(context.Background(), fakeClient, cm, patch); err != nil {
+		t.Errorf("merge patch failed, err=%s", err.Error())
+	}
+	if _, ok := cm.Annotations["add"]; ok {
+		t.Errorf("merge patch should not change the object in memory.")
+	}
+
+	var ecm corev1.ConfigMap
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "test"}, &ecm); err != nil {
+		t.Errorf("get configmap failed, err=%s", err.Error())
+	}
+	if _, ok := ecm.Annotations["remove"]; ok || ecm.Annotations["keep"] != "true" || ecm.Annotations["add"] != "new" {
+		t.Errorf("merge patch annotations not correct, annotations=%v", ecm.Annotations)
+	}
+}
+
+func Test_StorageClassAllowVolumeExpansion(t *testing.T) {
+	allow := true
+	sc := &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+		Provisioner:          "test",
+		AllowVolumeExpansion: &allow,
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(sc).Build()
+
+	scName := "expandable"
+	pvc := &corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: &scName}}
+	if ok, err := StorageClassAllowVolumeExpansion(context.Background(), fakeClient, pvc); err != nil || !ok {
+		t.Errorf("storageclass %s should allow volume expansion, err=%v", scName, err)
+	}
+
+	pvc.Spec.StorageClassName = nil
+	if ok, _ := StorageClassAllowVolumeExpansion(context.Background(), fakeClient, pvc); ok {
+		t.Errorf("pvc without storageclass should not allow volume expansion.")
+	}
 }
@@ -29,6 +29,8 @@ import (
 const (
 	FE_FOLLOWER_ROLE = "FOLLOWER"
 	FE_OBSERVE_ROLE  = "OBSERVER"
+	// the node role of cn in `show backends`, be is `mix`.
+	BE_COMPUTATION_ROLE = "computation"
 )
 
 type Frontend struct {
@@ -53,6 +55,40 @@ type Frontend struct {
 	CurrentConnected   string  `json:"current_connected" db:"CurrentConnected"`
 }
 
+// FrontendConfig is the config of fe displayed by `ADMIN SHOW FRONTEND CONFIG`.
+type FrontendConfig struct {
+	Key        string `json:"key" db:"Key"`
+	Value      string `json:"value" db:"Value"`
+	Type       string `json:"type" db:"Type"`
+	IsMutable  bool   `json:"is_mutable" db:"IsMutable"`
+	MasterOnly bool   `json:"master_only" db:"MasterOnly"`
+	Comment    string `json:"comment" db:"Comment"`
+}
+
+// TableProperty is the property of table displayed by `information_schema.table_properties`.
+type TableProperty struct {
+	Name  string `json:"property_name" db:"PROPERTY_NAME"`
+	Value string `json:"property_value" db:"PROPERTY_VALUE"`
+}
+
+// ReplicationNum return the number of replicas from the property `replication_num` or `replication_allocation`,
+// eg: `3` or `tag.location.default: 2, tag.location.group_a: 1`. returns 0 when the value can not be parsed.
+func (tp *TableProperty) ReplicationNum() int32 {
+	var num int64
+	for _, alloc := range strings.Split(tp.Value, ",") {
+		value := alloc
+		if i := strings.LastIndex(alloc, ":"); i != -1 {
+			value = alloc[i+1:]
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil {
+			return 0
+		}
+		num += n
+	}
+	return int32(num)
+}
+
 type Backend struct {
 	BackendID               string  `json:"backend_id" db:"BackendId"`
 	Host                    string  `json:"host" db:"Host"`
@@ -18,13 +18,19 @@
 package mysql
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/apache/doris-operator/pkg/common/metrics"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/go-sql-driver/mysql"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
@@ -33,7 +39,8 @@ import (
 )
 
 const (
-	COMPUTE_GROUP_ID = "compute_group_id"
+	COMPUTE_GROUP_ID   = "compute_group_id"
+	COMPUTE_GROUP_NAME = "compute_group_name"
 )
 
 type DBConfig struct {
@@ -42,6 +49,8 @@ type DBConfig struct {
 	Host     string
 	Port     string
 	Database string
+	// Timeout bounds dialing, reading and writing of the connections, not limited when 0.
+	Timeout time.Duration
 }
 
 type TLSConfig struct {
@@ -58,6 +67,8 @@ func NewDBConfig() DBConfig {
 
 type DB struct {
 	*sqlx.DB
+	// ctx bounds the statements and is the parent of their spans, the statements are not bounded and the spans are roots when nil.
+	ctx context.Context
 }
 
 // Debugging this code cannot be done through a standalone process on the host machine;
@@ -91,6 +102,13 @@ func NewDorisSqlDB(cfg DBConfig, tlsConfig *TLSConfig, secret *corev1.Secret) (*
 		}
 		dsn = dsn + "?tls=" + registerKey
 	}
+	if cfg.Timeout > 0 {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn = dsn + sep + fmt.Sprintf("timeout=%s&readTimeout=%s&writeTimeout=%s", cfg.Timeout, cfg.Timeout, cfg.Timeout)
+	}
 
 	db, err := sqlx.Open("mysql", dsn)
 	if err != nil {
@@ -102,7 +120,7 @@ func NewDorisSqlDB(cfg DBConfig, tlsConfig *TLSConfig, secret *corev1.Secret) (*
 		klog.Errorf("NewDorisSqlDB sqlx.Open.Ping failed ping doris sql client connection, err: %s \n", err.Error())
 		return nil, err
 	}
-	return &DB{db}, nil
+	return &DB{DB: db}, nil
 }
 
 func NewDorisMasterSqlDB(dbConf DBConfig, tlsConfig *TLSConfig, secret *corev1.Secret) (*DB, error) {
@@ -129,6 +147,7 @@ func NewDorisMasterSqlDB(dbConf DBConfig, tlsConfig *TLSConfig, secret *corev1.S
 			Host:     master.Host,
 			Port:     dbConf.Port,
 			Database: "mysql",
+			Timeout:  dbConf.Timeout,
 		}, tlsConfig, secret)
 		if err != nil {
 			klog.Errorf("NewDorisMasterSqlDB failed, get fe master connection  err:%s", err.Error())
@@ -142,16 +161,87 @@ func (db *DB) Close() error {
 	return db.DB.Close()
 }
 
+// WithContext return a copy of db that runs the statements bounded by ctx and traces them as the children of the span in ctx, the copy shares the connections of db.
+func (db *DB) WithContext(ctx context.Context) *DB {
+	return &DB{DB: db.DB, ctx: ctx}
+}
+
+// context return the context of statements, background when db not bound to a context.
+func (db *DB) context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
 func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
-	return db.DB.Unsafe().Exec(query, args...)
+	var res sql.Result
+	err := db.observe(query, func() (err error) {
+		res, err = db.DB.Unsafe().ExecContext(db.context(), query, args...)
+		return err
+	})
+	return res, err
 }
 
 func (db *DB) Select(dest interface{}, query string, args ...interface{}) error {
-	return db.DB.Select(dest, query, args...)
+	return db.observe(query, func() error {
+		return db.DB.SelectContext(db.context(), dest, query, args...)
+	})
 }
 
 func (db *DB) USelect(dest interface{}, query string, args ...interface{}) error {
-	return db.DB.Unsafe().Select(dest, query, args...)
+	return db.observe(query, func() error {
+		return db.DB.Unsafe().SelectContext(db.context(), dest, query, args...)
+	})
+}
+
+// observe run the statement by exec, the duration and result of statement are recorded in the sql metrics and a span.
+func (db *DB) observe(query string, exec func() error) error {
+	ctx := db.context()
+	operation := sqlOperation(query)
+	_, span := tracing.Start(ctx, "SQL "+operation, tracing.OperationKey.String(operation))
+	start := time.Now()
+	err := exec()
+	metrics.ObserveSQL(operation, start, err)
+	tracing.End(span, err)
+	return err
+}
+
+// sqlOperation return the two leading keywords of statement in upper case as the operation of sql metrics, e.g. `SHOW BACKENDS`, `ALTER SYSTEM`.
+// the arguments of statement are not used for keeping the cardinality of metrics low.
+func sqlOperation(query string) string {
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	})
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+	return strings.ToUpper(strings.Join(fields, " "))
+}
+
+// ProbeQuery run the query on a connection for checking the queries work, the query runs in the compute group by `USE @<compute group>` when computeGroup not empty.
+// the session of connection is switched to the compute group, so the db should be dedicated to probing.
+func (db *DB) ProbeQuery(ctx context.Context, computeGroup, query string) error {
+	return db.observe(query, func() error {
+		conn, err := db.DB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		if computeGroup != "" {
+			if _, err = conn.ExecContext(ctx, "USE @`"+computeGroup+"`"); err != nil {
+				return err
+			}
+		}
+		rows, err := conn.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+		}
+		return rows.Err()
+	})
 }
 
 func (db *DB) ShowFrontends() ([]*Frontend, error) {
@@ -181,6 +271,21 @@ func (db *DB) DecommissionBE(nodes []*Backend) error {
 	return err
 }
 
+func (db *DB) CancelDecommissionBE(nodes []*Backend) error {
+	if len(nodes) == 0 {
+		klog.Infoln("mysql CancelDecommissionBE BE node is empty")
+		return nil
+	}
+	nodesString := fmt.Sprintf(`"%s:%d"`, nodes[0].Host, nodes[0].HeartbeatPort)
+	for _, node := range nodes[1:] {
+		nodesString = nodesString + fmt.Sprintf(`,"%s:%d"`, node.Host, node.HeartbeatPort)
+	}
+
+	cancel := fmt.Sprintf("CANCEL DECOMMISSION BACKEND %s;", nodesString)
+	_, err := db.Exec(cancel)
+	return err
+}
+
 func (db *DB) DropBE(nodes []*Backend) error {
 	if len(nodes) == 0 {
 		klog.Infoln("mysql DropBE BE node is empty")
@@ -271,3 +376,58 @@ func (db *DB) GetFollowers() (*Frontend, []*Frontend, error) {
 	}
 	return master, res, nil
 }
+
+// GetUserHosts return the hosts of the identities of user, eg: '%' of 'root'@'%'.
+func (db *DB) GetUserHosts(user string) ([]string, error) {
+	var hosts []string
+	if err := db.Select(&hosts, "SELECT Host FROM mysql.user WHERE User = ?", user); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
+// SetPassword set the password of the user identity 'user'@'host'.
+func (db *DB) SetPassword(user, host, password string) error {
+	set := fmt.Sprintf("SET PASSWORD FOR '%s'@'%s' = PASSWORD('%s');", escapeString(user), escapeString(host), escapeString(password))
+	_, err := db.Exec(set)
+	return err
+}
+
+// escapeString escape the backslash and single quote for using the value in a single quoted string literal.
+func escapeString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+// ShowFrontendConfigs return the configs of the connected fe.
+func (db *DB) ShowFrontendConfigs() ([]*FrontendConfig, error) {
+	var configs []*FrontendConfig
+	err := db.USelect(&configs, "ADMIN SHOW FRONTEND CONFIG")
+	return configs, err
+}
+
+// SetFrontendConfig set the mutable config of the connected fe at runtime.
+func (db *DB) SetFrontendConfig(key, value string) error {
+	set := fmt.Sprintf("ADMIN SET FRONTEND CONFIG ('%s' = '%s');", escapeString(key), escapeString(value))
+	_, err := db.Exec(set)
+	return err
+}
+
+// GetMaxReplicationNum return the max number of replicas of all tables, the tables without replication properties are not counted.
+// the query is canceled when ctx done.
+func (db *DB) GetMaxReplicationNum(ctx context.Context) (int32, error) {
+	var properties []*TableProperty
+	query := "SELECT PROPERTY_NAME, PROPERTY_VALUE FROM information_schema.table_properties WHERE PROPERTY_NAME IN ('replication_num', 'replication_allocation')"
+	if err := db.observe(query, func() error {
+		return db.DB.SelectContext(ctx, &properties, query)
+	}); err != nil {
+		return 0, err
+	}
+
+	var max int32
+	for _, p := range properties {
+		if num := p.ReplicationNum(); num > max {
+			max = num
+		}
+	}
+	return max, nil
+}
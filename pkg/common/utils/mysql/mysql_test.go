@@ -18,13 +18,20 @@
 package mysql
 
 import (
+	"context"
 	_ "crypto/tls"
 	"database/sql/driver"
+	"errors"
+	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func Test_ShowFrontends(t *testing.T) {
@@ -142,6 +149,46 @@ func Test_DecommissionBE(t *testing.T) {
 	}
 }
 
+func Test_CancelDecommissionBE(t *testing.T) {
+	version := "doris-2.1.5-rc02-d5a02e095d"
+	startTime := "2024-08-21 10:05:37"
+	heartbeat := "2024-08-22 08:29:46"
+	values := []*Backend{{BackendID: "10009", Host: "doriscluster-sample-be-0.doriscluster-sample-be-internal.default.svc.cluster.local", HeartbeatPort: 9050, BePort: 9060, HttpPort: 8040, BrpcPort: 8060, ArrowFlightSqlPort: -1, LastStartTime: &startTime,
+		LastHeartbeat: &heartbeat, Alive: true, TabletNum: 24, DataUsedCapacity: "0.000", TrashUsedCapacity: "0.000", AvailCapacity: "74.619 GB", TotalCapacity: "439.037 GB", UsedPct: "83.00 %", MaxDiskUsedPct: "83.00 %",
+		RemoteUsedCapacity: "0.000", ErrMsg: "", Version: &version, Status: "{\"lastSuccessReportTabletsTime\":\"2024-08-22 08:29:09\",\"lastStreamLoadTime\":-1,\"isQueryDisabled\":false,\"isLoadDisabled\":false}", HeartbeatFailureCounter: 0, NodeRole: "mix"}}
+	values2 := []*Backend{{BackendID: "10009", Host: "doriscluster-sample-be-0.doriscluster-sample-be-internal.default.svc.cluster.local", HeartbeatPort: 9050, BePort: 9060, HttpPort: 8040, BrpcPort: 8060, ArrowFlightSqlPort: -1, LastStartTime: &startTime,
+		LastHeartbeat: &heartbeat, Alive: true, TabletNum: 24, DataUsedCapacity: "0.000", TrashUsedCapacity: "0.000", AvailCapacity: "74.619 GB", TotalCapacity: "439.037 GB", UsedPct: "83.00 %", MaxDiskUsedPct: "83.00 %",
+		RemoteUsedCapacity: "0.000", ErrMsg: "", Version: &version, Status: "{\"lastSuccessReportTabletsTime\":\"2024-08-22 08:29:09\",\"lastStreamLoadTime\":-1,\"isQueryDisabled\":false,\"isLoadDisabled\":false}", HeartbeatFailureCounter: 0, NodeRole: "mix"},
+		{BackendID: "10010", Host: "doriscluster-sample-be-1.doriscluster-sample-be-internal.default.svc.cluster.local", HeartbeatPort: 9050, BePort: 9060, HttpPort: 8040, BrpcPort: 8060, ArrowFlightSqlPort: -1, LastStartTime: &startTime,
+			LastHeartbeat: &heartbeat, Alive: true, TabletNum: 24, DataUsedCapacity: "0.000", TrashUsedCapacity: "0.000", AvailCapacity: "74.619 GB", TotalCapacity: "439.037 GB", UsedPct: "83.00 %", MaxDiskUsedPct: "83.00 %",
+			RemoteUsedCapacity: "0.000", ErrMsg: "", Version: &version, Status: "{\"lastSuccessReportTabletsTime\":\"2024-08-22 08:29:09\",\"lastStreamLoadTime\":-1,\"isQueryDisabled\":false,\"isLoadDisabled\":false}", HeartbeatFailureCounter: 0, NodeRole: "mix"}}
+	tests := [][]*Backend{
+		{},
+		values,
+		values2,
+	}
+	mysql_db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("sqlmock new failed %s", err.Error())
+	}
+	mock.ExpectExec("CANCEL DECOMMISSION BACKEND").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CANCEL DECOMMISSION BACKEND "doriscluster-sample-be-0.doriscluster-sample-be-internal.default.svc.cluster.local:9050","doriscluster-sample-be-1.doriscluster-sample-be-internal.default.svc.cluster.local:9050"`).WillReturnResult(sqlmock.NewResult(1, 2))
+	dorisdb := sqlx.NewDb(mysql_db, "mysql")
+	db := &DB{
+		DB: dorisdb,
+	}
+	defer db.Close()
+	for i, test := range tests {
+
+		t.Run("test"+strconv.Itoa(i), func(t *testing.T) {
+			err = db.CancelDecommissionBE(test)
+			if err != nil {
+				t.Errorf("test cancel decommission failed, err=%s", err.Error())
+			}
+		})
+	}
+}
+
 func Test_DropObserver(t *testing.T) {
 	version := "doris-2.1.5-rc02-d5a02e095d"
 	startTime := "2024-08-21 10:04:29"
@@ -261,3 +308,147 @@ func Test_DropBE(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetPassword(t *testing.T) {
+	mysql_db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Errorf("sqlmock new failed %s", err.Error())
+	}
+	mock.ExpectQuery("SELECT Host FROM mysql.user WHERE User = ?").WithArgs("root").
+		WillReturnRows(sqlmock.NewRows([]string{"Host"}).AddRow("%").AddRow("10.0.0.%"))
+	mock.ExpectExec(`SET PASSWORD FOR 'root'@'10.0.0.%' = PASSWORD('p\'w\\d');`).WillReturnResult(sqlmock.NewResult(0, 0))
+	db := &DB{
+		DB: sqlx.NewDb(mysql_db, "mysql"),
+	}
+	defer db.Close()
+	hosts, err := db.GetUserHosts("root")
+	if err != nil || !reflect.DeepEqual(hosts, []string{"%", "10.0.0.%"}) {
+		t.Errorf("get user hosts not expected, hosts=%v, err=%v", hosts, err)
+	}
+	if err := db.SetPassword("root", "10.0.0.%", `p'w\d`); err != nil {
+		t.Errorf("set password failed, err=%s", err.Error())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("set password sql not expected, err=%s", err.Error())
+	}
+}
+
+func Test_FrontendConfig(t *testing.T) {
+	mysql_db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Errorf("sqlmock new failed %s", err.Error())
+	}
+	rows := sqlmock.NewRows([]string{"Key", "Value", "Type", "IsMutable", "MasterOnly", "Comment"}).
+		AddRow("max_running_txn_num_per_db", "1000", "int", "true", "true", "").
+		AddRow("http_port", "8030", "int", "false", "false", "")
+	mock.ExpectQuery("ADMIN SHOW FRONTEND CONFIG").WillReturnRows(rows)
+	mock.ExpectExec(`ADMIN SET FRONTEND CONFIG ('max_running_txn_num_per_db' = '2000');`).WillReturnResult(sqlmock.NewResult(0, 0))
+	db := &DB{
+		DB: sqlx.NewDb(mysql_db, "mysql"),
+	}
+	defer db.Close()
+
+	configs, err := db.ShowFrontendConfigs()
+	if err != nil {
+		t.Fatalf("show frontend configs failed, err=%s", err.Error())
+	}
+	if len(configs) != 2 || !configs[0].IsMutable || configs[1].IsMutable {
+		t.Errorf("show frontend configs not expected, configs=%v", configs)
+	}
+	if err := db.SetFrontendConfig("max_running_txn_num_per_db", "2000"); err != nil {
+		t.Errorf("set frontend config failed, err=%s", err.Error())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("frontend config sql not expected, err=%s", err.Error())
+	}
+}
+
+func Test_GetMaxReplicationNum(t *testing.T) {
+	mysql_db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Errorf("sqlmock new failed %s", err.Error())
+	}
+	rows := sqlmock.NewRows([]string{"PROPERTY_NAME", "PROPERTY_VALUE"}).
+		AddRow("replication_num", "3").
+		AddRow("replication_allocation", "tag.location.default: 2, tag.location.group_a: 2").
+		AddRow("replication_allocation", "invalid")
+	mock.ExpectQuery("SELECT PROPERTY_NAME, PROPERTY_VALUE FROM information_schema.table_properties WHERE PROPERTY_NAME IN ('replication_num', 'replication_allocation')").WillReturnRows(rows)
+	db := &DB{
+		DB: sqlx.NewDb(mysql_db, "mysql"),
+	}
+	defer db.Close()
+
+	num, err := db.GetMaxReplicationNum(context.Background())
+	if err != nil {
+		t.Fatalf("get max replication num failed, err=%s", err.Error())
+	}
+	if num != 4 {
+		t.Errorf("expected max replication num 4, got %d", num)
+	}
+}
+
+func Test_sqlOperation(t *testing.T) {
+	tests := map[string]string{
+		"show backends": "SHOW BACKENDS",
+		`ALTER SYSTEM DECOMMISSION BACKEND "be-0:9050"`:  "ALTER SYSTEM",
+		"SET PASSWORD FOR 'root'@'%' = PASSWORD('pwd');": "SET PASSWORD",
+		"ADMIN SHOW FRONTEND CONFIG":                     "ADMIN SHOW",
+	}
+	for query, expect := range tests {
+		if op := sqlOperation(query); op != expect {
+			t.Errorf("the operation of %s is %s, expect %s", query, op, expect)
+		}
+	}
+}
+
+func Test_StatementSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracing.NewTracerProvider(exporter, tracing.Options{SampleRatio: 1})
+	otel.SetTracerProvider(tp)
+	defer tp.Shutdown(context.Background())
+
+	mysql_db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock new failed %s", err.Error())
+	}
+	mock.ExpectQuery("show backends").WillReturnError(errors.New("fe not reachable"))
+	ctx, parent := tracing.Start(context.Background(), "be.Sync")
+	db := (&DB{DB: sqlx.NewDb(mysql_db, "mysql")}).WithContext(ctx)
+	defer db.Close()
+
+	if _, err := db.ShowBackends(); err == nil {
+		t.Errorf("show backends should fail.")
+	}
+	tracing.End(parent, nil)
+	tp.ForceFlush(context.Background())
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 || spans[0].Name != "SQL SHOW BACKENDS" {
+		t.Fatalf("the span of statement not exported, %v", spans)
+	}
+	if spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() || spans[0].Status.Code != codes.Error {
+		t.Errorf("the span of statement should be the failed child of sync span.")
+	}
+}
+
+func Test_ProbeQuery(t *testing.T) {
+	mysql_db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock new failed %s", err.Error())
+	}
+	mock.ExpectExec("USE @`cg1`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("SELECT 1").WillReturnError(errors.New("no backend available"))
+	db := &DB{DB: sqlx.NewDb(mysql_db, "mysql")}
+	defer db.Close()
+
+	if err := db.ProbeQuery(context.Background(), "cg1", "SELECT 1"); err != nil {
+		t.Errorf("probe in compute group failed, err=%s", err.Error())
+	}
+	if err := db.ProbeQuery(context.Background(), "", "SELECT 1"); err == nil {
+		t.Errorf("probe should fail when the query failed.")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("the statements of probe not match, err=%s", err.Error())
+	}
+}
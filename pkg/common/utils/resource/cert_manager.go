@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package resource
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	CertManagerGroup             = "cert-manager.io"
+	CertManagerVersion           = "v1"
+	CertManagerCertificateKind   = "Certificate"
+	DefaultCertManagerIssuerKind = "Issuer"
+)
+
+// CertManagerCertificateGVR is the resource of cert-manager `Certificate`.
+var CertManagerCertificateGVR = schema.GroupVersionResource{Group: CertManagerGroup, Version: CertManagerVersion, Resource: "certificates"}
+
+// CertManagerIssuer describes the cert-manager issuer that issue certificates.
+type CertManagerIssuer struct {
+	Name  string
+	Kind  string
+	Group string
+}
+
+// CertManagerCertificateOptions describes the certificate requested from cert-manager.
+type CertManagerCertificateOptions struct {
+	// the name of Certificate and the secret that stores the certificate.
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// the owners of Certificate, empty when the Certificate is not owned by a doris cluster.
+	OwnerReferences []metav1.OwnerReference
+	CommonName      string
+	DnsNames        []string
+	Duration        time.Duration
+	RenewBefore     time.Duration
+	Issuer          CertManagerIssuer
+}
+
+// BuildCertManagerCertificate build the cert-manager `Certificate`, the labels are also added on the secret created by cert-manager for watching.
+func BuildCertManagerCertificate(opts CertManagerCertificateOptions) *unstructured.Unstructured {
+	kind := opts.Issuer.Kind
+	if kind == "" {
+		kind = DefaultCertManagerIssuerKind
+	}
+	group := opts.Issuer.Group
+	if group == "" {
+		group = CertManagerGroup
+	}
+
+	labels := map[string]interface{}{}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+	var dnsNames []interface{}
+	for _, name := range opts.DnsNames {
+		dnsNames = append(dnsNames, name)
+	}
+
+	spec := map[string]interface{}{
+		"secretName":  opts.Name,
+		"commonName":  opts.CommonName,
+		"duration":    opts.Duration.String(),
+		"renewBefore": opts.RenewBefore.String(),
+		"usages":      []interface{}{"server auth", "client auth"},
+		"privateKey": map[string]interface{}{
+			"algorithm":      "RSA",
+			"encoding":       "PKCS1",
+			"size":           int64(2048),
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  opts.Issuer.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"secretTemplate": map[string]interface{}{
+			"labels": labels,
+		},
+	}
+	if len(dnsNames) != 0 {
+		spec["dnsNames"] = dnsNames
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: CertManagerGroup, Version: CertManagerVersion, Kind: CertManagerCertificateKind})
+	u.SetName(opts.Name)
+	u.SetNamespace(opts.Namespace)
+	u.SetLabels(opts.Labels)
+	if len(opts.OwnerReferences) != 0 {
+		u.SetOwnerReferences(opts.OwnerReferences)
+	}
+	return u
+}
@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package resource
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_BuildCertManagerCertificate(t *testing.T) {
+	u := BuildCertManagerCertificate(CertManagerCertificateOptions{
+		Name:            "test-fe-tls",
+		Namespace:       "default",
+		Labels:          map[string]string{"app.doris.cluster": "test"},
+		OwnerReferences: []metav1.OwnerReference{{Name: "test"}},
+		CommonName:      "test-fe",
+		DnsNames:        []string{"test-fe-internal"},
+		Duration:        24 * time.Hour,
+		RenewBefore:     time.Hour,
+		Issuer:          CertManagerIssuer{Name: "doris-issuer"},
+	})
+
+	if u.GetKind() != CertManagerCertificateKind || u.GetAPIVersion() != "cert-manager.io/v1" {
+		t.Errorf("the gvk of certificate is wrong, apiVersion=%s kind=%s", u.GetAPIVersion(), u.GetKind())
+	}
+	if len(u.GetOwnerReferences()) != 1 {
+		t.Errorf("the certificate should be owned by cluster.")
+	}
+	if secretName, _, _ := unstructured.NestedString(u.Object, "spec", "secretName"); secretName != "test-fe-tls" {
+		t.Errorf("the secret name of certificate is %s, expect test-fe-tls", secretName)
+	}
+	if kind, _, _ := unstructured.NestedString(u.Object, "spec", "issuerRef", "kind"); kind != DefaultCertManagerIssuerKind {
+		t.Errorf("the issuer kind is %s, expect default %s", kind, DefaultCertManagerIssuerKind)
+	}
+	if duration, _, _ := unstructured.NestedString(u.Object, "spec", "duration"); duration != "24h0m0s" {
+		t.Errorf("the duration of certificate is %s, expect 24h0m0s", duration)
+	}
+	if label, _, _ := unstructured.NestedString(u.Object, "spec", "secretTemplate", "labels", "app.doris.cluster"); label != "test" {
+		t.Errorf("the secret template should have the labels of cluster.")
+	}
+	if dnsNames, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "dnsNames"); len(dnsNames) != 1 {
+		t.Errorf("the dns names of certificate is %v, expect [test-fe-internal]", dnsNames)
+	}
+}
@@ -21,10 +21,13 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"strings"
 
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
 	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -58,6 +61,13 @@ const (
 	TLS_CERTIFICATE_PATH_KEY    = "tls_certificate_path"
 	TLS_PRIVATE_KEY_PATH_KEY    = "tls_private_key_path"
 	TLS_CA_CERTIFICATE_PATH_KEY = "tls_ca_certificate_path"
+	ENABLE_HTTPS_KEY            = "enable_https"
+)
+
+// the storage paths key of be
+const (
+	STORAGE_ROOT_PATH_KEY       = "storage_root_path"
+	SPILL_STORAGE_ROOT_PATH_KEY = "spill_storage_root_path"
 )
 
 const ARROW_FLIGHT_SQL_PORT = "arrow_flight_sql_port"
@@ -66,6 +76,9 @@ const BRPC_LISTEN_PORT = "brpc_listen_port"
 const BROKER_IPC_PORT = "broker_ipc_port"
 const GRACE_SHUTDOWN_WAIT_SECONDS = "grace_shutdown_wait_seconds"
 
+// ConfigMapHashAnnotation records the hash of configmaps in pod template, pods restart when the configmaps changed.
+const ConfigMapHashAnnotation = "apache.doris.org/configmap-hash"
+
 const ENABLE_FQDN = "enable_fqdn_mode"
 const START_MODEL_FQDN = "FQDN"
 const START_MODEL_IP = "IP"
@@ -210,3 +223,155 @@ func GetDorisCoreConfigMapNames(dcr *dorisv1.DorisCluster) map[dorisv1.Component
 
 	return dorisCoreConfigMaps
 }
+
+// NeedRenderBEStorageConfig returns true when the volumes declared storage medium or used for spilling, the storage paths of be.conf should be rendered by operator.
+func NeedRenderBEStorageConfig(volumes []dorisv1.PersistentVolume) bool {
+	for _, volume := range volumes {
+		if volume.StorageMedium != "" || volume.SpillStorage {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedRenderConfig returns true when operator should render the core configmap of component, the storage paths of be and the tls configs are rendered.
+func NeedRenderConfig(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) bool {
+	switch componentType {
+	case dorisv1.Component_FE, dorisv1.Component_CN:
+		return dcr.IsTLSEnabled()
+	case dorisv1.Component_BE:
+		return dcr.IsTLSEnabled() || NeedRenderBEStorageConfig(dcr.Spec.BeSpec.PersistentVolumes)
+	default:
+		return false
+	}
+}
+
+// GetRenderedConfigMapName returns the name of configmap that rendered by operator from the core configmap of component.
+func GetRenderedConfigMapName(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) string {
+	return dorisv1.GenerateComponentStatefulSetName(dcr, componentType) + "-rendered-conf"
+}
+
+// buildBEStorageRootPaths build the value of `storage_root_path` and `spill_storage_root_path` by volumes.
+// the capacity of data directory is the storage request of pvc in GB. the capacity is omitted when auto expansion configured,
+// as the pvcs of pods are expanded separately and the storage request in spec goes stale, be uses the capacity of disk instead.
+func buildBEStorageRootPaths(volumes []dorisv1.PersistentVolume) (string, string) {
+	var storagePaths, spillPaths []string
+	for _, volume := range volumes {
+		path := strings.TrimSuffix(volume.MountPath, "/")
+		if path == "" {
+			continue
+		}
+
+		if volume.SpillStorage {
+			spillPaths = append(spillPaths, path)
+			continue
+		}
+		if volume.StorageMedium != "" {
+			srp := doris.StorageRootPath{Path: path, Medium: string(volume.StorageMedium)}
+			if volume.AutoExpansion == nil {
+				capacity := volume.PersistentVolumeClaimSpec.Resources.Requests[corev1.ResourceStorage]
+				srp.Capacity = capacity.Value() >> 30
+			}
+			storagePaths = append(storagePaths, srp.String())
+		}
+	}
+
+	return strings.Join(storagePaths, ";"), strings.Join(spillPaths, ";")
+}
+
+// RenderBEStorageConfig overwrite the `storage_root_path` and `spill_storage_root_path` in be.conf with the paths built from volumes.
+func RenderBEStorageConfig(conf string, volumes []dorisv1.PersistentVolume) string {
+	storagePaths, spillPaths := buildBEStorageRootPaths(volumes)
+	var kvs [][2]string
+	if storagePaths != "" {
+		kvs = append(kvs, [2]string{STORAGE_ROOT_PATH_KEY, storagePaths})
+	}
+	if spillPaths != "" {
+		kvs = append(kvs, [2]string{SPILL_STORAGE_ROOT_PATH_KEY, spillPaths})
+	}
+	return overwriteConfigValues(conf, kvs)
+}
+
+// overwriteConfigValues remove the lines of keys in conf, and append the keys with new values in order.
+func overwriteConfigValues(conf string, kvs [][2]string) string {
+	overwrites := map[string]bool{}
+	for _, kv := range kvs {
+		overwrites[kv[0]] = true
+	}
+
+	var lines []string
+	for _, line := range strings.Split(conf, "\n") {
+		key := strings.TrimSpace(strings.SplitN(line, "=", 2)[0])
+		if overwrites[key] {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	rendered := strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+	for _, kv := range kvs {
+		rendered = rendered + kv[0] + " = " + kv[1] + "\n"
+	}
+	return rendered
+}
+
+// BuildRenderedConfigMap build the configmap copied from the core configmap of component, the storage paths of be.conf are rendered by volumes
+// and the tls configs are rendered when operator issue certificates.
+func BuildRenderedConfigMap(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType, coreConfigMap *corev1.ConfigMap) *corev1.ConfigMap {
+	data := make(map[string]string, len(coreConfigMap.Data))
+	for k, v := range coreConfigMap.Data {
+		data[k] = v
+	}
+
+	key := getDefaultResolveKey(componentType)
+	if componentType == dorisv1.Component_BE && NeedRenderBEStorageConfig(dcr.Spec.BeSpec.PersistentVolumes) {
+		data[key] = RenderBEStorageConfig(data[key], dcr.Spec.BeSpec.PersistentVolumes)
+	}
+	if dcr.IsTLSEnabled() {
+		data[key] = RenderTLSConfig(data[key])
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetRenderedConfigMapName(dcr, componentType),
+			Namespace:       dcr.Namespace,
+			Labels:          dorisv1.GenerateStatefulSetLabels(dcr, componentType),
+			OwnerReferences: []metav1.OwnerReference{GetOwnerReference(dcr)},
+		},
+		Data: data,
+	}
+}
+
+// GetAppliedConfigMapName returns the name of configmap that snapshots the core configmap of component last applied on the pods.
+func GetAppliedConfigMapName(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) string {
+	return dorisv1.GenerateComponentStatefulSetName(dcr, componentType) + "-applied-conf"
+}
+
+// BuildAppliedConfigMap build the snapshot of the core configmap of component, the snapshot is compared with the core configmap
+// for finding the changed configs when the core configmap updated.
+func BuildAppliedConfigMap(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType, coreConfigMap *corev1.ConfigMap) *corev1.ConfigMap {
+	data := make(map[string]string, len(coreConfigMap.Data))
+	for k, v := range coreConfigMap.Data {
+		data[k] = v
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetAppliedConfigMapName(dcr, componentType),
+			Namespace:       dcr.Namespace,
+			Labels:          dorisv1.GenerateStatefulSetLabels(dcr, componentType),
+			OwnerReferences: []metav1.OwnerReference{GetOwnerReference(dcr)},
+		},
+		Data: data,
+	}
+}
+
+// SetConfigMapHash record the hash of the configmaps mounted in pods in the annotations of pod template, pods restart when the configmaps changed.
+func SetConfigMapHash(pts *corev1.PodTemplateSpec, cmHash string) {
+	annotations := make(map[string]string, len(pts.Annotations)+1)
+	for k, v := range pts.Annotations {
+		annotations[k] = v
+	}
+	annotations[ConfigMapHashAnnotation] = cmHash
+	pts.Annotations = annotations
+}
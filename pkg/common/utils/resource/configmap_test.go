@@ -24,6 +24,7 @@ import (
 
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
 	corev1 "k8s.io/api/core/v1"
+	kr "k8s.io/apimachinery/pkg/api/resource"
 )
 
 func Test_GetStartMode(t *testing.T) {
@@ -190,3 +191,62 @@ func Test_getCoreCmName(t *testing.T) {
 		})
 	}
 }
+
+func Test_RenderBEStorageConfig(t *testing.T) {
+	newVolume := func(mountPath string, medium dorisv1.StorageMedium, spill bool, storage string) dorisv1.PersistentVolume {
+		pv := dorisv1.PersistentVolume{MountPath: mountPath, StorageMedium: medium, SpillStorage: spill}
+		pv.PersistentVolumeClaimSpec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: kr.MustParse(storage)}
+		return pv
+	}
+
+	volumes := []dorisv1.PersistentVolume{
+		newVolume("/opt/apache-doris/be/storage/", dorisv1.StorageMediumSSD, false, "100Gi"),
+		newVolume("/opt/apache-doris/be/storage1", dorisv1.StorageMediumHDD, false, "1Ti"),
+		newVolume("/opt/apache-doris/be/spill", "", true, "50Gi"),
+		newVolume("/opt/apache-doris/be/log", "", false, "10Gi"),
+	}
+	if !NeedRenderBEStorageConfig(volumes) || NeedRenderBEStorageConfig(volumes[3:]) {
+		t.Errorf("NeedRenderBEStorageConfig result not expected.")
+	}
+
+	conf := "be_port = 9060\nstorage_root_path = /opt/apache-doris/be/storage\nwebserver_port = 8040\n"
+	expected := "be_port = 9060\nwebserver_port = 8040\n" +
+		"storage_root_path = /opt/apache-doris/be/storage.SSD,100;/opt/apache-doris/be/storage1.HDD,1024\n" +
+		"spill_storage_root_path = /opt/apache-doris/be/spill\n"
+	if rendered := RenderBEStorageConfig(conf, volumes); rendered != expected {
+		t.Errorf("RenderBEStorageConfig rendered %q, expected %q", rendered, expected)
+	}
+
+	// the capacity not rendered when auto expansion configured.
+	volumes[1].AutoExpansion = &dorisv1.PVCAutoExpansion{Step: kr.MustParse("100Gi"), MaxCapacity: kr.MustParse("2Ti")}
+	expected = "be_port = 9060\nwebserver_port = 8040\n" +
+		"storage_root_path = /opt/apache-doris/be/storage.SSD,100;/opt/apache-doris/be/storage1.HDD\n" +
+		"spill_storage_root_path = /opt/apache-doris/be/spill\n"
+	if rendered := RenderBEStorageConfig(conf, volumes); rendered != expected {
+		t.Errorf("RenderBEStorageConfig rendered %q, expected %q", rendered, expected)
+	}
+
+	// the storage paths not declared by volumes should be kept.
+	conf = "storage_root_path = /data\nspill_storage_root_path = /spill\n"
+	if rendered := RenderBEStorageConfig(conf, volumes[2:]); rendered != "storage_root_path = /data\nspill_storage_root_path = /opt/apache-doris/be/spill\n" {
+		t.Errorf("RenderBEStorageConfig not keep the storage_root_path, rendered %q", rendered)
+	}
+}
+
+func Test_RenderTLSConfig(t *testing.T) {
+	conf := "http_port = 8030\nenable_tls = false\n"
+	expected := "http_port = 8030\n" +
+		"enable_tls = true\n" +
+		"enable_ssl = true\n" +
+		"tls_ca_certificate_path = /opt/apache-doris/tls/ca.crt\n" +
+		"tls_certificate_path = /opt/apache-doris/tls/tls.crt\n" +
+		"tls_private_key_path = /opt/apache-doris/tls/tls.key\n"
+	if rendered := RenderTLSConfig(conf); rendered != expected {
+		t.Errorf("RenderTLSConfig rendered %q, expected %q", rendered, expected)
+	}
+
+	config := InjectTLSConfig(nil)
+	if GetString(config, ENABLE_TLS_KEY) != "true" || GetString(config, TLS_CERTIFICATE_PATH_KEY) != TLS_MOUNT_PATH+"/tls.crt" {
+		t.Errorf("InjectTLSConfig not set the tls configs, config=%v", config)
+	}
+}
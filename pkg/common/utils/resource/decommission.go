@@ -18,7 +18,12 @@
 package resource
 
 import (
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
 	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DecommissionPhase string
@@ -76,3 +81,176 @@ func (d *DecommissionTaskStatus) GetDecommissionPhase() DecommissionPhase {
 	}
 	return DecommissionPhaseUnknown
 }
+
+// DecommissionStalledTimeout is the duration that the tablets number of decommissioning backends not decreased, the decommission is regarded as stalled.
+const DecommissionStalledTimeout = 30 * time.Minute
+
+// BackendDecommissionProgress is the decommission progress of one backend.
+type BackendDecommissionProgress struct {
+	BackendId               string
+	Host                    string
+	InitialTabletNum        int64
+	TabletNum               int64
+	InitialDataUsedCapacity string
+	DataUsedCapacity        string
+}
+
+// DecommissionProgress is the tablets migration progress of decommissioning backends, it is shared by DorisCluster and DorisDisaggregatedCluster,
+// and converted to the DecommissionStatus of the corresponding api.
+type DecommissionProgress struct {
+	StartTime               time.Time
+	LastProgressTime        time.Time
+	EstimatedCompletionTime *time.Time
+	InitialTabletNum        int64
+	RemainingTabletNum      int64
+	Stalled                 bool
+	Backends                []BackendDecommissionProgress
+}
+
+// CalculateDecommissionProgress calculates the progress of the decommissioning backends based on the progress recorded in last reconcile.
+// last is nil means the decommission is just started, the current tablets number is used as initial value.
+func CalculateDecommissionProgress(last *DecommissionProgress, decommissionBackends []*mysql.Backend, now time.Time) *DecommissionProgress {
+	dp := &DecommissionProgress{
+		StartTime:        now,
+		LastProgressTime: now,
+	}
+	lastBackends := map[string]BackendDecommissionProgress{}
+	if last != nil {
+		dp.StartTime = last.StartTime
+		dp.LastProgressTime = last.LastProgressTime
+		for _, bp := range last.Backends {
+			lastBackends[bp.BackendId] = bp
+		}
+	}
+
+	for _, be := range decommissionBackends {
+		bp := BackendDecommissionProgress{
+			BackendId:               be.BackendID,
+			Host:                    be.Host,
+			InitialTabletNum:        be.TabletNum,
+			TabletNum:               be.TabletNum,
+			InitialDataUsedCapacity: be.DataUsedCapacity,
+			DataUsedCapacity:        be.DataUsedCapacity,
+		}
+		if lbp, ok := lastBackends[be.BackendID]; ok {
+			bp.InitialTabletNum = lbp.InitialTabletNum
+			bp.InitialDataUsedCapacity = lbp.InitialDataUsedCapacity
+		}
+
+		dp.InitialTabletNum += bp.InitialTabletNum
+		dp.RemainingTabletNum += bp.TabletNum
+		dp.Backends = append(dp.Backends, bp)
+	}
+
+	if last != nil && dp.RemainingTabletNum < last.RemainingTabletNum {
+		dp.LastProgressTime = now
+	}
+	dp.Stalled = dp.RemainingTabletNum > 0 && now.Sub(dp.LastProgressTime) >= DecommissionStalledTimeout
+	dp.EstimatedCompletionTime = estimateDecommissionCompletionTime(dp, now)
+	return dp
+}
+
+// estimateDecommissionCompletionTime uses the average migration rate since decommission started to estimate the time that all tablets migrated.
+// if no tablet migrated, the completion time can't be estimated and return nil.
+func estimateDecommissionCompletionTime(dp *DecommissionProgress, now time.Time) *time.Time {
+	if dp.RemainingTabletNum == 0 {
+		return &now
+	}
+
+	migrated := dp.InitialTabletNum - dp.RemainingTabletNum
+	elapsed := now.Sub(dp.StartTime)
+	if migrated <= 0 || elapsed <= 0 {
+		return nil
+	}
+
+	eta := now.Add(time.Duration(float64(elapsed) / float64(migrated) * float64(dp.RemainingTabletNum)))
+	return &eta
+}
+
+// IsNewlyStalled returns true when the decommission become stalled in this calculation, used to avoid sending stalled events repeatedly.
+func (dp *DecommissionProgress) IsNewlyStalled(last *DecommissionProgress) bool {
+	return dp.Stalled && (last == nil || !last.Stalled)
+}
+
+// NoProgressTimeout returns true when the tablets number of decommissioning backends has not decreased for DecommissionStalledTimeout,
+// used to stop waiting for the decommission that the phase can not be resolved, eg: the backends dropped by hand.
+func (dp *DecommissionProgress) NoProgressTimeout(now time.Time) bool {
+	return now.Sub(dp.LastProgressTime) >= DecommissionStalledTimeout
+}
+
+// NewDecommissionProgressFromStatus converts the DecommissionStatus in the status of DorisCluster to progress, nil when not decommissioning.
+func NewDecommissionProgressFromStatus(ds *dorisv1.DecommissionStatus) *DecommissionProgress {
+	if ds == nil {
+		return nil
+	}
+	dp := &DecommissionProgress{
+		StartTime:          ds.StartTime.Time,
+		LastProgressTime:   ds.LastProgressTime.Time,
+		InitialTabletNum:   ds.InitialTabletNum,
+		RemainingTabletNum: ds.RemainingTabletNum,
+		Stalled:            ds.Stalled,
+	}
+	if ds.EstimatedCompletionTime != nil {
+		dp.EstimatedCompletionTime = &ds.EstimatedCompletionTime.Time
+	}
+	for _, b := range ds.Backends {
+		dp.Backends = append(dp.Backends, BackendDecommissionProgress(b))
+	}
+	return dp
+}
+
+// ToStatus converts the progress to the DecommissionStatus displayed in the status of DorisCluster.
+func (dp *DecommissionProgress) ToStatus() *dorisv1.DecommissionStatus {
+	ds := &dorisv1.DecommissionStatus{
+		StartTime:          metav1.NewTime(dp.StartTime),
+		LastProgressTime:   metav1.NewTime(dp.LastProgressTime),
+		InitialTabletNum:   dp.InitialTabletNum,
+		RemainingTabletNum: dp.RemainingTabletNum,
+		Stalled:            dp.Stalled,
+	}
+	if dp.EstimatedCompletionTime != nil {
+		eta := metav1.NewTime(*dp.EstimatedCompletionTime)
+		ds.EstimatedCompletionTime = &eta
+	}
+	for _, bp := range dp.Backends {
+		ds.Backends = append(ds.Backends, dorisv1.BackendDecommissionStatus(bp))
+	}
+	return ds
+}
+
+// NewDecommissionProgressFromDisaggregatedStatus converts the DecommissionStatus of compute group to progress, nil when not decommissioning.
+// the status type is kept in the api of DorisDisaggregatedCluster, the fields are copied for reusing the converters of DorisCluster.
+func NewDecommissionProgressFromDisaggregatedStatus(ds *dv1.DecommissionStatus) *DecommissionProgress {
+	if ds == nil {
+		return nil
+	}
+	dds := dorisv1.DecommissionStatus{
+		StartTime:               ds.StartTime,
+		LastProgressTime:        ds.LastProgressTime,
+		EstimatedCompletionTime: ds.EstimatedCompletionTime,
+		InitialTabletNum:        ds.InitialTabletNum,
+		RemainingTabletNum:      ds.RemainingTabletNum,
+		Stalled:                 ds.Stalled,
+	}
+	for _, b := range ds.Backends {
+		dds.Backends = append(dds.Backends, dorisv1.BackendDecommissionStatus(b))
+	}
+	return NewDecommissionProgressFromStatus(&dds)
+}
+
+// ToDisaggregatedStatus converts the progress to the DecommissionStatus displayed in the status of compute group.
+func (dp *DecommissionProgress) ToDisaggregatedStatus() *dv1.DecommissionStatus {
+	ds := dp.ToStatus()
+	dds := &dv1.DecommissionStatus{
+		StartTime:               ds.StartTime,
+		LastProgressTime:        ds.LastProgressTime,
+		EstimatedCompletionTime: ds.EstimatedCompletionTime,
+		InitialTabletNum:        ds.InitialTabletNum,
+		RemainingTabletNum:      ds.RemainingTabletNum,
+		Stalled:                 ds.Stalled,
+	}
+	for _, b := range ds.Backends {
+		dds.Backends = append(dds.Backends, dv1.BackendDecommissionStatus(b))
+	}
+	return dds
+}
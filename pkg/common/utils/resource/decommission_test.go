@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+)
+
+func Test_CalculateDecommissionProgress(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	backends := []*mysql.Backend{
+		{BackendID: "10001", Host: "test-be-2", TabletNum: 600, DataUsedCapacity: "6.000 GB"},
+		{BackendID: "10002", Host: "test-be-3", TabletNum: 400, DataUsedCapacity: "4.000 GB"},
+	}
+
+	dp := CalculateDecommissionProgress(nil, backends, start)
+	if dp.InitialTabletNum != 1000 || dp.RemainingTabletNum != 1000 {
+		t.Errorf("initial progress tablets not correct, initial=%d, remaining=%d", dp.InitialTabletNum, dp.RemainingTabletNum)
+	}
+	if dp.EstimatedCompletionTime != nil {
+		t.Errorf("estimated completion time should be nil when no tablet migrated.")
+	}
+
+	// 10 minutes later, half of tablets migrated.
+	backends = []*mysql.Backend{
+		{BackendID: "10001", Host: "test-be-2", TabletNum: 300, DataUsedCapacity: "3.000 GB"},
+		{BackendID: "10002", Host: "test-be-3", TabletNum: 200, DataUsedCapacity: "2.000 GB"},
+	}
+	now := start.Add(10 * time.Minute)
+	ndp := CalculateDecommissionProgress(dp, backends, now)
+	if ndp.InitialTabletNum != 1000 || ndp.RemainingTabletNum != 500 {
+		t.Errorf("progress tablets not correct, initial=%d, remaining=%d", ndp.InitialTabletNum, ndp.RemainingTabletNum)
+	}
+	if ndp.Backends[0].InitialDataUsedCapacity != "6.000 GB" || ndp.Backends[0].DataUsedCapacity != "3.000 GB" {
+		t.Errorf("backend data used capacity not correct, %+v", ndp.Backends[0])
+	}
+	if !ndp.LastProgressTime.Equal(now) {
+		t.Errorf("last progress time should be updated when tablets decreased.")
+	}
+	if ndp.EstimatedCompletionTime == nil || !ndp.EstimatedCompletionTime.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("estimated completion time not correct, %v", ndp.EstimatedCompletionTime)
+	}
+
+	// tablets not moved for a long time.
+	sdp := CalculateDecommissionProgress(ndp, backends, now.Add(DecommissionStalledTimeout))
+	if !sdp.Stalled || !sdp.IsNewlyStalled(ndp) {
+		t.Errorf("decommission should be stalled when tablets not decreased in %s", DecommissionStalledTimeout)
+	}
+	if sdp.IsNewlyStalled(sdp) {
+		t.Errorf("decommission already stalled should not be newly stalled.")
+	}
+
+	// the backends dropped by hand have no tablets, the decommission is not stalled but has no progress.
+	edp := CalculateDecommissionProgress(nil, nil, now)
+	if edp.NoProgressTimeout(now.Add(time.Minute)) || !CalculateDecommissionProgress(edp, nil, now.Add(DecommissionStalledTimeout)).NoProgressTimeout(now.Add(DecommissionStalledTimeout)) {
+		t.Errorf("decommission should be no progress timeout only after %s", DecommissionStalledTimeout)
+	}
+}
+
+func Test_DecommissionProgressStatusConvert(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dp := CalculateDecommissionProgress(nil, []*mysql.Backend{{BackendID: "10001", Host: "test-be-2", TabletNum: 10}}, now)
+
+	ddp := NewDecommissionProgressFromStatus(dp.ToStatus())
+	if !ddp.StartTime.Equal(now) || ddp.Backends[0].InitialTabletNum != 10 {
+		t.Errorf("doris decommission status convert not correct, %+v", ddp)
+	}
+	cdp := NewDecommissionProgressFromDisaggregatedStatus(dp.ToDisaggregatedStatus())
+	if !cdp.StartTime.Equal(now) || cdp.Backends[0].BackendId != "10001" {
+		t.Errorf("disaggregated decommission status convert not correct, %+v", cdp)
+	}
+	if NewDecommissionProgressFromStatus(nil) != nil || NewDecommissionProgressFromDisaggregatedStatus(nil) != nil {
+		t.Errorf("nil status should convert to nil progress.")
+	}
+}
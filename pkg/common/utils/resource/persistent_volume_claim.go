@@ -18,7 +18,10 @@
 package resource
 
 import (
+	"errors"
+	"strconv"
 	"strings"
+	"time"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
@@ -26,6 +29,7 @@ import (
 	"github.com/apache/doris-operator/pkg/common/utils/hash"
 	"github.com/apache/doris-operator/pkg/common/utils/set"
 	corev1 "k8s.io/api/core/v1"
+	kr "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
@@ -40,6 +44,18 @@ const (
 	PVCManagerAnnotationApache = "apache.doris.org/pvc-manager"
 )
 
+// PVCClusterUIDAnnotation records the uid of cluster that the pvc attached to, used to find the pvcs retained by a deleted cluster.
+const PVCClusterUIDAnnotation = "apache.doris.org/pvc-cluster-uid"
+
+// DefaultAutoExpansionUsedPercentThreshold is the disk used percent that triggers pvc expansion when not configured.
+const DefaultAutoExpansionUsedPercentThreshold int32 = 80
+
+// PVCAutoExpansionCheckedAtAnnotation records the last time that the disk usage of pvc was checked by auto expansion.
+const PVCAutoExpansionCheckedAtAnnotation = "apache.doris.org/auto-expansion-checked-at"
+
+// AutoExpansionCheckInterval is the minimal interval between two disk usage checks of one pvc.
+const AutoExpansionCheckInterval = 5 * time.Minute
+
 func BuildPVCName(stsName, ordinal, volumeName string) string {
 	pvcName := stsName + "-" + ordinal
 	if volumeName != "" {
@@ -212,3 +228,141 @@ func GenerateEveryoneMountPathDorisPersistentVolume(spec *dorisv1.BaseSpec, excl
 
 	return dorisPersistentVolumes, nil
 }
+
+// CalculateAutoExpandedCapacity returns the storage request that the pvc should be expanded to.
+// current is the storage request of pvc now, the capacity grows one step every time and not exceeds maxCapacity.
+// the bool is false when the disk usage not crosses the threshold or the capacity has reached the ceiling.
+func CalculateAutoExpandedCapacity(current kr.Quantity, usedPercent float64, threshold int32, step, maxCapacity kr.Quantity) (kr.Quantity, bool) {
+	if threshold <= 0 {
+		threshold = DefaultAutoExpansionUsedPercentThreshold
+	}
+	if usedPercent < float64(threshold) || step.Sign() <= 0 || current.Cmp(maxCapacity) >= 0 {
+		return current, false
+	}
+
+	expanded := current.DeepCopy()
+	expanded.Add(step)
+	if expanded.Cmp(maxCapacity) > 0 {
+		expanded = maxCapacity.DeepCopy()
+	}
+	return expanded, true
+}
+
+// AutoExpansionCheckDue returns whether the disk usage of pvc should be checked again, the pvc checked in the last AutoExpansionCheckInterval is skipped.
+func AutoExpansionCheckDue(pvc *corev1.PersistentVolumeClaim, now time.Time) bool {
+	checkedAt, err := time.Parse(time.RFC3339, pvc.Annotations[PVCAutoExpansionCheckedAtAnnotation])
+	if err != nil {
+		return true
+	}
+	return now.Sub(checkedAt) >= AutoExpansionCheckInterval
+}
+
+// MarkAutoExpansionChecked records the time of disk usage check in the annotation of pvc.
+func MarkAutoExpansionChecked(pvc *corev1.PersistentVolumeClaim, now time.Time) {
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[PVCAutoExpansionCheckedAtAnnotation] = now.UTC().Format(time.RFC3339)
+}
+
+// ParseDorisUsedPercent parse the used percent displayed by `show backends`, eg: "83.00 %".
+func ParseDorisUsedPercent(pct string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(pct), "%")), 64)
+}
+
+// ParseDfUsedPercent parse the used percent from the output of `df -P {path}`, the output as follows:
+// Filesystem     1024-blocks    Used Available Capacity Mounted on
+// /dev/vdb          10218772 8255012   1947376      81% /opt/apache-doris/fe/doris-meta
+func ParseDfUsedPercent(output string) (float64, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return 0, errors.New("unexpected df output: " + output)
+	}
+
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 5 {
+		return 0, errors.New("unexpected df output: " + output)
+	}
+	return strconv.ParseFloat(strings.TrimSuffix(fields[4], "%"), 64)
+}
+
+// DeletePVCWhenScaled returns whether the pvcs of pods removed by scaling down should be deleted. the pvcs of fe are deleted by default.
+func DeletePVCWhenScaled(policy *dorisv1.PersistentVolumeClaimRetentionPolicy, componentType dorisv1.ComponentType) bool {
+	if policy == nil || policy.WhenScaled == "" {
+		return componentType == dorisv1.Component_FE
+	}
+	return policy.WhenScaled == dorisv1.DeletePersistentVolumeClaimRetentionPolicyType
+}
+
+// DeletePVCWhenDeleted returns whether the pvcs should be deleted with the DorisCluster.
+func DeletePVCWhenDeleted(policy *dorisv1.PersistentVolumeClaimRetentionPolicy) bool {
+	return policy != nil && policy.WhenDeleted == dorisv1.DeletePersistentVolumeClaimRetentionPolicyType
+}
+
+// DeleteDisaggregatedPVCWhenScaled returns whether the pvcs of pods removed by scaling down should be deleted, the pvcs are deleted by default.
+func DeleteDisaggregatedPVCWhenScaled(policy *dv1.PersistentVolumeClaimRetentionPolicy) bool {
+	return policy == nil || policy.WhenScaled != dv1.RetainPersistentVolumeClaimRetentionPolicyType
+}
+
+// DeleteDisaggregatedPVCWhenDeleted returns whether the pvcs should be deleted with the DorisDisaggregatedCluster.
+func DeleteDisaggregatedPVCWhenDeleted(policy *dv1.PersistentVolumeClaimRetentionPolicy) bool {
+	return policy != nil && policy.WhenDeleted == dv1.DeletePersistentVolumeClaimRetentionPolicyType
+}
+
+// ApplyPVCRetentionPolicy keeps the owner reference, finalizer and cluster uid annotation of pvc consistent with the `whenDeleted` policy.
+// when the pvc should be deleted with cluster, the cluster is set as the owner of pvc, and the pvc finalizer is removed for garbage collection.
+// the first bool represents the pvc changed, the second represents the pvc is retained by a deleted cluster and re-attached to the owner.
+func ApplyPVCRetentionPolicy(pvc *corev1.PersistentVolumeClaim, owner metav1.OwnerReference, deleteWithOwner bool) (bool, bool) {
+	changed := false
+	hasOwner := false
+	var refs []metav1.OwnerReference
+	for _, ref := range pvc.OwnerReferences {
+		if ref.Kind == owner.Kind && ref.Name == owner.Name {
+			if deleteWithOwner && ref.UID == owner.UID {
+				hasOwner = true
+				refs = append(refs, ref)
+			} else {
+				changed = true
+			}
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	if deleteWithOwner && !hasOwner {
+		refs = append(refs, owner)
+		changed = true
+	}
+	pvc.OwnerReferences = refs
+
+	if deleteWithOwner && RemovePVCFinalizers(pvc) {
+		changed = true
+	}
+
+	reattached := false
+	uid := string(owner.UID)
+	if pvc.Annotations[PVCClusterUIDAnnotation] != uid {
+		reattached = pvc.Annotations[PVCClusterUIDAnnotation] != ""
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[PVCClusterUIDAnnotation] = uid
+		changed = true
+	}
+
+	return changed, reattached
+}
+
+// RemovePVCFinalizers remove the finalizers added by operator, returns true if pvc have finalizer removed.
+func RemovePVCFinalizers(pvc *corev1.PersistentVolumeClaim) bool {
+	var finalizers []string
+	for _, f := range pvc.Finalizers {
+		if f == pvc_finalizer || f == pvcFinalizerApache {
+			continue
+		}
+		finalizers = append(finalizers, f)
+	}
+
+	removed := len(finalizers) != len(pvc.Finalizers)
+	pvc.Finalizers = finalizers
+	return removed
+}
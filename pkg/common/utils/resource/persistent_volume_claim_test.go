@@ -20,8 +20,12 @@ package resource
 import (
 	"fmt"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	corev1 "k8s.io/api/core/v1"
+	kr "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"testing"
+	"time"
 )
 
 func Test_BuildPVCAnnotations(t *testing.T) {
@@ -46,3 +50,96 @@ func Test_Result(t *testing.T) {
 		fmt.Println("test true")
 	}
 }
+
+func Test_CalculateAutoExpandedCapacity(t *testing.T) {
+	tests := []struct {
+		current     string
+		usedPercent float64
+		threshold   int32
+		want        string
+		expand      bool
+	}{
+		{current: "100Gi", usedPercent: 50, threshold: 80, want: "100Gi", expand: false},
+		{current: "100Gi", usedPercent: 85, threshold: 80, want: "150Gi", expand: true},
+		{current: "100Gi", usedPercent: 81, threshold: 0, want: "150Gi", expand: true},
+		{current: "180Gi", usedPercent: 90, threshold: 80, want: "200Gi", expand: true},
+		{current: "200Gi", usedPercent: 95, threshold: 80, want: "200Gi", expand: false},
+	}
+
+	step := kr.MustParse("50Gi")
+	maxCapacity := kr.MustParse("200Gi")
+	for i, test := range tests {
+		got, expand := CalculateAutoExpandedCapacity(kr.MustParse(test.current), test.usedPercent, test.threshold, step, maxCapacity)
+		if expand != test.expand || got.Cmp(kr.MustParse(test.want)) != 0 {
+			t.Errorf("case %d CalculateAutoExpandedCapacity got %s %t, want %s %t", i, got.String(), expand, test.want, test.expand)
+		}
+	}
+}
+
+func Test_ParseUsedPercent(t *testing.T) {
+	if pct, err := ParseDorisUsedPercent("83.00 %"); err != nil || pct != 83 {
+		t.Errorf("ParseDorisUsedPercent failed, pct=%f, err=%v", pct, err)
+	}
+
+	output := "Filesystem     1024-blocks    Used Available Capacity Mounted on\n/dev/vdb          10218772 8255012   1947376      81% /opt/apache-doris/fe/doris-meta\n"
+	if pct, err := ParseDfUsedPercent(output); err != nil || pct != 81 {
+		t.Errorf("ParseDfUsedPercent failed, pct=%f, err=%v", pct, err)
+	}
+	if _, err := ParseDfUsedPercent("df: /opt/not-exist: No such file or directory"); err == nil {
+		t.Errorf("ParseDfUsedPercent should failed on unexpected output.")
+	}
+}
+
+func Test_AutoExpansionCheckDue(t *testing.T) {
+	now := time.Now()
+	pvc := &corev1.PersistentVolumeClaim{}
+	if !AutoExpansionCheckDue(pvc, now) {
+		t.Errorf("AutoExpansionCheckDue should check the pvc never checked.")
+	}
+
+	MarkAutoExpansionChecked(pvc, now)
+	if AutoExpansionCheckDue(pvc, now.Add(time.Minute)) {
+		t.Errorf("AutoExpansionCheckDue should skip the pvc checked in the interval.")
+	}
+	if !AutoExpansionCheckDue(pvc, now.Add(AutoExpansionCheckInterval)) {
+		t.Errorf("AutoExpansionCheckDue should check the pvc again after the interval.")
+	}
+}
+
+func Test_DeletePVCWhenScaled(t *testing.T) {
+	if !DeletePVCWhenScaled(nil, dorisv1.Component_FE) || DeletePVCWhenScaled(nil, dorisv1.Component_BE) {
+		t.Errorf("DeletePVCWhenScaled default policy not right.")
+	}
+	policy := &dorisv1.PersistentVolumeClaimRetentionPolicy{WhenScaled: dorisv1.DeletePersistentVolumeClaimRetentionPolicyType}
+	if !DeletePVCWhenScaled(policy, dorisv1.Component_BE) {
+		t.Errorf("DeletePVCWhenScaled should delete be pvcs when policy is Delete.")
+	}
+}
+
+func Test_ApplyPVCRetentionPolicy(t *testing.T) {
+	owner := metav1.OwnerReference{APIVersion: "doris.selectdb.com/v1", Kind: "DorisCluster", Name: "test", UID: "new-uid"}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "storage-test-be-0",
+			Annotations: map[string]string{PVCClusterUIDAnnotation: "old-uid"},
+			Finalizers:  []string{pvc_finalizer},
+		},
+	}
+
+	changed, reattached := ApplyPVCRetentionPolicy(pvc, owner, true)
+	if !changed || !reattached {
+		t.Errorf("ApplyPVCRetentionPolicy should re-attach the pvc retained by deleted cluster.")
+	}
+	if len(pvc.OwnerReferences) != 1 || pvc.OwnerReferences[0].UID != "new-uid" || len(pvc.Finalizers) != 0 {
+		t.Errorf("ApplyPVCRetentionPolicy should set owner and remove finalizer when delete with owner, pvc=%v", pvc.ObjectMeta)
+	}
+
+	if changed, _ = ApplyPVCRetentionPolicy(pvc, owner, true); changed {
+		t.Errorf("ApplyPVCRetentionPolicy should not change the pvc that have applied.")
+	}
+
+	changed, reattached = ApplyPVCRetentionPolicy(pvc, owner, false)
+	if !changed || reattached || len(pvc.OwnerReferences) != 0 {
+		t.Errorf("ApplyPVCRetentionPolicy should remove owner when retain, pvc=%v", pvc.ObjectMeta)
+	}
+}
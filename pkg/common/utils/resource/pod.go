@@ -209,6 +209,15 @@ func NewPodTemplateSpec(dcr *v1.DorisCluster, config map[string]interface{}, com
 
 	if len(GetMountConfigMapInfo(spec.ConfigMapInfo)) != 0 {
 		configVolumes, _ := getMultiConfigVolumeAndVolumeMount(&spec.ConfigMapInfo, componentType)
+		// mount the configmap rendered by operator in place of the core configmap, the volume name not changed for keeping the volumeMounts.
+		if NeedRenderConfig(dcr, componentType) {
+			coreConfigMapName := getDorisCoreConfigMapName(dcr, componentType)
+			for i := range configVolumes {
+				if coreConfigMapName != "" && configVolumes[i].Name == coreConfigMapName && configVolumes[i].ConfigMap != nil {
+					configVolumes[i].ConfigMap.Name = GetRenderedConfigMapName(dcr, componentType)
+				}
+			}
+		}
 		volumes = append(volumes, configVolumes...)
 	}
 
@@ -226,6 +235,11 @@ func NewPodTemplateSpec(dcr *v1.DorisCluster, config map[string]interface{}, com
 		volumes = append(volumes, sharedVolumes...)
 	}
 
+	if dcr.IsTLSEnabled() && componentType != v1.Component_Broker {
+		tlsVolume, _ := GetTLSVolumeAndVolumeMount(GetTLSServerSecretName(v1.GenerateComponentStatefulSetName(dcr, componentType)))
+		volumes = append(volumes, tlsVolume)
+	}
+
 	pts := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        GeneratePodTemplateName(dcr, componentType),
@@ -543,6 +557,11 @@ func NewBaseMainContainer(dcr *v1.DorisCluster, config map[string]interface{}, c
 		})
 	}
 
+	if dcr.IsTLSEnabled() && componentType != v1.Component_Broker {
+		_, tlsVolumeMount := GetTLSVolumeAndVolumeMount(GetTLSServerSecretName(v1.GenerateComponentStatefulSetName(dcr, componentType)))
+		volumeMounts = append(volumeMounts, tlsVolumeMount)
+	}
+
 	if len(sharedVolumeMounts) != 0 {
 		volumeMounts = append(volumeMounts, sharedVolumeMounts...)
 	}
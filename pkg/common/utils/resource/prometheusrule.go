@@ -0,0 +1,191 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package resource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/doris-operator/pkg/common/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const PrometheusRuleKind = "PrometheusRule"
+
+// the default thresholds of alerts.
+const (
+	DEFAULT_ALERT_FOR                    = 5 * time.Minute
+	DEFAULT_DISK_USAGE_PERCENT_THRESHOLD = 85
+	DEFAULT_COMPACTION_SCORE_THRESHOLD   = 100
+	DEFAULT_DECOMMISSION_TIMEOUT         = 2 * time.Hour
+)
+
+// the severities of alerts.
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityWarning  = "warning"
+)
+
+// PrometheusRuleOptions describes the `PrometheusRule` with the alerts of a doris cluster, zero thresholds use the defaults.
+type PrometheusRuleOptions struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// the owners of PrometheusRule, the PrometheusRule is deleted with the cluster.
+	OwnerReferences []metav1.OwnerReference
+	// the name of doris cluster, alerts select the metrics of the cluster by the `namespace` and `doris_cluster` labels.
+	ClusterName string
+	// ComputeGroupAlert adds the alert of unavailable compute groups, only DorisDisaggregatedCluster has compute groups.
+	ComputeGroupAlert         bool
+	For                       time.Duration
+	DiskUsagePercentThreshold int32
+	CompactionScoreThreshold  int32
+	DecommissionTimeout       time.Duration
+}
+
+type alertRule struct {
+	alert       string
+	expr        string
+	for_        time.Duration
+	severity    string
+	summary     string
+	description string
+}
+
+// BuildPrometheusRule build the Prometheus Operator `PrometheusRule` with the curated alerts of doris cluster.
+func BuildPrometheusRule(opts PrometheusRuleOptions) *unstructured.Unstructured {
+	alertFor := opts.For
+	if alertFor <= 0 {
+		alertFor = DEFAULT_ALERT_FOR
+	}
+	diskThreshold := opts.DiskUsagePercentThreshold
+	if diskThreshold <= 0 {
+		diskThreshold = DEFAULT_DISK_USAGE_PERCENT_THRESHOLD
+	}
+	compactionThreshold := opts.CompactionScoreThreshold
+	if compactionThreshold <= 0 {
+		compactionThreshold = DEFAULT_COMPACTION_SCORE_THRESHOLD
+	}
+	decommissionTimeout := opts.DecommissionTimeout
+	if decommissionTimeout <= 0 {
+		decommissionTimeout = DEFAULT_DECOMMISSION_TIMEOUT
+	}
+
+	sel := fmt.Sprintf(`namespace="%s",%s="%s"`, opts.Namespace, MetricsClusterLabel, opts.ClusterName)
+	nodeInfo := func(tp, state string) string {
+		return fmt.Sprintf(`max(doris_fe_node_info{%s,type="%s",state="%s"})`, sel, tp, state)
+	}
+	alerts := []alertRule{{
+		alert:       "DorisFEMasterMissing",
+		expr:        fmt.Sprintf(`max(doris_fe_node_info{%[1]s,type="is_master"}) < 1 or absent(doris_fe_node_info{%[1]s,type="is_master"})`, sel),
+		for_:        alertFor,
+		severity:    AlertSeverityCritical,
+		summary:     "fe master is missing",
+		description: "no fe of doris cluster " + opts.ClusterName + " is master, the metadata of cluster can not be updated.",
+	}, {
+		alert:       "DorisFENodeNotAlive",
+		expr:        nodeInfo("fe_node_num", "alive") + " < " + nodeInfo("fe_node_num", "total"),
+		for_:        alertFor,
+		severity:    AlertSeverityWarning,
+		summary:     "fe followers or observers are not alive",
+		description: "{{ $value }} fe nodes of doris cluster " + opts.ClusterName + " are alive, less than the total.",
+	}, {
+		alert:       "DorisBENodeNotAlive",
+		expr:        nodeInfo("be_node_num", "alive") + " < " + nodeInfo("be_node_num", "total"),
+		for_:        alertFor,
+		severity:    AlertSeverityWarning,
+		summary:     "backends are not alive",
+		description: "{{ $value }} backends of doris cluster " + opts.ClusterName + " are alive, less than the total.",
+	}, {
+		alert:       "DorisNodeHeartbeatFailed",
+		expr:        fmt.Sprintf(`up{%s} == 0`, sel),
+		for_:        alertFor,
+		severity:    AlertSeverityWarning,
+		summary:     "doris node is unreachable",
+		description: "the {{ $labels." + MetricsComponentLabel + " }} node {{ $labels.instance }} of doris cluster " + opts.ClusterName + " not responds to the scraping of Prometheus.",
+	}, {
+		alert:       "DorisBEDiskUsageHigh",
+		expr:        fmt.Sprintf(`(1 - doris_be_disks_avail_capacity{%[1]s} / doris_be_disks_total_capacity{%[1]s}) * 100 > %d`, sel, diskThreshold),
+		for_:        alertFor,
+		severity:    AlertSeverityWarning,
+		summary:     "disk usage of backend is high",
+		description: fmt.Sprintf("the disk {{ $labels.path }} of backend {{ $labels.instance }} in doris cluster %s is {{ $value | printf \"%%.2f\" }}%% used, more than %d%%.", opts.ClusterName, diskThreshold),
+	}, {
+		alert: "DorisBECompactionScoreHigh",
+		expr: fmt.Sprintf(`max by (instance) (doris_be_tablet_base_max_compaction_score{%[1]s}) > %[2]d or max by (instance) (doris_be_tablet_cumulative_max_compaction_score{%[1]s}) > %[2]d`,
+			sel, compactionThreshold),
+		for_:        alertFor,
+		severity:    AlertSeverityWarning,
+		summary:     "compaction score of backend is high",
+		description: fmt.Sprintf("the max compaction score of tablets on backend {{ $labels.instance }} in doris cluster %s is {{ $value }}, more than %d.", opts.ClusterName, compactionThreshold),
+	}, {
+		alert:       "DorisBEDecommissionTooLong",
+		expr:        nodeInfo("be_node_num", "decommissioned") + " > 0",
+		for_:        decommissionTimeout,
+		severity:    AlertSeverityWarning,
+		summary:     "backends are decommissioning too long",
+		description: fmt.Sprintf("{{ $value }} backends of doris cluster %s are still decommissioning after %s.", opts.ClusterName, decommissionTimeout.String()),
+	}}
+	if opts.ComputeGroupAlert {
+		alerts = append(alerts, alertRule{
+			alert:       "DorisComputeGroupUnavailable",
+			expr:        fmt.Sprintf(`%s{%s="%s",%s="%s"} == 0`, metrics.ComputeGroupAvailableName, metrics.NamespaceLabel, opts.Namespace, metrics.ClusterLabel, opts.ClusterName),
+			for_:        alertFor,
+			severity:    AlertSeverityCritical,
+			summary:     "compute group is unavailable",
+			description: "the compute group {{ $labels." + metrics.ComputeGroupLabel + " }} of doris cluster " + opts.ClusterName + " has no available backend.",
+		})
+	}
+
+	var rules []interface{}
+	for _, a := range alerts {
+		rules = append(rules, map[string]interface{}{
+			"alert": a.alert,
+			"expr":  a.expr,
+			"for":   a.for_.Truncate(time.Second).String(),
+			"labels": map[string]interface{}{
+				"severity":          a.severity,
+				MetricsClusterLabel: opts.ClusterName,
+			},
+			"annotations": map[string]interface{}{
+				"summary":     a.summary,
+				"description": a.description,
+			},
+		})
+	}
+	spec := map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  opts.Name,
+				"rules": rules,
+			},
+		},
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: MonitoringGroup, Version: MonitoringVersion, Kind: PrometheusRuleKind})
+	u.SetName(opts.Name)
+	u.SetNamespace(opts.Namespace)
+	u.SetLabels(opts.Labels)
+	if len(opts.OwnerReferences) != 0 {
+		u.SetOwnerReferences(opts.OwnerReferences)
+	}
+	return u
+}
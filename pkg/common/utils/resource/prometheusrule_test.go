@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package resource
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_BuildPrometheusRule(t *testing.T) {
+	opts := PrometheusRuleOptions{
+		Name:        "test-alert-rules",
+		Namespace:   "default",
+		Labels:      map[string]string{"release": "prometheus"},
+		ClusterName: "test",
+	}
+
+	u := BuildPrometheusRule(opts)
+	if u.GetKind() != PrometheusRuleKind || u.GetAPIVersion() != "monitoring.coreos.com/v1" {
+		t.Errorf("the kind of PrometheusRule is wrong, apiVersion=%s kind=%s", u.GetAPIVersion(), u.GetKind())
+	}
+	rules := getTestAlerts(t, u)
+	if len(rules) != 7 {
+		t.Errorf("the rules of DorisCluster should have 7 alerts, got %d", len(rules))
+	}
+	if _, ok := rules["DorisComputeGroupUnavailable"]; ok {
+		t.Errorf("the compute group alert should not be rendered without compute groups.")
+	}
+	disk := rules["DorisBEDiskUsageHigh"]
+	if !strings.HasSuffix(disk["expr"].(string), "> 85") || disk["for"] != "5m0s" {
+		t.Errorf("the disk alert should use the default threshold, %v", disk)
+	}
+	if !strings.Contains(rules["DorisFEMasterMissing"]["expr"].(string), `namespace="default",doris_cluster="test"`) {
+		t.Errorf("the alerts should select the metrics of cluster, %s", rules["DorisFEMasterMissing"]["expr"])
+	}
+	if rules["DorisBEDecommissionTooLong"]["for"] != "2h0m0s" {
+		t.Errorf("the decommission alert should use the default timeout, %v", rules["DorisBEDecommissionTooLong"]["for"])
+	}
+
+	opts.ComputeGroupAlert = true
+	opts.For = 10 * time.Minute
+	opts.DiskUsagePercentThreshold = 90
+	opts.CompactionScoreThreshold = 200
+	opts.DecommissionTimeout = 30 * time.Minute
+	rules = getTestAlerts(t, BuildPrometheusRule(opts))
+	if !strings.HasSuffix(rules["DorisBEDiskUsageHigh"]["expr"].(string), "> 90") || rules["DorisBEDiskUsageHigh"]["for"] != "10m0s" {
+		t.Errorf("the disk alert should use the configured threshold, %v", rules["DorisBEDiskUsageHigh"])
+	}
+	if !strings.Contains(rules["DorisBECompactionScoreHigh"]["expr"].(string), "> 200") {
+		t.Errorf("the compaction alert should use the configured threshold, %v", rules["DorisBECompactionScoreHigh"])
+	}
+	if rules["DorisBEDecommissionTooLong"]["for"] != "30m0s" {
+		t.Errorf("the decommission alert should use the configured timeout, %v", rules["DorisBEDecommissionTooLong"]["for"])
+	}
+	cg, ok := rules["DorisComputeGroupUnavailable"]
+	if !ok || cg["expr"] != `doris_operator_compute_group_available{doris_namespace="default",doris_cluster="test"} == 0` {
+		t.Errorf("the compute group alert is wrong, %v", cg)
+	}
+}
+
+func getTestAlerts(t *testing.T, u *unstructured.Unstructured) map[string]map[string]interface{} {
+	groups, _, _ := unstructured.NestedSlice(u.Object, "spec", "groups")
+	if len(groups) != 1 {
+		t.Fatalf("the PrometheusRule should have one group, got %d", len(groups))
+	}
+	alerts := map[string]map[string]interface{}{}
+	for _, r := range groups[0].(map[string]interface{})["rules"].([]interface{}) {
+		rule := r.(map[string]interface{})
+		alerts[rule["alert"].(string)] = rule
+	}
+	return alerts
+}
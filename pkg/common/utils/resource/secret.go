@@ -17,7 +17,10 @@
 
 package resource
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func GetDorisLoginInformation(secret *corev1.Secret) (adminUserName, password string) {
 	adminUserName = "root"
@@ -29,3 +32,30 @@ func GetDorisLoginInformation(secret *corev1.Secret) (adminUserName, password st
 	}
 	return adminUserName, password
 }
+
+// GetPasswordRotationStateSecretName return the name of secret that stores the state of password rotation of cluster.
+func GetPasswordRotationStateSecretName(clusterName string) string {
+	return clusterName + "-auth-rotation"
+}
+
+// GetAdminUserSecretName return the name of secret that the deprecated `adminUser` credentials migrated to.
+func GetAdminUserSecretName(clusterName string) string {
+	return clusterName + "-admin-user"
+}
+
+// BuildAdminUserSecret build the `kubernetes.io/basic-auth` secret that stores the credentials of management user.
+// the secret is not owned by cluster, the credentials are kept when the cluster deleted.
+func BuildAdminUserSecret(name, namespace string, labels map[string]string, username, password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte(username),
+			corev1.BasicAuthPasswordKey: []byte(password),
+		},
+	}
+}
@@ -15,3 +15,120 @@
 // specific language governing permissions and limitations
 // under the License.
 package resource
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	MonitoringGroup    = "monitoring.coreos.com"
+	MonitoringVersion  = "v1"
+	ServiceMonitorKind = "ServiceMonitor"
+	PodMonitorKind     = "PodMonitor"
+)
+
+// the labels added on the scraped metrics for identifying the cluster, component and compute group.
+const (
+	MetricsClusterLabel      = "doris_cluster"
+	MetricsComponentLabel    = "doris_component"
+	MetricsComputeGroupLabel = "doris_compute_group"
+)
+
+// the metrics paths of components, meta service exposes the metrics of brpc.
+const (
+	DEFAULT_METRICS_PATH = "/metrics"
+	MS_METRICS_PATH      = "/brpc_metrics"
+)
+
+// MonitorOptions describes the `ServiceMonitor` or `PodMonitor` that scrape the metrics of a component.
+type MonitorOptions struct {
+	// ServiceMonitor or PodMonitor, default ServiceMonitor.
+	Kind      string
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// the owners of monitor, the monitor is deleted with the cluster.
+	OwnerReferences []metav1.OwnerReference
+	// the labels of service for ServiceMonitor, or the labels of pods for PodMonitor.
+	Selector map[string]string
+	// the name of port that exposes metrics, ports are named by `GetPortKey`.
+	Port          string
+	Path          string
+	Interval      time.Duration
+	ScrapeTimeout time.Duration
+	// MetricsLabels are added on every scraped metric by relabeling.
+	MetricsLabels map[string]string
+}
+
+// GetMonitorKind return the kind of monitor, default is ServiceMonitor.
+func GetMonitorKind(kind string) string {
+	if kind == PodMonitorKind {
+		return PodMonitorKind
+	}
+	return ServiceMonitorKind
+}
+
+// BuildMonitor build the Prometheus Operator `ServiceMonitor` or `PodMonitor`.
+func BuildMonitor(opts MonitorOptions) *unstructured.Unstructured {
+	kind := GetMonitorKind(opts.Kind)
+	endpoint := map[string]interface{}{
+		"port": opts.Port,
+		"path": opts.Path,
+	}
+	if opts.Interval > 0 {
+		endpoint["interval"] = opts.Interval.String()
+	}
+	if opts.ScrapeTimeout > 0 {
+		endpoint["scrapeTimeout"] = opts.ScrapeTimeout.String()
+	}
+	var relabelings []interface{}
+	for _, k := range sortedKeys(opts.MetricsLabels) {
+		relabelings = append(relabelings, map[string]interface{}{
+			"action":      "replace",
+			"targetLabel": k,
+			"replacement": opts.MetricsLabels[k],
+		})
+	}
+	if len(relabelings) != 0 {
+		endpoint["relabelings"] = relabelings
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range opts.Selector {
+		matchLabels[k] = v
+	}
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+	}
+	if kind == PodMonitorKind {
+		spec["podMetricsEndpoints"] = []interface{}{endpoint}
+	} else {
+		spec["endpoints"] = []interface{}{endpoint}
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: MonitoringGroup, Version: MonitoringVersion, Kind: kind})
+	u.SetName(opts.Name)
+	u.SetNamespace(opts.Namespace)
+	u.SetLabels(opts.Labels)
+	if len(opts.OwnerReferences) != 0 {
+		u.SetOwnerReferences(opts.OwnerReferences)
+	}
+	return u
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package resource
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_BuildMonitor(t *testing.T) {
+	opts := MonitorOptions{
+		Name:            "test-be",
+		Namespace:       "default",
+		Labels:          map[string]string{"release": "prometheus"},
+		OwnerReferences: []metav1.OwnerReference{{Name: "test"}},
+		Selector:        map[string]string{"app.doris.ownerreference/name": "test"},
+		Port:            GetPortKey(WEBSERVER_PORT),
+		Path:            DEFAULT_METRICS_PATH,
+		Interval:        30 * time.Second,
+		MetricsLabels:   map[string]string{MetricsComponentLabel: "be", MetricsClusterLabel: "test"},
+	}
+
+	u := BuildMonitor(opts)
+	if u.GetKind() != ServiceMonitorKind || u.GetAPIVersion() != "monitoring.coreos.com/v1" {
+		t.Errorf("the default monitor should be ServiceMonitor, apiVersion=%s kind=%s", u.GetAPIVersion(), u.GetKind())
+	}
+	endpoints, _, _ := unstructured.NestedSlice(u.Object, "spec", "endpoints")
+	if len(endpoints) != 1 {
+		t.Fatalf("the ServiceMonitor should have one endpoint, got %d", len(endpoints))
+	}
+	endpoint := endpoints[0].(map[string]interface{})
+	if endpoint["port"] != "webserver-port" || endpoint["path"] != "/metrics" || endpoint["interval"] != "30s" {
+		t.Errorf("the endpoint of ServiceMonitor is wrong, %v", endpoint)
+	}
+	if _, ok := endpoint["scrapeTimeout"]; ok {
+		t.Errorf("the scrapeTimeout should not be set when not configured.")
+	}
+	relabelings := endpoint["relabelings"].([]interface{})
+	if len(relabelings) != 2 || relabelings[0].(map[string]interface{})["targetLabel"] != MetricsClusterLabel {
+		t.Errorf("the relabelings should add the labels in order, %v", relabelings)
+	}
+	if label, _, _ := unstructured.NestedString(u.Object, "spec", "selector", "matchLabels", "app.doris.ownerreference/name"); label != "test" {
+		t.Errorf("the selector of ServiceMonitor is wrong.")
+	}
+
+	opts.Kind = PodMonitorKind
+	u = BuildMonitor(opts)
+	if u.GetKind() != PodMonitorKind {
+		t.Errorf("the monitor kind is %s, expect PodMonitor", u.GetKind())
+	}
+	if endpoints, _, _ := unstructured.NestedSlice(u.Object, "spec", "podMetricsEndpoints"); len(endpoints) != 1 {
+		t.Errorf("the PodMonitor should have one podMetricsEndpoint.")
+	}
+}
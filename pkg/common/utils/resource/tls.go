@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package resource
+
+import (
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/certificate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ENABLE_SSL_KEY = "enable_ssl"
+
+	tls_volume_name = "doris-operator-tls"
+	// TLS_MOUNT_PATH the path of certificates issued by operator in pods.
+	TLS_MOUNT_PATH = DEFAULT_ROOT_PATH + "/tls"
+
+	// TLSCertificateHashAnnotation records the hash of certificates in pod template, pods restart when the certificates rotated.
+	TLSCertificateHashAnnotation = "apache.doris.org/tls-certificate-hash"
+)
+
+var (
+	DefaultTLSCertificateValidity = 365 * 24 * time.Hour
+	DefaultTLSRenewBefore         = 30 * 24 * time.Hour
+)
+
+// GetTLSDurations returns the validity of certificates and the duration before expiry to renew.
+// if renewBefore is not less than validity, the certificates are renewed when a third of validity left.
+func GetTLSDurations(validity, renewBefore *metav1.Duration) (time.Duration, time.Duration) {
+	v := DefaultTLSCertificateValidity
+	if validity != nil && validity.Duration > 0 {
+		v = validity.Duration
+	}
+	rb := DefaultTLSRenewBefore
+	if renewBefore != nil && renewBefore.Duration > 0 {
+		rb = renewBefore.Duration
+	}
+	if rb >= v {
+		rb = v / 3
+	}
+	return v, rb
+}
+
+// GetTLSCASecretName returns the name of secret that stores the ca of cluster.
+func GetTLSCASecretName(clusterName string) string {
+	return clusterName + "-tls-ca"
+}
+
+// GetTLSClientSecretName returns the name of secret that stores the client certificate used by operator to connect doris.
+func GetTLSClientSecretName(clusterName string) string {
+	return clusterName + "-tls-client"
+}
+
+// GetTLSServerSecretName returns the name of secret that stores the certificate of the pods in statefulset.
+func GetTLSServerSecretName(statefulsetName string) string {
+	return statefulsetName + "-tls"
+}
+
+// BuildServiceDNSNames build the dns names of certificate. the pods in statefulset are accessed by the fqdn of headless service,
+// the other services are accessed by service name.
+func BuildServiceDNSNames(namespace string, headlessService string, services ...string) []string {
+	dnsNames := []string{"localhost"}
+	for _, suffix := range []string{"", "." + namespace, "." + namespace + ".svc", "." + namespace + ".svc.cluster.local"} {
+		dnsNames = append(dnsNames, headlessService+suffix, "*."+headlessService+suffix)
+		for _, svc := range services {
+			dnsNames = append(dnsNames, svc+suffix)
+		}
+	}
+	return dnsNames
+}
+
+// BuildTLSSecret build the secret that stores the certificate and the ca.
+func BuildTLSSecret(name, namespace string, labels map[string]string, ownerRef metav1.OwnerReference, ca, cert *certificate.CA) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			certificate.TlsCACertName: ca.GetEncodeCert(),
+			certificate.TLsCertName:   cert.GetEncodeCert(),
+			certificate.TlsKeyName:    cert.GetEncodePrivateKey(),
+		},
+	}
+}
+
+// getTLSConfigValues returns the tls configs pointed to the certificates issued by operator.
+func getTLSConfigValues() [][2]string {
+	return [][2]string{
+		{ENABLE_TLS_KEY, "true"},
+		{ENABLE_SSL_KEY, "true"},
+		{TLS_CA_CERTIFICATE_PATH_KEY, TLS_MOUNT_PATH + "/" + certificate.TlsCACertName},
+		{TLS_CERTIFICATE_PATH_KEY, TLS_MOUNT_PATH + "/" + certificate.TLsCertName},
+		{TLS_PRIVATE_KEY_PATH_KEY, TLS_MOUNT_PATH + "/" + certificate.TlsKeyName},
+	}
+}
+
+// RenderTLSConfig overwrite the tls configs in conf with the certificates issued by operator.
+func RenderTLSConfig(conf string) string {
+	return overwriteConfigValues(conf, getTLSConfigValues())
+}
+
+// InjectTLSConfig set the tls configs into the resolved config, the probes and sql clients use the certificates issued by operator.
+func InjectTLSConfig(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	for _, kv := range getTLSConfigValues() {
+		config[kv[0]] = kv[1]
+	}
+	return config
+}
+
+// GetTLSVolumeAndVolumeMount returns the volume and volumeMount of the secret that stores certificate issued by operator.
+func GetTLSVolumeAndVolumeMount(secretName string) (corev1.Volume, corev1.VolumeMount) {
+	return corev1.Volume{
+		Name: tls_volume_name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}, corev1.VolumeMount{
+		Name:      tls_volume_name,
+		MountPath: TLS_MOUNT_PATH,
+		ReadOnly:  true,
+	}
+}
+
+// GetDisaggregatedTLSRenderedConfigMapName returns the name of configmap that have tls configs rendered by operator.
+func GetDisaggregatedTLSRenderedConfigMapName(ddc *dv1.DorisDisaggregatedCluster, configMapName string) string {
+	return ddc.Name + "-" + configMapName + "-tls-rendered"
+}
+
+// BuildDisaggregatedTLSRenderedConfigMap build the configmap copied from the configmap of disaggregated cluster, the tls configs of config file are rendered.
+func BuildDisaggregatedTLSRenderedConfigMap(ddc *dv1.DorisDisaggregatedCluster, cm *corev1.ConfigMap, resolveKey string) *corev1.ConfigMap {
+	data := make(map[string]string, len(cm.Data))
+	for k, v := range cm.Data {
+		data[k] = v
+	}
+	data[resolveKey] = RenderTLSConfig(data[resolveKey])
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetDisaggregatedTLSRenderedConfigMapName(ddc, cm.Name),
+			Namespace:       ddc.Namespace,
+			Labels:          map[string]string{dv1.DorisDisaggregatedClusterName: ddc.Name},
+			OwnerReferences: []metav1.OwnerReference{GetOwnerReference(ddc)},
+		},
+		Data: data,
+	}
+}
+
+// SetTLSCertificateHash record the hash of certificates in the annotations of pod template.
+func SetTLSCertificateHash(pts *corev1.PodTemplateSpec, certHash string) {
+	annotations := make(map[string]string, len(pts.Annotations)+1)
+	for k, v := range pts.Annotations {
+		annotations[k] = v
+	}
+	annotations[TLSCertificateHashAnnotation] = certHash
+	pts.Annotations = annotations
+}
@@ -0,0 +1,62 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// migrateAdminUserToSecret stores the deprecated plaintext `adminUser` credentials of cluster in a `kubernetes.io/basic-auth` secret labeled with cluster,
+// and returns the name of secret that should be set as `authSecret`. the secret is not owned by cluster, so the credentials are kept when the cluster deleted.
+// the secret with the same name not labeled with cluster is not overwritten.
+func migrateAdminUserToSecret(ctx context.Context, k8sclient client.Client, cluster client.Object, labels map[string]string, username, password string) (string, error) {
+	secretName := resource.GetAdminUserSecretName(cluster.GetName())
+	secret := resource.BuildAdminUserSecret(secretName, cluster.GetNamespace(), labels, username, password)
+
+	exist, err := k8s.GetSecret(ctx, k8sclient, cluster.GetNamespace(), secretName)
+	if apierrors.IsNotFound(err) {
+		if err := k8s.CreateSecret(ctx, k8sclient, secret); err != nil {
+			return "", err
+		}
+		klog.Infof("migrateAdminUserToSecret namespace=%s name=%s created secret %s for adminUser.", cluster.GetNamespace(), cluster.GetName(), secretName)
+		return secretName, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range labels {
+		if exist.Labels[k] != v {
+			return "", fmt.Errorf("secret %s already exists and is not created for cluster %s", secretName, cluster.GetName())
+		}
+	}
+
+	// the secret created by the interrupted migration, refresh the credentials with the current adminUser.
+	exist.Data = secret.Data
+	if err := k8s.UpdateSecret(ctx, k8sclient, exist); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
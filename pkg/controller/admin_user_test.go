@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_migrateAdminUserToSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = dorisv1.AddToScheme(scheme)
+	dcr := &dorisv1.DorisCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: dorisv1.GroupVersion.String(), Kind: "DorisCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+	}
+	labels := map[string]string{dorisv1.DorisClusterLabelKey: dcr.Name}
+	k8sclient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	name, err := migrateAdminUserToSecret(context.Background(), k8sclient, dcr, labels, "root", "pwd")
+	if err != nil || name != resource.GetAdminUserSecretName(dcr.Name) {
+		t.Fatalf("migrate adminUser failed, name=%s, err=%v", name, err)
+	}
+	secret, err := k8s.GetSecret(context.Background(), k8sclient, dcr.Namespace, name)
+	if err != nil {
+		t.Fatalf("get migrated secret failed, err=%s", err.Error())
+	}
+	if secret.Type != corev1.SecretTypeBasicAuth || string(secret.Data["username"]) != "root" || string(secret.Data["password"]) != "pwd" {
+		t.Errorf("migrated secret not match, type=%s, data=%v", secret.Type, secret.Data)
+	}
+
+	// the interrupted migration is resumed with the current adminUser.
+	if _, err := migrateAdminUserToSecret(context.Background(), k8sclient, dcr, labels, "root", "pwd2"); err != nil {
+		t.Fatalf("resume migration failed, err=%s", err.Error())
+	}
+	secret, _ = k8s.GetSecret(context.Background(), k8sclient, dcr.Namespace, name)
+	if string(secret.Data["password"]) != "pwd2" {
+		t.Errorf("migrated secret not updated, password=%s", string(secret.Data["password"]))
+	}
+
+	// the secret is not owned by cluster, the credentials are kept when the cluster deleted.
+	if len(secret.OwnerReferences) != 0 {
+		t.Errorf("expected the migrated secret not owned by cluster, ownerReferences=%v", secret.OwnerReferences)
+	}
+
+	// the secret not created for cluster is not overwritten.
+	secret.Labels = nil
+	if err := k8sclient.Update(context.Background(), secret); err != nil {
+		t.Fatalf("update secret failed, err=%s", err.Error())
+	}
+	if _, err := migrateAdminUserToSecret(context.Background(), k8sclient, dcr, labels, "root", "pwd3"); err == nil {
+		t.Errorf("expected the secret not created for cluster to be rejected")
+	}
+}
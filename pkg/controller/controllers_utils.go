@@ -32,7 +32,13 @@ func inconsistentStatus(status *v1.DorisClusterStatus, dcr *v1.DorisCluster) boo
 	return inconsistentFEStatus(status.FEStatus, dcr.Status.FEStatus) ||
 		inconsistentBEStatus(status.BEStatus, dcr.Status.BEStatus) ||
 		inconsistentCnStatus(status.CnStatus, dcr.Status.CnStatus) ||
-		inconsistentBrokerStatus(status.BrokerStatus, dcr.Status.BrokerStatus)
+		inconsistentBrokerStatus(status.BrokerStatus, dcr.Status.BrokerStatus) ||
+		!reflect.DeepEqual(status.ClusterHealth, dcr.Status.ClusterHealth) ||
+		!reflect.DeepEqual(status.Conditions, dcr.Status.Conditions) ||
+		!reflect.DeepEqual(status.Nodes, dcr.Status.Nodes) ||
+		!reflect.DeepEqual(status.Versions, dcr.Status.Versions) ||
+		!reflect.DeepEqual(status.VersionSkewSince, dcr.Status.VersionSkewSince) ||
+		!reflect.DeepEqual(status.QueryProbe, dcr.Status.QueryProbe)
 }
 
 func inconsistentCnStatus(eStatus *v1.CnStatus, nStatus *v1.CnStatus) bool {
@@ -25,6 +25,9 @@ import (
 	"time"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	"github.com/apache/doris-operator/pkg/common/logging"
+	"github.com/apache/doris-operator/pkg/common/metrics"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/apache/doris-operator/pkg/common/utils/hash"
 	sc "github.com/apache/doris-operator/pkg/controller/sub_controller"
 	dcgs "github.com/apache/doris-operator/pkg/controller/sub_controller/disaggregated_cluster/computegroups"
@@ -61,12 +64,9 @@ type DisaggregatedClusterReconciler struct {
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
 	Scs      map[string]sc.DisaggregatedSubController
-	//record configmap response instance. key: configMap namespacedName, value: DorisDisaggregatedCluster namespacedName
-	//wcms map[string]string
 }
 
 func (dc *DisaggregatedClusterReconciler) Init(mgr ctrl.Manager, options *Options) {
-	//wcms := make(map[string]string)
 	scs := make(map[string]sc.DisaggregatedSubController)
 	msc := metaservice.New(mgr)
 	scs[msc.GetControllerName()] = msc
@@ -78,15 +78,17 @@ func (dc *DisaggregatedClusterReconciler) Init(mgr ctrl.Manager, options *Option
 
 	if err := (&DisaggregatedClusterReconciler{
 		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor(disaggregatedClusterController),
+		Recorder: sc.NewDedupRecorder(mgr.GetEventRecorderFor(disaggregatedClusterController)),
 		Scs:      scs,
-		//wcms:     wcms,
 	}).SetupWithManager(mgr); err != nil {
 		klog.Error(err, "unable to create controller ", "disaggregatedClusterReconciler")
 		os.Exit(1)
 	}
 
 	if options.EnableWebHook {
+		dv1.RejectPlaintextAdminPassword = options.RejectPlaintextAdminPassword
+		cv := &sc.ConfigMapValidator{K8sclient: mgr.GetClient()}
+		dv1.ConfigValidator = cv.ValidateDisaggregatedCluster
 		if err := (&dv1.DorisDisaggregatedCluster{}).SetupWebhookWithManager(mgr); err != nil {
 			klog.Error(err, " unable to create unnamedwatches ", " controller ", " DorisDisaggregatedCluster ")
 			os.Exit(1)
@@ -95,12 +97,83 @@ func (dc *DisaggregatedClusterReconciler) Init(mgr ctrl.Manager, options *Option
 }
 
 func (dc *DisaggregatedClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dv1.DorisDisaggregatedCluster{}, configMapIndexField, func(o client.Object) []string {
+		return referConfigMapNames(o.(*dv1.DorisDisaggregatedCluster))
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dv1.DorisDisaggregatedCluster{}, authSecretIndexField, func(o client.Object) []string {
+		ddc := o.(*dv1.DorisDisaggregatedCluster)
+		return rotatedAuthSecret(ddc.Spec.AuthSecret, ddc.Spec.PasswordRotation != nil)
+	}); err != nil {
+		return err
+	}
+
 	builder := dc.resourceBuilder(ctrl.NewControllerManagedBy(mgr))
 	builder = dc.watchPodBuilder(builder)
-	//builder = dc.watchConfigMapBuilder(builder)
+	builder = dc.watchSecretBuilder(builder)
+	builder = dc.watchConfigMapBuilder(builder)
 	return builder.Complete(dc)
 }
 
+// watchSecretBuilder watch the tls secrets and the AuthSecret of cluster. the secrets issued by cert-manager and the state secret of password rotation are not owned by cluster but have the label of cluster.
+// reconcile the cluster when the secrets issued or rotated for applying the statefulsets and restarting pods, or the password in AuthSecret changed for rotating the password.
+// the clusters are looked up by the label or the index of AuthSecret, the events of other secrets are filtered out.
+func (dc *DisaggregatedClusterReconciler) watchSecretBuilder(builder *ctrl.Builder) *ctrl.Builder {
+	mapFn := handler.EnqueueRequestsFromMapFunc(dc.clustersReferSecret)
+
+	p := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return len(dc.clustersReferSecret(context.Background(), e.Object)) != 0
+		},
+		UpdateFunc: func(u event.UpdateEvent) bool {
+			return u.ObjectOld.GetResourceVersion() != u.ObjectNew.GetResourceVersion() &&
+				len(dc.clustersReferSecret(context.Background(), u.ObjectNew)) != 0
+		},
+		DeleteFunc: func(d event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(g event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return builder.Watches(&corev1.Secret{},
+		mapFn, controller_builder.WithPredicates(p))
+}
+
+// authSecretIndexField is the index of clusters by the name of AuthSecret that the password of management user rotated in.
+const authSecretIndexField = "spec.authSecret"
+
+// rotatedAuthSecret returns the AuthSecret as the index value when password rotation enabled, nil otherwise.
+func rotatedAuthSecret(authSecret string, rotationEnabled bool) []string {
+	if authSecret == "" || !rotationEnabled {
+		return nil
+	}
+	return []string{authSecret}
+}
+
+// clustersReferSecret returns the request of cluster that labeled on the secret, or the requests of clusters that rotate the password in the secret.
+func (dc *DisaggregatedClusterReconciler) clustersReferSecret(ctx context.Context, a client.Object) []reconcile.Request {
+	if ddcName := a.GetLabels()[dv1.DorisDisaggregatedClusterName]; ddcName != "" {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Name:      ddcName,
+			Namespace: a.GetNamespace(),
+		}}}
+	}
+
+	var ddcs dv1.DorisDisaggregatedClusterList
+	if err := dc.Client.List(ctx, &ddcs, client.InNamespace(a.GetNamespace()), client.MatchingFields{authSecretIndexField: a.GetName()}); err != nil {
+		klog.Errorf("DisaggregatedClusterReconciler clustersReferSecret list disaggregated clusters in namespace=%s failed, err=%s", a.GetNamespace(), err.Error())
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range ddcs.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: ddcs.Items[i].Name, Namespace: ddcs.Items[i].Namespace}})
+	}
+	return reqs
+}
+
 func (dc *DisaggregatedClusterReconciler) watchPodBuilder(builder *ctrl.Builder) *ctrl.Builder {
 	mapFn := handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, a client.Object) []reconcile.Request {
@@ -146,41 +219,65 @@ func (dc *DisaggregatedClusterReconciler) watchPodBuilder(builder *ctrl.Builder)
 		mapFn, controller_builder.WithPredicates(p))
 }
 
-//func (dc *DisaggregatedClusterReconciler) watchConfigMapBuilder(builder *ctrl.Builder) *ctrl.Builder {
-//	mapFn := handler.EnqueueRequestsFromMapFunc(
-//		func(a client.Object) []reconcile.Request {
-//			namespace := a.GetNamespace()
-//			name := a.GetName()
-//			cmnn := types.NamespacedName{Namespace: namespace, Name: name}
-//			cmnnStr := cmnn.String()
-//			if ddc, ok := dc.wcms[cmnnStr]; ok {
-//				nna := strings.Split(ddc, "/")
-//				// not run only for code standard
-//				if len(nna) != 2 {
-//					return nil
-//				}
-//
-//				return []reconcile.Request{{NamespacedName: types.NamespacedName{
-//					Namespace: nna[0],
-//					Name:      nna[1],
-//				}}}
-//			}
-//			return nil
-//		})
-//
-//	p := predicate.Funcs{
-//		UpdateFunc: func(u event.UpdateEvent) bool {
-//			ns := u.ObjectNew.GetNamespace()
-//			name := u.ObjectNew.GetName()
-//			nsn := ns + "/" + name
-//			_, ok := dc.wcms[nsn]
-//			return ok
-//		},
-//	}
-//
-//	return builder.Watches(&source.Kind{Type: &corev1.ConfigMap{}},
-//		mapFn, controller_builder.WithPredicates(p))
-//}
+// watchConfigMapBuilder watch the configmaps referenced in `configMaps` of meta service, fe and compute groups.
+// reconcile the clusters that enable restarting when config change for restarting the pods that mount the changed configmap.
+// the clusters are looked up by the index of referenced configmaps, the events of configmaps not referenced are filtered out.
+func (dc *DisaggregatedClusterReconciler) watchConfigMapBuilder(builder *ctrl.Builder) *ctrl.Builder {
+	mapFn := handler.EnqueueRequestsFromMapFunc(dc.clustersReferConfigMap)
+
+	p := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return len(dc.clustersReferConfigMap(context.Background(), e.Object)) != 0
+		},
+		UpdateFunc: func(u event.UpdateEvent) bool {
+			return u.ObjectOld.GetResourceVersion() != u.ObjectNew.GetResourceVersion() &&
+				len(dc.clustersReferConfigMap(context.Background(), u.ObjectNew)) != 0
+		},
+		DeleteFunc: func(d event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(g event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return builder.Watches(&corev1.ConfigMap{},
+		mapFn, controller_builder.WithPredicates(p))
+}
+
+// configMapIndexField is the index of DorisDisaggregatedCluster by the names of configmaps that restart pods when changed.
+const configMapIndexField = "spec.configMaps.name"
+
+// clustersReferConfigMap returns the requests of clusters that restart pods when the configmap changed.
+func (dc *DisaggregatedClusterReconciler) clustersReferConfigMap(ctx context.Context, a client.Object) []reconcile.Request {
+	var ddcs dv1.DorisDisaggregatedClusterList
+	if err := dc.Client.List(ctx, &ddcs, client.InNamespace(a.GetNamespace()), client.MatchingFields{configMapIndexField: a.GetName()}); err != nil {
+		klog.Errorf("DisaggregatedClusterReconciler clustersReferConfigMap list disaggregated clusters in namespace=%s failed, err=%s", a.GetNamespace(), err.Error())
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range ddcs.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: ddcs.Items[i].Name, Namespace: ddcs.Items[i].Namespace}})
+	}
+	return reqs
+}
+
+// referConfigMapNames returns the names of configmaps mounted by meta service, fe and compute groups of cluster, nil when restarting on config change not enabled.
+func referConfigMapNames(ddc *dv1.DorisDisaggregatedCluster) []string {
+	if !ddc.IsRestartWhenConfigChangeEnabled() {
+		return nil
+	}
+	cms := append([]dv1.ConfigMap{}, ddc.Spec.MetaService.ConfigMaps...)
+	cms = append(cms, ddc.Spec.FeSpec.ConfigMaps...)
+	for i := range ddc.Spec.ComputeGroups {
+		cms = append(cms, ddc.Spec.ComputeGroups[i].ConfigMaps...)
+	}
+	var names []string
+	for _, cm := range cms {
+		names = append(names, cm.Name)
+	}
+	return names
+}
 
 func (dc *DisaggregatedClusterReconciler) resourceBuilder(builder *ctrl.Builder) *ctrl.Builder {
 	return builder.For(&dv1.DorisDisaggregatedCluster{}).
@@ -194,12 +291,27 @@ func (dc *DisaggregatedClusterReconciler) resourceBuilder(builder *ctrl.Builder)
 // 3. clear need delete resource.
 // 4. display new status(eorganize status, update cr or status)
 func (dc *DisaggregatedClusterReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.Start(ctx, "DorisDisaggregatedCluster.Reconcile", tracing.ClusterAttributes(metrics.DorisDisaggregatedClusterKind, req.Namespace, req.Name)...)
+	ctx, _ = logging.IntoContext(ctx, logging.ClusterKey, req.Name)
+	res, err := dc.reconcileCluster(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// reconcileCluster reconcile the DorisDisaggregatedCluster in the span of Reconcile.
+func (dc *DisaggregatedClusterReconciler) reconcileCluster(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	var ddc dv1.DorisDisaggregatedCluster
 	err := dc.Get(ctx, req.NamespacedName, &ddc)
 	if apierrors.IsNotFound(err) {
-		klog.Warningf("disaggreatedClusterReconciler not find resource DorisDisaggregatedCluster namespaceName %s", req.NamespacedName)
+		klog.FromContext(ctx).Info("disaggreatedClusterReconciler not find resource DorisDisaggregatedCluster")
+		metrics.DeleteCluster(metrics.DorisDisaggregatedClusterKind, req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
+
+	// move the deprecated plaintext adminUser into authSecret, the cluster reconciled again when the spec updated.
+	if ddc.DeletionTimestamp.IsZero() && ddc.Spec.AdminUser != nil {
+		return dc.migrateAdminUser(ctx, &ddc)
+	}
 	hv := hash.HashObject(ddc.Spec)
 
 	var res ctrl.Result
@@ -224,10 +336,16 @@ func (dc *DisaggregatedClusterReconciler) Reconcile(ctx context.Context, req rec
 
 	//display new status.
 	disRes, disErr := func() (ctrl.Result, error) {
+		//refresh the nodes queried from fe, and check the versions of nodes.
+		nc := &sc.DisaggregatedSubDefaultController{K8sclient: dc.Client, K8srecorder: dc.Recorder}
+		nc.RefreshNodes(ctx, &ddc)
+		nc.UpdateVersionSkew(ctx, &ddc)
+		nc.ProbeQueries(ctx, &ddc)
+
 		//reorganize status.
 		var stsRes ctrl.Result
 		var stsErr error
-		if stsRes, stsErr = dc.reorganizeStatus(&ddc); stsErr != nil {
+		if stsRes, stsErr = dc.reorganizeStatus(ctx, &ddc); stsErr != nil {
 			return stsRes, stsErr
 		}
 
@@ -248,9 +366,43 @@ func (dc *DisaggregatedClusterReconciler) Reconcile(ctx context.Context, req rec
 	if msg != "" {
 		return res, errors.New(msg)
 	}
+	// requeue for the next scheduled rotation of the password of management user.
+	if res.IsZero() && ddc.Spec.PasswordRotation != nil {
+		res.RequeueAfter = sc.PasswordRotationRequeueAfter(ctx, dc.Client, ddc.Namespace, ddc.Name, ddc.Spec.PasswordRotation.Interval)
+	}
+	// requeue for refreshing the nodes in status.
+	if ddc.Status.Nodes != nil && !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > sc.NodesRefreshInterval) {
+		res.RequeueAfter = sc.NodesRefreshInterval
+	}
+	// requeue for probing the queries at the interval.
+	if interval := sc.DisaggregatedQueryProbeRequeueAfter(ddc.Spec.QueryProbe); interval > 0 && !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > interval) {
+		res.RequeueAfter = interval
+	}
 	return res, nil
 }
 
+// migrateAdminUser store the plaintext adminUser in a basic-auth secret, set it as authSecret and strip adminUser from spec.
+// when authSecret already configured, adminUser is not used and only stripped from spec.
+func (dc *DisaggregatedClusterReconciler) migrateAdminUser(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) (ctrl.Result, error) {
+	if ddc.Spec.AuthSecret == "" {
+		secretName, err := migrateAdminUserToSecret(ctx, dc.Client, ddc, map[string]string{dv1.DorisDisaggregatedClusterName: ddc.Name}, ddc.Spec.AdminUser.Name, ddc.Spec.AdminUser.Password)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedClusterReconciler migrateAdminUser failed")
+			dc.Recorder.Event(ddc, string(sc.EventWarning), string(sc.AdminUserMigrateFailed), "migrate adminUser to secret failed, "+err.Error())
+			return ctrl.Result{}, err
+		}
+		ddc.Spec.AuthSecret = secretName
+	}
+
+	ddc.Spec.AdminUser = nil
+	if err := dc.Update(ctx, ddc); err != nil {
+		klog.FromContext(ctx).Error(err, "DisaggregatedClusterReconciler migrateAdminUser update DorisDisaggregatedCluster failed")
+		return ctrl.Result{}, err
+	}
+	dc.Recorder.Event(ddc, string(sc.EventNormal), string(sc.AdminUserMigrated), "the plaintext adminUser is removed from spec, the management user is read from authSecret "+ddc.Spec.AuthSecret)
+	return ctrl.Result{}, nil
+}
+
 func (dc *DisaggregatedClusterReconciler) clearUnusedResources(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) (ctrl.Result, error) {
 	for _, subC := range dc.Scs {
 		subC.ClearResources(ctx, ddc)
@@ -259,11 +411,14 @@ func (dc *DisaggregatedClusterReconciler) clearUnusedResources(ctx context.Conte
 	return ctrl.Result{}, nil
 }
 
-func (dc *DisaggregatedClusterReconciler) reorganizeStatus(ddc *dv1.DorisDisaggregatedCluster) (ctrl.Result, error) {
+func (dc *DisaggregatedClusterReconciler) reorganizeStatus(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) (ctrl.Result, error) {
 	for _, sc := range dc.Scs {
 		//update component status.
-		if err := sc.UpdateComponentStatus(ddc); err != nil {
-			klog.Errorf("DorisClusterReconciler reconcile update component %s status failed.err=%s\n", sc.GetControllerName(), err.Error())
+		_, span := tracing.Start(ctx, sc.GetControllerName()+".UpdateComponentStatus", tracing.ControllerKey.String(sc.GetControllerName()))
+		err := sc.UpdateComponentStatus(ddc)
+		tracing.End(span, err)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "disaggreatedClusterReconciler update the status of component failed", logging.ComponentKey, sc.GetControllerName())
 			// if failed, the cluster status is not green, in follow step will return requeue after 5 second. so, return error is not need.
 			//return requeueIfError(err)
 		}
@@ -277,6 +432,9 @@ func (dc *DisaggregatedClusterReconciler) reorganizeStatus(ddc *dv1.DorisDisaggr
 		ddc.Status.ClusterHealth.Health = dv1.Yellow
 	}
 
+	sc.SetDisaggregatedClusterConditions(ddc)
+	metrics.RecordDisaggregatedClusterStatus(ddc)
+
 	//if have any component not ready, should reconcile.
 	if ddc.Status.MetaServiceStatus.Phase != dv1.Ready || ddc.Status.FEStatus.Phase != dv1.Ready || ddc.Status.ClusterHealth.CGAvailableCount != ddc.Status.ClusterHealth.CGCount {
 		return ctrl.Result{Requeue: true}, nil
@@ -289,8 +447,13 @@ func (dc *DisaggregatedClusterReconciler) reconcileSub(ctx context.Context, ddc
 	// recall all sub for check error.
 	errs := []error{}
 	for _, subC := range dc.Scs {
-		if err := subC.Sync(ctx, ddc); err != nil {
-			klog.Errorf("disaggreatedClusterReconciler sub reconciler %s sync err=%s.", subC.GetControllerName(), err.Error())
+		sctx, span := tracing.Start(ctx, subC.GetControllerName()+".Sync", tracing.ControllerKey.String(subC.GetControllerName()))
+		sctx, slogger := logging.IntoContext(sctx, logging.ComponentKey, subC.GetControllerName())
+		err := subC.Sync(sctx, ddc)
+		tracing.End(span, err)
+		if err != nil {
+			slogger.Error(err, "disaggreatedClusterReconciler sync the sub resources failed")
+			metrics.IncReconcileErrors(metrics.DorisDisaggregatedClusterKind, subC.GetControllerName())
 			errs = append(errs, err)
 		}
 	}
@@ -319,7 +482,7 @@ func (dc *DisaggregatedClusterReconciler) updateObjectORStatus(ctx context.Conte
 			}
 		}
 		if err := dc.Update(ctx, ddc); err != nil {
-			klog.Errorf("disaggreatedClusterReconciler update DorisDisaggregatedCluster namespace %s name %s  failed, err=%s", ddc.Namespace, ddc.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggreatedClusterReconciler update DorisDisaggregatedCluster failed")
 			//return ctrl.Result{}, err
 		}
 	}
@@ -390,7 +553,7 @@ func (dc *DisaggregatedClusterReconciler) updateDorisDisaggregatedClusterStatus(
 		ddc.Status.DeepCopyInto(&eddc.Status)
 		return dc.Status().Update(ctx, &eddc)
 	}); err != nil {
-		klog.Errorf("updateDorisDisaggregatedClusterStatus update status failed err: %s", err.Error())
+		klog.FromContext(ctx).Error(err, "updateDorisDisaggregatedClusterStatus update status failed")
 	}
 
 	// if the status is not equal before reconcile and now the status is not available we should requeue.
@@ -19,11 +19,16 @@ package controller
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
 	sc "github.com/apache/doris-operator/pkg/controller/sub_controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type fakeDisaggregatedSubController struct {
@@ -87,7 +92,7 @@ func TestReorganizeStatusConsidersMetaServiceHealth(t *testing.T) {
 				},
 			}
 
-			_, err := reconciler.reorganizeStatus(ddc)
+			_, err := reconciler.reorganizeStatus(context.Background(), ddc)
 			if err != nil {
 				t.Fatalf("reorganizeStatus returned error: %v", err)
 			}
@@ -97,3 +102,73 @@ func TestReorganizeStatusConsidersMetaServiceHealth(t *testing.T) {
 		})
 	}
 }
+
+func TestReferConfigMapNames(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{}
+	ddc.Spec.MetaService.ConfigMaps = []dv1.ConfigMap{{Name: "ms-conf"}}
+	ddc.Spec.FeSpec.ConfigMaps = []dv1.ConfigMap{{Name: "fe-conf"}}
+	ddc.Spec.ComputeGroups = []dv1.ComputeGroup{{UniqueId: "cg1", CommonSpec: dv1.CommonSpec{ConfigMaps: []dv1.ConfigMap{{Name: "be-conf"}}}}}
+	if names := referConfigMapNames(ddc); len(names) != 0 {
+		t.Errorf("expected no configmap indexed when restart not enabled, got %v", names)
+	}
+
+	enabled := true
+	ddc.Spec.EnableRestartWhenConfigChange = &enabled
+	names := referConfigMapNames(ddc)
+	if !reflect.DeepEqual(names, []string{"ms-conf", "fe-conf", "be-conf"}) {
+		t.Errorf("unexpected configmaps referred by cluster, got %v", names)
+	}
+}
+
+func TestClustersReferConfigMap(t *testing.T) {
+	enabled := true
+	refer := &dv1.DorisDisaggregatedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "refer"}}
+	refer.Spec.EnableRestartWhenConfigChange = &enabled
+	refer.Spec.FeSpec.ConfigMaps = []dv1.ConfigMap{{Name: "fe-conf"}}
+	other := &dv1.DorisDisaggregatedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}}
+	other.Spec.EnableRestartWhenConfigChange = &enabled
+	other.Spec.FeSpec.ConfigMaps = []dv1.ConfigMap{{Name: "other-conf"}}
+
+	scheme := runtime.NewScheme()
+	_ = dv1.AddToScheme(scheme)
+	dc := &DisaggregatedClusterReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(refer, other).
+		WithIndex(&dv1.DorisDisaggregatedCluster{}, configMapIndexField, func(o client.Object) []string {
+			return referConfigMapNames(o.(*dv1.DorisDisaggregatedCluster))
+		}).Build()}
+
+	reqs := dc.clustersReferConfigMap(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fe-conf"}})
+	if len(reqs) != 1 || reqs[0].Name != "refer" {
+		t.Errorf("expected only the cluster refer the configmap enqueued, got %v", reqs)
+	}
+	if reqs := dc.clustersReferConfigMap(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unused"}}); len(reqs) != 0 {
+		t.Errorf("expected no cluster enqueued for the configmap not referred, got %v", reqs)
+	}
+}
+
+func TestClustersReferSecret(t *testing.T) {
+	rotate := &dv1.DorisDisaggregatedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rotate"}}
+	rotate.Spec.AuthSecret = "auth"
+	rotate.Spec.PasswordRotation = &dv1.PasswordRotation{}
+	norotate := &dv1.DorisDisaggregatedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "norotate"}}
+	norotate.Spec.AuthSecret = "auth"
+
+	scheme := runtime.NewScheme()
+	_ = dv1.AddToScheme(scheme)
+	dc := &DisaggregatedClusterReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(rotate, norotate).
+		WithIndex(&dv1.DorisDisaggregatedCluster{}, authSecretIndexField, func(o client.Object) []string {
+			ddc := o.(*dv1.DorisDisaggregatedCluster)
+			return rotatedAuthSecret(ddc.Spec.AuthSecret, ddc.Spec.PasswordRotation != nil)
+		}).Build()}
+
+	reqs := dc.clustersReferSecret(context.Background(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "auth"}})
+	if len(reqs) != 1 || reqs[0].Name != "rotate" {
+		t.Errorf("expected only the cluster rotate the password in the secret enqueued, got %v", reqs)
+	}
+	state := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rotate-auth-rotation", Labels: map[string]string{dv1.DorisDisaggregatedClusterName: "rotate"}}}
+	if reqs := dc.clustersReferSecret(context.Background(), state); len(reqs) != 1 || reqs[0].Name != "rotate" {
+		t.Errorf("expected the cluster labeled on the secret enqueued, got %v", reqs)
+	}
+	if reqs := dc.clustersReferSecret(context.Background(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unused"}}); len(reqs) != 0 {
+		t.Errorf("expected no cluster enqueued for the secret not referred, got %v", reqs)
+	}
+}
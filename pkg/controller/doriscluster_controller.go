@@ -36,6 +36,9 @@ package controller
 import (
 	"context"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/logging"
+	"github.com/apache/doris-operator/pkg/common/metrics"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
 	"github.com/apache/doris-operator/pkg/controller/sub_controller"
@@ -92,18 +95,23 @@ var (
 //+kubebuilder:rbac:groups=doris.selectdb.com,resources=dorisclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=doris.selectdb.com,resources=dorisclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get
 //+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="core",resources=endpoints,verbs=get;watch;list
-//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;update;watch
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=admissionregistration,resources=validatingwebhookconfigurations,verbs=get;list;update;watch
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;update;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -115,16 +123,26 @@ var (
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
 func (r *DorisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	klog.FromContext(ctx)
-	klog.Info("DorisClusterReconciler reconcile the update crd name ", req.Name, " namespace ", req.Namespace)
+	ctx, span := tracing.Start(ctx, "DorisCluster.Reconcile", tracing.ClusterAttributes(metrics.DorisClusterKind, req.Namespace, req.Name)...)
+	ctx, _ = logging.IntoContext(ctx, logging.ClusterKey, req.Name)
+	res, err := r.reconcileCluster(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// reconcileCluster reconcile the DorisCluster in the span of Reconcile.
+func (r *DorisClusterReconciler) reconcileCluster(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := klog.FromContext(ctx)
+	logger.Info("DorisClusterReconciler reconcile the DorisCluster")
 	var edcr dorisv1.DorisCluster
 	err := r.Client.Get(ctx, req.NamespacedName, &edcr)
 	if apierrors.IsNotFound(err) {
+		metrics.DeleteCluster(metrics.DorisClusterKind, req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
 
 	if err != nil && !apierrors.IsNotFound(err) {
-		klog.Error(err, " the req kind is not exists ", req.NamespacedName, " name ", req.Name)
+		logger.Error(err, "DorisClusterReconciler get the DorisCluster failed")
 		return requeueIfError(err)
 	}
 
@@ -135,6 +153,11 @@ func (r *DorisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// move the deprecated plaintext adminUser into authSecret, the cluster reconciled again when the spec updated.
+	if edcr.Spec.AdminUser != nil {
+		return r.migrateAdminUser(ctx, &edcr)
+	}
+
 	if dcr.Spec.EnableRestartWhenConfigChange {
 		coreConfigMaps := resource.GetDorisCoreConfigMapNames(dcr)
 		for componentType := range coreConfigMaps {
@@ -146,8 +169,13 @@ func (r *DorisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	//subControllers reconcile for create or update sub resource.
 	for _, rc := range r.Scs {
-		if err := rc.Sync(ctx, dcr); err != nil {
-			klog.Error("DorisClusterReconciler reconcile ", " sub resource reconcile failed ", "namespace: ", dcr.Namespace, " name: ", dcr.Name, " controller: ", rc.GetControllerName(), " error: ", err)
+		sctx, span := tracing.Start(ctx, rc.GetControllerName()+".Sync", tracing.ControllerKey.String(rc.GetControllerName()))
+		sctx, slogger := logging.IntoContext(sctx, logging.ComponentKey, rc.GetControllerName())
+		err := rc.Sync(sctx, dcr)
+		tracing.End(span, err)
+		if err != nil {
+			slogger.Error(err, "DorisClusterReconciler sync the sub resources failed")
+			metrics.IncReconcileErrors(metrics.DorisClusterKind, rc.GetControllerName())
 			return requeueIfError(err)
 		}
 	}
@@ -156,20 +184,61 @@ func (r *DorisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	r.clearNoEffectResources(ctx, dcr)
 	for _, rc := range r.Scs {
 		//update component status.
-
-		if err := rc.UpdateComponentStatus(dcr); err != nil {
-			klog.Errorf("DorisClusterReconciler reconcile update component %s status failed.err=%s\n", rc.GetControllerName(), err.Error())
+		_, span := tracing.Start(ctx, rc.GetControllerName()+".UpdateComponentStatus", tracing.ControllerKey.String(rc.GetControllerName()))
+		err := rc.UpdateComponentStatus(dcr)
+		tracing.End(span, err)
+		if err != nil {
+			logger.Error(err, "DorisClusterReconciler update the status of component failed", logging.ComponentKey, rc.GetControllerName())
 			return requeueIfError(err)
 		}
 	}
 
+	hc := &sub_controller.SubDefaultController{K8sclient: r.Client, K8srecorder: r.Recorder}
+	hc.UpdateClusterHealth(ctx, dcr)
+	hc.ProbeQueries(ctx, dcr)
+
 	//if dcr has updated by doris operator, should update it in apiserver. if not ignore it.
 	if err = r.revertDorisClusterSomeFields(ctx, &edcr, dcr); err != nil {
-		klog.Errorf("DorisClusterReconciler updateDorisClusterToOld update dorisCluster namespace=%s, name=%s failed, err=%s", dcr.Namespace, dcr.Name, err.Error())
+		logger.Error(err, "DorisClusterReconciler revert the fields of DorisCluster failed")
 		return requeueIfError(err)
 	}
 
-	return r.updateDorisClusterStatus(ctx, dcr)
+	res, err := r.updateDorisClusterStatus(ctx, dcr)
+	// requeue for the next scheduled rotation of the password of management user.
+	if err == nil && res.IsZero() && dcr.Spec.PasswordRotation != nil {
+		res.RequeueAfter = sub_controller.PasswordRotationRequeueAfter(ctx, r.Client, dcr.Namespace, dcr.Name, dcr.Spec.PasswordRotation.Interval)
+	}
+	// requeue for refreshing the nodes in status.
+	if err == nil && dcr.Status.Nodes != nil && !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > sub_controller.NodesRefreshInterval) {
+		res.RequeueAfter = sub_controller.NodesRefreshInterval
+	}
+	// requeue for probing the queries at the interval.
+	if interval := sub_controller.QueryProbeRequeueAfter(dcr.Spec.QueryProbe); err == nil && interval > 0 && !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > interval) {
+		res.RequeueAfter = interval
+	}
+	return res, err
+}
+
+// migrateAdminUser store the plaintext adminUser in a basic-auth secret, set it as authSecret and strip adminUser from spec.
+// when authSecret already configured, adminUser is not used and only stripped from spec.
+func (r *DorisClusterReconciler) migrateAdminUser(ctx context.Context, dcr *dorisv1.DorisCluster) (ctrl.Result, error) {
+	if dcr.Spec.AuthSecret == "" {
+		secretName, err := migrateAdminUserToSecret(ctx, r.Client, dcr, map[string]string{dorisv1.DorisClusterLabelKey: dcr.Name}, dcr.Spec.AdminUser.Name, dcr.Spec.AdminUser.Password)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "DorisClusterReconciler migrateAdminUser failed")
+			r.Recorder.Event(dcr, string(sub_controller.EventWarning), string(sub_controller.AdminUserMigrateFailed), "migrate adminUser to secret failed, "+err.Error())
+			return requeueIfError(err)
+		}
+		dcr.Spec.AuthSecret = secretName
+	}
+
+	dcr.Spec.AdminUser = nil
+	if err := r.Update(ctx, dcr); err != nil {
+		klog.FromContext(ctx).Error(err, "DorisClusterReconciler migrateAdminUser update DorisCluster failed")
+		return requeueIfError(err)
+	}
+	r.Recorder.Event(dcr, string(sub_controller.EventNormal), string(sub_controller.AdminUserMigrated), "the plaintext adminUser is removed from spec, the management user is read from authSecret "+dcr.Spec.AuthSecret)
+	return ctrl.Result{}, nil
 }
 
 // if cluster spec be reverted, doris operator should revert to old.
@@ -202,6 +271,8 @@ func (r *DorisClusterReconciler) updateDorisClusterStatus(ctx context.Context, d
 		return ctrl.Result{}, err
 	}
 
+	metrics.RecordDorisClusterStatus(dcr)
+
 	// if the status is not equal before reconcile and now the status is not available we should requeue.
 	if !inconsistentStatus(&dcr.Status, &edcr) {
 		if r.reconcile(dcr) {
@@ -347,29 +418,84 @@ func (r *DorisClusterReconciler) watchConfigMapBuilder(builder *ctrl.Builder) *c
 		mapFn, controller_builder.WithPredicates(p))
 }
 
+// watchSecretBuilder watch the tls secrets and the AuthSecret of cluster. the secrets issued by cert-manager and the state secret of password rotation are not owned by cluster but have the label of cluster.
+// reconcile the cluster when the secrets issued or rotated for applying the statefulsets and restarting pods, or the password in AuthSecret changed for rotating the password.
+// the clusters are looked up by the label or the index of AuthSecret, the events of other secrets are filtered out.
+func (r *DorisClusterReconciler) watchSecretBuilder(builder *ctrl.Builder) *ctrl.Builder {
+	mapFn := handler.EnqueueRequestsFromMapFunc(r.clustersReferSecret)
+
+	p := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return len(r.clustersReferSecret(context.Background(), e.Object)) != 0
+		},
+		UpdateFunc: func(u event.UpdateEvent) bool {
+			return u.ObjectOld.GetResourceVersion() != u.ObjectNew.GetResourceVersion() &&
+				len(r.clustersReferSecret(context.Background(), u.ObjectNew)) != 0
+		},
+		DeleteFunc: func(d event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(g event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return builder.Watches(&corev1.Secret{},
+		mapFn, controller_builder.WithPredicates(p))
+}
+
+// clustersReferSecret returns the request of cluster that labeled on the secret, or the requests of clusters that rotate the password in the secret.
+func (r *DorisClusterReconciler) clustersReferSecret(ctx context.Context, a client.Object) []reconcile.Request {
+	if dorisName := a.GetLabels()[dorisv1.DorisClusterLabelKey]; dorisName != "" {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Name:      dorisName,
+			Namespace: a.GetNamespace(),
+		}}}
+	}
+
+	var dcrs dorisv1.DorisClusterList
+	if err := r.Client.List(ctx, &dcrs, client.InNamespace(a.GetNamespace()), client.MatchingFields{authSecretIndexField: a.GetName()}); err != nil {
+		klog.Errorf("DorisClusterReconciler clustersReferSecret list dorisclusters in namespace=%s failed, err=%s", a.GetNamespace(), err.Error())
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range dcrs.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: dcrs.Items[i].Name, Namespace: dcrs.Items[i].Namespace}})
+	}
+	return reqs
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DorisClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dorisv1.DorisCluster{}, authSecretIndexField, func(o client.Object) []string {
+		dcr := o.(*dorisv1.DorisCluster)
+		return rotatedAuthSecret(dcr.Spec.AuthSecret, dcr.Spec.PasswordRotation != nil)
+	}); err != nil {
+		return err
+	}
+
 	builder := r.resourceBuilder(ctrl.NewControllerManagedBy(mgr))
 	builder = r.watchPodBuilder(builder)
 	builder = r.watchConfigMapBuilder(builder)
+	builder = r.watchSecretBuilder(builder)
 	return builder.Complete(r)
 }
 
 // Init initial the DorisClusterReconciler for reconcile.
 func (r *DorisClusterReconciler) Init(mgr ctrl.Manager, options *Options) {
 	subcs := make(map[string]sub_controller.SubController)
-	fc := fe.New(mgr.GetClient(), mgr.GetEventRecorderFor(feControllerName))
+	fc := fe.New(mgr.GetClient(), sub_controller.NewDedupRecorder(mgr.GetEventRecorderFor(feControllerName)))
 	subcs[feControllerName] = fc
-	be := be.New(mgr.GetClient(), mgr.GetEventRecorderFor(beControllerName))
+	be := be.New(mgr.GetClient(), sub_controller.NewDedupRecorder(mgr.GetEventRecorderFor(beControllerName)))
 	subcs[beControllerName] = be
-	cn := cn.New(mgr.GetClient(), mgr.GetEventRecorderFor(cnControllerName))
+	cn := cn.New(mgr.GetClient(), sub_controller.NewDedupRecorder(mgr.GetEventRecorderFor(cnControllerName)))
 	subcs[cnControllerName] = cn
-	brk := bk.New(mgr.GetClient(), mgr.GetEventRecorderFor(brokerControllerName))
+	brk := bk.New(mgr.GetClient(), sub_controller.NewDedupRecorder(mgr.GetEventRecorderFor(brokerControllerName)))
 	subcs[brokerControllerName] = brk
 
 	if err := (&DorisClusterReconciler{
 		Client:          mgr.GetClient(),
-		Recorder:        mgr.GetEventRecorderFor(name),
+		Recorder:        sub_controller.NewDedupRecorder(mgr.GetEventRecorderFor(name)),
 		Scs:             subcs,
 		WatchConfigMaps: make(map[string]string),
 	}).SetupWithManager(mgr); err != nil {
@@ -378,6 +504,11 @@ func (r *DorisClusterReconciler) Init(mgr ctrl.Manager, options *Options) {
 	}
 	klog.Infof("dorisclusterreconcile %t", options.EnableWebHook)
 	if options.EnableWebHook {
+		dorisv1.RejectPlaintextAdminPassword = options.RejectPlaintextAdminPassword
+		cv := &sub_controller.ConfigMapValidator{K8sclient: mgr.GetClient()}
+		dorisv1.ConfigValidator = cv.ValidateDorisCluster
+		sdc := &sub_controller.SubDefaultController{K8sclient: mgr.GetClient()}
+		dorisv1.MaxReplicationNumGetter = sdc.GetMaxReplicationNum
 		if err := (&dorisv1.DorisCluster{}).SetupWebhookWithManager(mgr); err != nil {
 			klog.Error(err, " unable to create unnamedwatches ", " controller ", " DorisCluster ")
 			os.Exit(1)
@@ -28,4 +28,10 @@ type Options struct {
 	Namespace string
 	//the service for operator
 	WebhookService string
+	// the cert-manager issuer that issue the certificate of webhook server, the certificate is self-signed by operator when empty.
+	WebhookCertManagerIssuer string
+	// the kind of cert-manager issuer, `Issuer` or `ClusterIssuer`.
+	WebhookCertManagerIssuerKind string
+	// reject the new plaintext `adminUser.password` in validating webhook, `authSecret` should be used.
+	RejectPlaintextAdminPassword bool
 }
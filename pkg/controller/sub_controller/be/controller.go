@@ -19,6 +19,7 @@ package be
 
 import (
 	"context"
+	"errors"
 	"github.com/apache/doris-operator/api/doris/v1"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
@@ -59,13 +60,13 @@ func (be *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 	if dcr.Status.BEStatus != nil {
 		oldStatus = *(dcr.Status.BEStatus.DeepCopy())
 	}
-	be.InitStatus(dcr, v1.Component_BE)
-	if !be.FeAvailable(dcr) {
+	be.InitStatus(ctx, dcr, v1.Component_BE)
+	if !be.FeAvailable(ctx, dcr) {
 		return nil
 	}
 
 	if dcr.Spec.EnableRestartWhenConfigChange {
-		be.CompareConfigmapAndTriggerRestart(dcr, oldStatus, v1.Component_BE)
+		be.CompareConfigmapAndTriggerRestart(ctx, dcr, oldStatus, v1.Component_BE)
 	}
 
 	beSpec := dcr.Spec.BeSpec
@@ -73,35 +74,56 @@ func (be *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 	//2. get config for generate statefulset and service.
 	config, err := be.GetConfig(ctx, &beSpec.ConfigMapInfo, dcr.Namespace, v1.Component_BE)
 	if err != nil {
-		klog.Error("BeController Sync ", "resolve be configmap failed, namespace ", dcr.Namespace, " error :", err)
+		klog.FromContext(ctx).Error(err, "BeController Sync resolve be configmap failed", "namespace", dcr.Namespace)
 		return err
 	}
+	if dcr.IsTLSEnabled() {
+		config = resource.InjectTLSConfig(config)
+	}
 
-	be.CheckConfigMountPath(dcr, v1.Component_BE)
-	be.CheckSecretMountPath(dcr, v1.Component_BE)
+	be.CheckConfigMountPath(ctx, dcr, v1.Component_BE)
+	be.CheckSecretMountPath(ctx, dcr, v1.Component_BE)
 	be.CheckSecretExist(ctx, dcr, v1.Component_BE)
 	//generate new be service.
 	svc := resource.BuildExternalService(dcr, v1.Component_BE, config)
 	//create or update be external and domain search service, update the status of fe on src.
 	internalService := resource.BuildInternalService(dcr, v1.Component_BE, config)
 	if err := k8s.ApplyService(ctx, be.K8sclient, &internalService, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("be controller sync apply internalService name=%s, namespace=%s, clusterName=%s failed.message=%s.",
-			internalService.Name, internalService.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "be controller sync apply internalService failed", "name", internalService.Name, "namespace", internalService.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 	if err := k8s.ApplyService(ctx, be.K8sclient, &svc, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("be controller sync apply external service name=%s, namespace=%s, clusterName=%s failed. message=%s.",
-			svc.Name, svc.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "be controller sync apply external service failed", "name", svc.Name, "namespace", svc.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 
-	if err = be.prepareStatefulsetApply(dcr, oldStatus); err != nil {
+	be.ApplyMonitor(ctx, dcr, v1.Component_BE)
+
+	if err = be.prepareStatefulsetApply(ctx, dcr, oldStatus); err != nil {
+		return err
+	}
+
+	// be decommission processing, skip apply statefulset.
+	if dcr.Status.BEStatus.ComponentCondition.Phase == v1.Decommissioning {
+		return nil
+	}
+
+	if err = be.ApplyRenderedConfigMap(ctx, dcr, v1.Component_BE); err != nil {
 		return err
 	}
 
 	st := be.buildBEStatefulSet(dcr, config)
+	if dcr.IsTLSEnabled() {
+		certHash, err := be.ApplyTLSCertificates(ctx, dcr, v1.Component_BE)
+		if errors.Is(err, sub_controller.ErrTLSCertificateNotReady) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resource.SetTLSCertificateHash(&st.Spec.Template, certHash)
+	}
 	if !be.PrepareReconcileResources(ctx, dcr, v1.Component_BE) {
-		klog.Infof("be controller sync preparing resource for reconciling namespace %s name %s!", dcr.Namespace, dcr.Name)
+		klog.FromContext(ctx).Info("be controller sync preparing resource for reconciling", "namespace", dcr.Namespace, "name", dcr.Name)
 		return nil
 	}
 
@@ -115,8 +137,7 @@ func (be *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 		be.RestrictConditionsEqual(new, est)
 		return resource.StatefulSetDeepEqual(new, est, false)
 	}, ndf); err != nil {
-		klog.Errorf("fe controller sync statefulset name=%s, namespace=%s, clusterName=%s failed. message=%s.",
-			st.Name, st.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "fe controller sync statefulset failed", "name", st.Name, "namespace", st.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 
@@ -142,6 +163,11 @@ func (be *Controller) ClearResources(ctx context.Context, dcr *v1.DorisCluster)
 		return true, nil
 	}
 
+	if err := be.RecycleResources(ctx, dcr, v1.Component_BE); err != nil {
+		klog.FromContext(ctx).Info("be ClearResources recycle pvc resource for reconciling", "namespace", dcr.Namespace, "name", dcr.Name)
+		return false, err
+	}
+
 	if dcr.Spec.BeSpec == nil {
 		return be.ClearCommonResources(ctx, dcr, v1.Component_BE)
 	}
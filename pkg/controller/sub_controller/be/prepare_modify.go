@@ -18,17 +18,42 @@
 package be
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	v1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	sc "github.com/apache/doris-operator/pkg/controller/sub_controller"
+	appv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 )
 
 // prepareStatefulsetApply means Pre-operation and status control on the client side
-func (be *Controller) prepareStatefulsetApply(dcr *v1.DorisCluster, oldStatus v1.ComponentStatus) error {
+func (be *Controller) prepareStatefulsetApply(ctx context.Context, dcr *v1.DorisCluster, oldStatus v1.ComponentStatus) error {
+	var oldSt appv1.StatefulSet
+	err := be.K8sclient.Get(ctx, types.NamespacedName{Namespace: dcr.Namespace, Name: v1.GenerateComponentStatefulSetName(dcr, v1.Component_BE)}, &oldSt)
+	if err == nil && dcr.Spec.BeSpec.Replicas != nil {
+		// the decommission in progress is canceled when replicas reverted or cancel annotation is set.
+		_, cancel := dcr.Annotations[v1.BECancelDecommission]
+		if oldStatus.ComponentCondition.Phase == v1.Decommissioning && (cancel || *dcr.Spec.BeSpec.Replicas >= *oldSt.Spec.Replicas) {
+			return be.cancelDecommission(ctx, dcr, &oldSt)
+		}
+		if dcr.Spec.BeSpec.EnableDecommission && *dcr.Spec.BeSpec.Replicas < *oldSt.Spec.Replicas {
+			return be.decommissionScaleDown(ctx, dcr, &oldSt, oldStatus)
+		}
+	}
 
 	// be rolling restart
 	// check 1: be Phase is Available
 	// check 2: be RestartTime is not empty and useful
 	// check 3: be RestartTime different from old(This condition does not need to be checked here. If it is allowed to pass, it will be processed idempotent when applying sts.)
-	if oldStatus.ComponentCondition.Phase == v1.Available && be.CheckRestartTimeAndInject(dcr, v1.Component_BE) {
+	if oldStatus.ComponentCondition.Phase == v1.Available && be.CheckRestartTimeAndInject(ctx, dcr, v1.Component_BE) {
 		dcr.Status.BEStatus.ComponentCondition.Phase = v1.Restarting
 	}
 
@@ -36,3 +61,164 @@ func (be *Controller) prepareStatefulsetApply(dcr *v1.DorisCluster, oldStatus v1
 
 	return nil
 }
+
+// decommissionScaleDown decommission the be nodes that will be removed by scale down. the statefulset will not be shrunk until the tablets on these nodes migrated.
+func (be *Controller) decommissionScaleDown(ctx context.Context, dcr *v1.DorisCluster, oldSt *appv1.StatefulSet, oldStatus v1.ComponentStatus) error {
+	masterDBClient, err := be.GetMasterSqlClient(ctx, dcr)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "beController decommissionScaleDown GetMasterSqlClient failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return err
+	}
+	defer masterDBClient.Close()
+
+	keepAmount := *dcr.Spec.BeSpec.Replicas
+	allBackends, decommissionBackends, err := be.getScaledDownBackends(ctx, masterDBClient, dcr, *oldSt.Spec.Replicas, keepAmount)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "beController decommissionScaleDown getScaledDownBackends failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return err
+	}
+
+	dts := resource.ConstructDecommissionTaskStatus(allBackends, keepAmount)
+	switch dts.GetDecommissionPhase() {
+	case resource.DecommissionAcceptable:
+		if err = masterDBClient.DecommissionBE(decommissionBackends); err != nil {
+			klog.FromContext(ctx).Error(err, "beController decommissionScaleDown DecommissionBE failed", "namespace", dcr.Namespace, "name", dcr.Name)
+			be.K8srecorder.Event(dcr, string(sc.EventWarning), sc.BEDecommissionFailed, "decommission be failed, "+err.Error())
+			return err
+		}
+		be.K8srecorder.Event(dcr, string(sc.EventNormal), string(sc.DecommissionStarted), fmt.Sprintf("decommission %d be nodes for scaling down to %d replicas.", len(decommissionBackends), keepAmount))
+		dcr.Status.BEStatus.ComponentCondition.Phase = v1.Decommissioning
+		be.recordDecommissionProgress(ctx, dcr, nil, decommissionBackends)
+	case resource.Decommissioning:
+		klog.FromContext(ctx).Info("beController decommissionScaleDown decommission in progress", "namespace", dcr.Namespace, "name", dcr.Name)
+		dcr.Status.BEStatus.ComponentCondition.Phase = v1.Decommissioning
+		be.recordDecommissionProgress(ctx, dcr, oldStatus.DecommissionStatus, decommissionBackends)
+	case resource.DecommissionPhaseUnknown:
+		// the phase is queried again in next reconcile, the backends dropped by hand or missing in SHOW BACKENDS never resolve it,
+		// so the statefulset is applied when the tablets not migrated for DecommissionStalledTimeout.
+		dp := be.recordDecommissionProgress(ctx, dcr, oldStatus.DecommissionStatus, decommissionBackends)
+		if !dp.NoProgressTimeout(time.Now()) {
+			klog.FromContext(ctx).Info("beController decommissionScaleDown decommission phase unknown, wait for resolving", "namespace", dcr.Namespace, "name", dcr.Name)
+			dcr.Status.BEStatus.ComponentCondition.Phase = v1.Decommissioning
+			return nil
+		}
+		klog.FromContext(ctx).Info("beController decommissionScaleDown decommission phase not resolved, apply the statefulset", "namespace", dcr.Namespace, "name", dcr.Name)
+		be.K8srecorder.Event(dcr, string(sc.EventWarning), string(sc.DecommissionUnresolved), fmt.Sprintf("the decommission phase of be nodes can not be resolved and no tablets migrated since %s, scale down to %d replicas without waiting.", dp.LastProgressTime.Format(time.RFC3339), keepAmount))
+		dcr.Status.BEStatus.DecommissionStatus = nil
+		dcr.Status.BEStatus.ComponentCondition.Phase = v1.Scaling
+	case resource.Decommissioned:
+		if err = masterDBClient.DropBE(decommissionBackends); err != nil {
+			klog.FromContext(ctx).Error(err, "beController decommissionScaleDown DropBE failed", "namespace", dcr.Namespace, "name", dcr.Name)
+			be.K8srecorder.Event(dcr, string(sc.EventWarning), sc.BEDecommissionFailed, "drop decommissioned be failed, "+err.Error())
+			return err
+		}
+		be.K8srecorder.Event(dcr, string(sc.EventNormal), string(sc.DecommissionFinished), fmt.Sprintf("decommission of %d be nodes finished, the nodes dropped.", len(decommissionBackends)))
+		dcr.Status.BEStatus.ComponentCondition.Phase = v1.Scaling
+	}
+
+	return nil
+}
+
+// cancelDecommission cancel the decommission of be nodes in progress. the be pods are kept, so the replicas is reverted to the statefulset's when it is less.
+func (be *Controller) cancelDecommission(ctx context.Context, dcr *v1.DorisCluster, oldSt *appv1.StatefulSet) error {
+	masterDBClient, err := be.GetMasterSqlClient(ctx, dcr)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "beController cancelDecommission GetMasterSqlClient failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return err
+	}
+	defer masterDBClient.Close()
+
+	allBackends, _, err := be.getScaledDownBackends(ctx, masterDBClient, dcr, *oldSt.Spec.Replicas, *oldSt.Spec.Replicas)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "beController cancelDecommission getScaledDownBackends failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return err
+	}
+	var decommissionBackends []*mysql.Backend
+	for _, backend := range allBackends {
+		if backend.SystemDecommissioned {
+			decommissionBackends = append(decommissionBackends, backend)
+		}
+	}
+	if err = masterDBClient.CancelDecommissionBE(decommissionBackends); err != nil {
+		klog.FromContext(ctx).Error(err, "beController cancelDecommission CancelDecommissionBE failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		be.K8srecorder.Event(dcr, string(sc.EventWarning), sc.BEDecommissionFailed, "cancel decommission be failed, "+err.Error())
+		return err
+	}
+
+	// revert replicas and remove the cancel annotation in one patch, the update of doris cluster after reconciling will conflict with it.
+	if *dcr.Spec.BeSpec.Replicas < *oldSt.Spec.Replicas {
+		*dcr.Spec.BeSpec.Replicas = *oldSt.Spec.Replicas
+	}
+	delete(dcr.Annotations, v1.BECancelDecommission)
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{v1.BECancelDecommission: nil},
+		},
+		"spec": map[string]interface{}{
+			"beSpec": map[string]interface{}{"replicas": *dcr.Spec.BeSpec.Replicas},
+		},
+	}
+	if err = k8s.MergePatchClientObject(ctx, be.K8sclient, dcr, patch); err != nil {
+		klog.FromContext(ctx).Error(err, "beController cancelDecommission patch doriscluster failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return err
+	}
+
+	be.K8srecorder.Event(dcr, string(sc.EventNormal), string(sc.DecommissionCanceled), fmt.Sprintf("the decommission of %d be nodes canceled, be replicas keep %d.", len(decommissionBackends), *dcr.Spec.BeSpec.Replicas))
+	dcr.Status.BEStatus.ComponentCondition.Phase = v1.Available
+	return nil
+}
+
+// recordDecommissionProgress update the decommission progress in status and return it, and send a warning event when the decommission stalled.
+func (be *Controller) recordDecommissionProgress(ctx context.Context, dcr *v1.DorisCluster, lastStatus *v1.DecommissionStatus, decommissionBackends []*mysql.Backend) *resource.DecommissionProgress {
+	last := resource.NewDecommissionProgressFromStatus(lastStatus)
+	dp := resource.CalculateDecommissionProgress(last, decommissionBackends, time.Now())
+	if dp.IsNewlyStalled(last) {
+		be.K8srecorder.Event(dcr, string(sc.EventWarning), string(sc.DecommissionStalled), fmt.Sprintf("the tablets number of decommissioning be nodes have not decreased since %s, remaining %d tablets.", dp.LastProgressTime.Format(time.RFC3339), dp.RemainingTabletNum))
+	}
+	dcr.Status.BEStatus.DecommissionStatus = dp.ToStatus()
+	return dp
+}
+
+// getScaledDownBackends returns the backends controlled by the be statefulset and the backends that will be removed by scale down.
+// the backends not match the pods of statefulset(eg: deleted pods when scaled down without decommission) are excluded.
+func (be *Controller) getScaledDownBackends(ctx context.Context, masterDBClient *mysql.DB, dcr *v1.DorisCluster, stsReplicas, keepAmount int32) ([]*mysql.Backend, []*mysql.Backend, error) {
+	backends, err := masterDBClient.ShowBackends()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	podTemplateName := resource.GeneratePodTemplateName(dcr, v1.Component_BE)
+	podMap := make(map[string]string) // key is pod ip, value is pod name
+	pods, err := k8s.GetPods(ctx, be.K8sclient, dcr.Namespace, v1.GetPodLabels(dcr, v1.Component_BE))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" {
+			podMap[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	var allBackends, decommissionBackends []*mysql.Backend
+	for _, backend := range backends {
+		podName, ok := podMap[backend.Host]
+		if !ok {
+			// use fqdn, like: doriscluster-sample-be-0.doriscluster-sample-be-internal.doris.svc.cluster.local
+			podName = strings.Split(backend.Host, ".")[0]
+		}
+		if !strings.HasPrefix(podName, podTemplateName+"-") {
+			continue
+		}
+		ordinal, err := strconv.Atoi(strings.TrimPrefix(podName, podTemplateName+"-"))
+		if err != nil || int32(ordinal) >= stsReplicas {
+			continue
+		}
+
+		allBackends = append(allBackends, backend)
+		if int32(ordinal) >= keepAmount {
+			decommissionBackends = append(decommissionBackends, backend)
+		}
+	}
+
+	return allBackends, decommissionBackends, nil
+}
@@ -17,8 +17,11 @@
 package be
 
 import (
+	"context"
 	"encoding/json"
 	v1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/controller/sub_controller"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 	"time"
 )
@@ -60,7 +63,11 @@ func Test_prepareStatefulsetApply(t *testing.T) {
         }
     }
 }`
-	bc := &Controller{}
+	bc := &Controller{
+		SubDefaultController: sub_controller.SubDefaultController{
+			K8sclient: fake.NewClientBuilder().Build(),
+		},
+	}
 	ntime := time.Now().Format(time.RFC3339)
 	dcr := &v1.DorisCluster{}
 	if err := json.Unmarshal([]byte(dcrJsonStr), dcr); err != nil {
@@ -74,5 +81,5 @@ func Test_prepareStatefulsetApply(t *testing.T) {
 			Phase: v1.Available,
 		},
 	}
-	bc.prepareStatefulsetApply(dcr, oldStatus)
+	bc.prepareStatefulsetApply(context.Background(), dcr, oldStatus)
 }
@@ -59,7 +59,7 @@ func (bk *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 		return nil
 	}
 
-	if !bk.FeAvailable(dcr) {
+	if !bk.FeAvailable(ctx, dcr) {
 		return nil
 	}
 	brokerSpec := dcr.Spec.BrokerSpec
@@ -68,16 +68,15 @@ func (bk *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 	//2. get config for generate statefulset and service.
 	config, err := bk.GetConfig(ctx, &brokerSpec.ConfigMapInfo, dcr.Namespace, v1.Component_Broker)
 	if err != nil {
-		klog.Error("BrokerController Sync ", "resolve broker configmap failed, namespace ", dcr.Namespace, " error ", err)
+		klog.FromContext(ctx).Error(err, "BrokerController Sync resolve broker configmap failed", "namespace", dcr.Namespace)
 		return err
 	}
-	bk.CheckConfigMountPath(dcr, v1.Component_Broker)
-	bk.CheckSecretMountPath(dcr, v1.Component_Broker)
+	bk.CheckConfigMountPath(ctx, dcr, v1.Component_Broker)
+	bk.CheckSecretMountPath(ctx, dcr, v1.Component_Broker)
 	bk.CheckSecretExist(ctx, dcr, v1.Component_Broker)
 	internalService := resource.BuildInternalService(dcr, v1.Component_Broker, config)
 	if err := k8s.ApplyService(ctx, bk.K8sclient, &internalService, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("broker controller sync apply internalService name=%s, namespace=%s, clusterName=%s failed.message=%s.",
-			internalService.Name, internalService.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "broker controller sync apply internalService failed", "name", internalService.Name, "namespace", internalService.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 
@@ -86,8 +85,7 @@ func (bk *Controller) Sync(ctx context.Context, dcr *v1.DorisCluster) error {
 		// if have restart annotation, we should exclude the interference for comparison.
 		return resource.StatefulSetDeepEqual(new, est, false)
 	}); err != nil {
-		klog.Errorf("broker controller sync statefulset name=%s, namespace=%s, clusterName=%s failed. message=%s.",
-			st.Name, st.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "broker controller sync statefulset failed", "name", st.Name, "namespace", st.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 
@@ -139,7 +137,7 @@ func (bk *Controller) getFeConfig(ctx context.Context, feconfigMapInfo *v1.Confi
 	}
 	feconfigMaps, err := k8s.GetConfigMaps(ctx, bk.K8sclient, namespace, cms)
 	if err != nil {
-		klog.Errorf("BrokerController getFeConfig fe config failed, namespace: %s,err: %s \n", namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "BrokerController getFeConfig fe config failed", "namespace", namespace)
 	}
 	res, resolveErr := resource.ResolveConfigMaps(feconfigMaps, v1.Component_FE)
 
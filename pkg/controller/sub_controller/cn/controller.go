@@ -19,6 +19,7 @@ package cn
 
 import (
 	"context"
+	"errors"
 	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
 	"github.com/apache/doris-operator/pkg/common/utils"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
@@ -57,13 +58,13 @@ func New(k8sclient client.Client, k8srecorder record.EventRecorder) *Controller
 func (cn *Controller) Sync(ctx context.Context, dcr *dorisv1.DorisCluster) error {
 	if dcr.Spec.CnSpec == nil {
 		if _, err := cn.ClearResources(ctx, dcr); err != nil {
-			klog.Errorf("cn controller sync clearResource  namespace=%s,srcName=%s, err=%s\n", dcr.Namespace, dcr.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "cn controller sync clearResource failed", "namespace", dcr.Namespace, "name", dcr.Name)
 			return err
 		}
 		return nil
 	}
 
-	if !cn.FeAvailable(dcr) {
+	if !cn.FeAvailable(ctx, dcr) {
 		return nil
 	}
 
@@ -71,35 +72,50 @@ func (cn *Controller) Sync(ctx context.Context, dcr *dorisv1.DorisCluster) error
 
 	config, err := cn.GetConfig(ctx, &cnSpec.ConfigMapInfo, dcr.Namespace)
 	if err != nil {
-		klog.Errorf("cn controller sync resolve cn configMap failed, namespace %s ，err : %s", dcr.Namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "cn controller sync resolve cn configMap failed", "namespace", dcr.Namespace)
 		return err
 	}
-	cn.CheckConfigMountPath(dcr, dorisv1.Component_CN)
-	cn.CheckSecretMountPath(dcr, dorisv1.Component_CN)
+	if dcr.IsTLSEnabled() {
+		config = resource.InjectTLSConfig(config)
+	}
+	cn.CheckConfigMountPath(ctx, dcr, dorisv1.Component_CN)
+	cn.CheckSecretMountPath(ctx, dcr, dorisv1.Component_CN)
 	cn.CheckSecretExist(ctx, dcr, dorisv1.Component_CN)
 	svc := resource.BuildExternalService(dcr, dorisv1.Component_CN, config)
 	internalSVC := resource.BuildInternalService(dcr, dorisv1.Component_CN, config)
 
 	if err := k8s.ApplyService(ctx, cn.K8sclient, &internalSVC, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("cn controller sync apply internalService name=%s, namespace=%s, clusterName=%s failed.message=%s.",
-			internalSVC.Name, internalSVC.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "cn controller sync apply internalService failed", "name", internalSVC.Name, "namespace", internalSVC.Namespace, "clusterName", dcr.Name)
 		return err
 	}
 
 	if err := k8s.ApplyService(ctx, cn.K8sclient, &svc, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("cn controller sync apply externalService name=%s, namespace=%s, clusterName=%s failed.message=%s.",
-			svc.Name, svc.Namespace, dcr.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "cn controller sync apply externalService failed", "name", svc.Name, "namespace", svc.Namespace, "clusterName", dcr.Name)
 		return err
 	}
+
+	cn.ApplyMonitor(ctx, dcr, dorisv1.Component_CN)
 	cnStatefulSet := cn.buildCnStatefulSet(dcr, config)
 	if !cn.PrepareReconcileResources(ctx, dcr, dorisv1.Component_CN) {
-		klog.Infof("cn controller sync preparing resource for reconciling namespace %s name %s!", dcr.Namespace, dcr.Name)
+		klog.FromContext(ctx).Info("cn controller sync preparing resource for reconciling", "namespace", dcr.Namespace, "name", dcr.Name)
 		return nil
 	}
 
+	if err = cn.ApplyRenderedConfigMap(ctx, dcr, dorisv1.Component_CN); err != nil {
+		return err
+	}
+	if dcr.IsTLSEnabled() {
+		certHash, err := cn.ApplyTLSCertificates(ctx, dcr, dorisv1.Component_CN)
+		if errors.Is(err, sub_controller.ErrTLSCertificateNotReady) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resource.SetTLSCertificateHash(&cnStatefulSet.Spec.Template, certHash)
+	}
+
 	if err = cn.applyStatefulSet(ctx, &cnStatefulSet, cnSpec.AutoScalingPolicy != nil); err != nil {
-		klog.Errorf("cn controller sync statefulset name=%s, namespace=%s,failed. message=%s.",
-			cnStatefulSet.Name, cnStatefulSet.Namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "cn controller sync statefulset failed", "name", cnStatefulSet.Name, "namespace", cnStatefulSet.Namespace)
 		return err
 	}
 
@@ -157,7 +173,7 @@ func (cn *Controller) applyStatefulSet(ctx context.Context, st *appv1.StatefulSe
 	if err := cn.K8sclient.Get(ctx, types.NamespacedName{Namespace: st.Namespace, Name: st.Name}, &est); apierrors.IsNotFound(err) {
 		return k8s.CreateClientObject(ctx, cn.K8sclient, st)
 	} else if err != nil {
-		klog.Errorf("CnController Sync create statefulset name=%s, namespace=%s error=%s", st.Name, st.Namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "CnController Sync create statefulset", "name", st.Name, "namespace", st.Namespace)
 		return err
 	}
 	//if the spec is changed, update the status of cn on src.
@@ -189,14 +205,14 @@ func (cn *Controller) deleteAutoScaler(ctx context.Context, dcr *dorisv1.DorisCl
 	}
 
 	if dcr.Status.CnStatus.HorizontalScaler.Name == "" {
-		klog.V(4).Infof("cnController not need delete the autoScaler, namespace=%s, src name=%s.", dcr.Namespace, dcr.Name)
+		klog.FromContext(ctx).V(4).Info("cnController not need delete the autoScaler", "namespace", dcr.Namespace, "name", dcr.Name)
 		return nil
 	}
 
 	autoScalerName := dcr.Status.CnStatus.HorizontalScaler.Name
 	version := dcr.Status.CnStatus.HorizontalScaler.Version
 	if err := k8s.DeleteAutoscaler(ctx, cn.K8sclient, dcr.Namespace, autoScalerName, version); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("cnController sync deploy or delete failed, namespace=%s, autosclaer name=%s, autoscaler version=%s", dcr.GetNamespace(), autoScalerName, version)
+		klog.FromContext(ctx).Error(nil, "cnController sync deploy or delete autoscaler failed", "namespace", dcr.GetNamespace(), "name", autoScalerName, "version", version)
 		return err
 	}
 
@@ -208,7 +224,7 @@ func (cn *Controller) deployAutoScaler(ctx context.Context, policy dorisv1.AutoS
 	params := cn.buildCnAutoscalerParams(policy, target, dcr)
 	autoScaler := resource.BuildHorizontalPodAutoscaler(params)
 	if err := k8s.CreateOrUpdateClientObject(ctx, cn.K8sclient, autoScaler); err != nil {
-		klog.Errorf("cnController deployAutoscaler failed, namespace=%s,name=%s,version=%s,error=%s", autoScaler.GetNamespace(), autoScaler.GetName(), policy.Version, err.Error())
+		klog.FromContext(ctx).Error(err, "cnController deployAutoscaler failed", "namespace", autoScaler.GetNamespace(), "name", autoScaler.GetName(), "version", policy.Version)
 		return err
 	}
 
@@ -218,7 +234,7 @@ func (cn *Controller) deployAutoScaler(ctx context.Context, policy dorisv1.AutoS
 func (cn *Controller) ClearResources(ctx context.Context, dcr *dorisv1.DorisCluster) (bool, error) {
 	cnStatus := dcr.Status.CnStatus
 	if cnStatus == nil {
-		klog.Info("Doris cluster is not have cn")
+		klog.FromContext(ctx).Info("Doris cluster is not have cn", "namespace", dcr.Namespace, "name", dcr.Name)
 		return true, nil
 	}
 
@@ -229,6 +245,11 @@ func (cn *Controller) ClearResources(ctx context.Context, dcr *dorisv1.DorisClus
 		}
 	}
 
+	if err := cn.RecycleResources(ctx, dcr, dorisv1.Component_CN); err != nil {
+		klog.FromContext(ctx).Info("cn ClearResources recycle pvc resource for reconciling", "namespace", dcr.Namespace, "name", dcr.Name)
+		return false, err
+	}
+
 	if dcr.Spec.CnSpec == nil {
 		cn.ClearCommonResources(ctx, dcr, dorisv1.Component_CN)
 	}
@@ -244,7 +265,7 @@ func (cn *Controller) DeleteAutoscaler(ctx context.Context, dcr *dorisv1.DorisCl
 	autoScalerName := dcr.Status.CnStatus.HorizontalScaler.Name
 	version := dcr.Status.CnStatus.HorizontalScaler.Version
 	if err := k8s.DeleteAutoscaler(ctx, cn.K8sclient, dcr.Namespace, autoScalerName, version); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("cnController delete failed, namespace=%s, autosclaer name=%s, autoscaler version=%s", dcr.GetNamespace(), autoScalerName, version)
+		klog.FromContext(ctx).Error(nil, "cnController delete autoscaler failed", "namespace", dcr.GetNamespace(), "name", autoScalerName, "version", version)
 		return err
 	}
 
@@ -259,7 +280,7 @@ func (cn *Controller) GetConfig(ctx context.Context, configMapInfo *dorisv1.Conf
 	}
 	configMaps, err := k8s.GetConfigMaps(ctx, cn.K8sclient, namespace, cms)
 	if err != nil {
-		klog.Errorf("CnController GetConfig get configmap failed, namespace: %s, err: %s \n", namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "CnController GetConfig get configmap failed", "namespace", namespace)
 	}
 	res, resolveErr := resource.ResolveConfigMaps(configMaps, dorisv1.Component_CN)
 	return res, utils.MergeError(err, resolveErr)
@@ -272,7 +293,7 @@ func (cn *Controller) getFeConfig(ctx context.Context, configMapInfo *dorisv1.Co
 	}
 	configMaps, err := k8s.GetConfigMaps(ctx, cn.K8sclient, namespace, cms)
 	if err != nil {
-		klog.Errorf("CnController GetFeConfig get configmap failed, namespace: %s, err: %s \n", namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "CnController GetFeConfig get configmap failed", "namespace", namespace)
 	}
 	res, resolveErr := resource.ResolveConfigMaps(configMaps, dorisv1.Component_FE)
 	return res, utils.MergeError(err, resolveErr)
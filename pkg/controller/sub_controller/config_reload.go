@@ -0,0 +1,290 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// configApplier applies the changed configs on all nodes of component at runtime.
+type configApplier interface {
+	// mutableConfigs return the configs of component and whether the config can be modified at runtime.
+	mutableConfigs(ctx context.Context) (map[string]bool, error)
+	// apply modify the configs on all nodes of component.
+	apply(ctx context.Context, configs map[string]string) error
+}
+
+// frontendConfigApplier set the configs of every fe by `ADMIN SET FRONTEND CONFIG`.
+type frontendConfigApplier struct {
+	masterDB  *mysql.DB
+	dbConf    mysql.DBConfig
+	tlsConfig *mysql.TLSConfig
+	tlsSecret *corev1.Secret
+}
+
+func (fa *frontendConfigApplier) mutableConfigs(ctx context.Context) (map[string]bool, error) {
+	configs, err := fa.masterDB.ShowFrontendConfigs()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		res[config.Key] = config.IsMutable
+	}
+	return res, nil
+}
+
+func (fa *frontendConfigApplier) apply(ctx context.Context, configs map[string]string) error {
+	fes, err := fa.masterDB.ShowFrontends()
+	if err != nil {
+		return err
+	}
+	for _, fe := range fes {
+		db, err := mysql.NewDorisSqlDB(mysql.DBConfig{
+			User:     fa.dbConf.User,
+			Password: fa.dbConf.Password,
+			Host:     fe.Host,
+			Port:     strconv.Itoa(fe.QueryPort),
+			Database: "mysql",
+		}, fa.tlsConfig, fa.tlsSecret)
+		if err != nil {
+			return fmt.Errorf("connect fe %s failed, err=%s", fe.Host, err.Error())
+		}
+		for _, key := range sortedKeys(configs) {
+			if err := db.SetFrontendConfig(key, configs[key]); err != nil {
+				db.Close()
+				return fmt.Errorf("set config %s on fe %s failed, err=%s", key, fe.Host, err.Error())
+			}
+		}
+		db.Close()
+	}
+	return nil
+}
+
+// backendConfigApplier update the configs of every be by the http api `/api/update_config`.
+type backendConfigApplier struct {
+	masterDB *mysql.DB
+	user     string
+	password string
+	// not nil when the webserver of be serves https.
+	tlsConfig *tls.Config
+}
+
+func (ba *backendConfigApplier) clients() ([]*doris.BackendHttpClient, error) {
+	bes, err := ba.masterDB.ShowBackends()
+	if err != nil {
+		return nil, err
+	}
+	var clients []*doris.BackendHttpClient
+	for _, be := range bes {
+		if be.NodeRole == mysql.BE_COMPUTATION_ROLE {
+			continue
+		}
+		clients = append(clients, doris.NewBackendHttpClient(be.Host, be.HttpPort, ba.user, ba.password, ba.tlsConfig))
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no backend registered in fe")
+	}
+	return clients, nil
+}
+
+// mutableConfigs ask every be for the configs, as the bes may run different versions. a config is mutable only when it is mutable on all bes,
+// the error is returned when any be can not answer, then the component is restarted for applying configs.
+func (ba *backendConfigApplier) mutableConfigs(ctx context.Context) (map[string]bool, error) {
+	clients, err := ba.clients()
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]bool{}
+	counts := map[string]int{}
+	for i, c := range clients {
+		configs, err := c.ShowConfigs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("show configs on be %s failed, err=%s", c.Endpoint, err.Error())
+		}
+		for key, mutable := range configs {
+			if i == 0 {
+				res[key] = mutable
+			} else {
+				res[key] = res[key] && mutable
+			}
+			counts[key]++
+		}
+	}
+	// the config not existing on some bes is not modified at runtime.
+	for key, count := range counts {
+		if count != len(clients) {
+			res[key] = false
+		}
+	}
+	return res, nil
+}
+
+func (ba *backendConfigApplier) apply(ctx context.Context, configs map[string]string) error {
+	clients, err := ba.clients()
+	if err != nil {
+		return err
+	}
+	for _, c := range clients {
+		if err := c.UpdateConfigs(ctx, configs); err != nil {
+			return fmt.Errorf("update configs on be %s failed, err=%s", c.Endpoint, err.Error())
+		}
+	}
+	return nil
+}
+
+// SaveAppliedConfig snapshot the core configmap of component as the configs applied on the pods.
+func (d *SubDefaultController) SaveAppliedConfig(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
+	coreCmName := resource.GetDorisCoreConfigMapNames(dcr)[componentType]
+	if coreCmName == "" {
+		return
+	}
+	coreCm, err := k8s.GetConfigMap(ctx, d.K8sclient, dcr.Namespace, coreCmName)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController SaveAppliedConfig get configmap failed", "name", coreCmName, "namespace", dcr.Namespace)
+		return
+	}
+	if err := k8s.ApplyConfigMap(ctx, d.K8sclient, resource.BuildAppliedConfigMap(dcr, componentType, coreCm)); err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController SaveAppliedConfig apply the applied configmap failed", "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+	}
+}
+
+// ReloadConfig compare the core configmap with the applied configs, the changed configs are modified at runtime on all nodes
+// when all of them are mutable. returns false when the component should be restarted for applying configs.
+// the classification of changed configs is reported in event.
+func (d *SubDefaultController) ReloadConfig(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) bool {
+	changes, err := d.getConfigChanges(ctx, dcr, componentType)
+	if err != nil {
+		klog.FromContext(ctx).Info("SubDefaultController ReloadConfig the changed configs not resolved, restart for applying", "err", err, "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		return false
+	}
+	if changes.IsEmpty() {
+		return true
+	}
+
+	adminUserName, password := d.GetManagementAdminUserAndPWD(ctx, dcr)
+	dbConf, tlsConfig, tlsSecret := d.getSqlClientConfig(ctx, dcr, adminUserName, password)
+	masterDB, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, tlsSecret)
+	if err != nil {
+		d.K8srecorder.Event(dcr, string(EventWarning), string(ConfigHotReloadFailed), "connect fe failed, restart "+string(componentType)+" for applying configs, "+err.Error())
+		return false
+	}
+	defer masterDB.Close()
+
+	var applier configApplier
+	switch componentType {
+	case dorisv1.Component_FE:
+		applier = &frontendConfigApplier{masterDB: masterDB, dbConf: dbConf, tlsConfig: tlsConfig, tlsSecret: tlsSecret}
+	case dorisv1.Component_BE:
+		beTLSConfig, err := d.getBackendHttpsTLSConfig(ctx, dcr)
+		if err != nil {
+			d.K8srecorder.Event(dcr, string(EventWarning), string(ConfigHotReloadFailed), "the webserver of be not accessible by operator, restart be for applying configs, "+err.Error())
+			return false
+		}
+		applier = &backendConfigApplier{masterDB: masterDB, user: adminUserName, password: password, tlsConfig: beTLSConfig}
+	default:
+		return false
+	}
+
+	mutableConfigs, err := applier.mutableConfigs(ctx)
+	if err != nil {
+		d.K8srecorder.Event(dcr, string(EventWarning), string(ConfigHotReloadFailed), "get the mutable configs failed, restart "+string(componentType)+" for applying configs, "+err.Error())
+		return false
+	}
+	mutableKeys, immutableKeys := changes.Classify(mutableConfigs)
+	classification := fmt.Sprintf("%s configs changed, mutable: [%s], immutable: [%s]", componentType, strings.Join(mutableKeys, ","), strings.Join(immutableKeys, ","))
+	if len(immutableKeys) != 0 {
+		d.K8srecorder.Event(dcr, string(EventNormal), string(ConfigChangeNeedRestart), classification+", restart for applying configs.")
+		return false
+	}
+
+	if err := applier.apply(ctx, changes.Changed); err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ReloadConfig apply configs failed", "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(ConfigHotReloadFailed), classification+", apply at runtime failed, restart for applying configs. "+err.Error())
+		return false
+	}
+	d.K8srecorder.Event(dcr, string(EventNormal), string(ConfigHotReloaded), classification+", applied at runtime without restart.")
+	return true
+}
+
+// getBackendHttpsTLSConfig returns the tls config for requesting the webserver of be, nil when `enable_https` not enabled in be.conf.
+// the webserver can only be verified when the certificates issued by operator, otherwise the error explains why the configs not applied at runtime.
+func (d *SubDefaultController) getBackendHttpsTLSConfig(ctx context.Context, dcr *dorisv1.DorisCluster) (*tls.Config, error) {
+	config, err := k8s.GetConfig(ctx, d.K8sclient, &dcr.Spec.BeSpec.ConfigMapInfo, dcr.Namespace, dorisv1.Component_BE)
+	if err != nil {
+		return nil, err
+	}
+	if resource.GetString(config, resource.ENABLE_HTTPS_KEY) != "true" {
+		return nil, nil
+	}
+	if !dcr.IsTLSEnabled() {
+		return nil, errors.New("enable_https is enabled in be.conf but the certificates of be not issued by operator, the webserver of be can not be verified. enable spec.tls and set ssl_certificate_path, ssl_private_key_path to the certificates issued by operator in " + resource.TLS_MOUNT_PATH + " to apply the mutable configs at runtime")
+	}
+
+	secret, err := k8s.GetSecret(ctx, d.K8sclient, dcr.Namespace, resource.GetTLSClientSecretName(dcr.Name))
+	if err != nil {
+		return nil, err
+	}
+	return newOperatorHttpsTLSConfig(secret)
+}
+
+// getConfigChanges diff the applied configs with the configs in core configmap of component.
+func (d *SubDefaultController) getConfigChanges(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) (doris.ConfigChanges, error) {
+	appliedCm, err := k8s.GetConfigMap(ctx, d.K8sclient, dcr.Namespace, resource.GetAppliedConfigMapName(dcr, componentType))
+	if err != nil {
+		return doris.ConfigChanges{}, err
+	}
+	coreCm, err := k8s.GetConfigMap(ctx, d.K8sclient, dcr.Namespace, resource.GetDorisCoreConfigMapNames(dcr)[componentType])
+	if err != nil {
+		return doris.ConfigChanges{}, err
+	}
+
+	oldConfigs, err := resource.ResolveConfigMaps([]*corev1.ConfigMap{appliedCm}, componentType)
+	if err != nil {
+		return doris.ConfigChanges{}, err
+	}
+	newConfigs, err := resource.ResolveConfigMaps([]*corev1.ConfigMap{coreCm}, componentType)
+	if err != nil {
+		return doris.ConfigChanges{}, err
+	}
+	return doris.DiffConfigs(oldConfigs, newConfigs), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
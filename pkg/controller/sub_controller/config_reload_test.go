@@ -0,0 +1,247 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	"github.com/jmoiron/sqlx"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_getConfigChanges(t *testing.T) {
+	ctx := context.Background()
+	dcr := &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: dorisv1.DorisClusterSpec{
+			BeSpec: &dorisv1.BeSpec{BaseSpec: dorisv1.BaseSpec{ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "be-conf"}}},
+		},
+	}
+	coreCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "be-conf"},
+		Data:       map[string]string{"be.conf": "be_port = 9060\ndisable_auto_compaction = false\n"},
+	}
+	k8sclient := fake.NewClientBuilder().WithObjects(coreCm).Build()
+	d := &SubDefaultController{K8sclient: k8sclient}
+
+	// the applied configs not snapshot, the changes are not resolved.
+	if _, err := d.getConfigChanges(ctx, dcr, dorisv1.Component_BE); err == nil {
+		t.Errorf("expected the changes not resolved without the applied configs")
+	}
+
+	d.SaveAppliedConfig(ctx, dcr, dorisv1.Component_BE)
+	coreCm.Data["be.conf"] = "be_port = 9061\ndisable_auto_compaction = true\n"
+	if err := k8sclient.Update(ctx, coreCm); err != nil {
+		t.Fatalf("update core configmap failed, err=%s", err.Error())
+	}
+	changes, err := d.getConfigChanges(ctx, dcr, dorisv1.Component_BE)
+	if err != nil {
+		t.Fatalf("get config changes failed, err=%s", err.Error())
+	}
+	if !reflect.DeepEqual(changes.Changed, map[string]string{"be_port": "9061", "disable_auto_compaction": "true"}) || len(changes.Removed) != 0 {
+		t.Errorf("config changes not expected, changes=%v", changes)
+	}
+
+	d.SaveAppliedConfig(ctx, dcr, dorisv1.Component_BE)
+	applied, _ := k8s.GetConfigMap(ctx, k8sclient, "default", "test-be-applied-conf")
+	if applied == nil || applied.Data["be.conf"] != coreCm.Data["be.conf"] {
+		t.Errorf("the applied configs not updated, configmap=%v", applied)
+	}
+}
+
+func Test_CompareConfigmapAndTriggerRestart(t *testing.T) {
+	ctx := context.Background()
+	dcr := &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: dorisv1.DorisClusterSpec{
+			BeSpec: &dorisv1.BeSpec{BaseSpec: dorisv1.BaseSpec{ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "be-conf"}}},
+		},
+		Status: dorisv1.DorisClusterStatus{BEStatus: &dorisv1.ComponentStatus{}},
+	}
+	coreCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "be-conf"},
+		Data:       map[string]string{"be.conf": "be_port = 9060\n"},
+	}
+	k8sclient := fake.NewClientBuilder().WithObjects(coreCm).Build()
+	d := &SubDefaultController{K8sclient: k8sclient, K8srecorder: record.NewFakeRecorder(10)}
+	appliedName := resource.GetAppliedConfigMapName(dcr, dorisv1.Component_BE)
+
+	// the changed configs not resolved without snapshot, restart is triggered and the snapshot is not saved before the restart finished.
+	oldStatus := dorisv1.ComponentStatus{CoreConfigMapHashValue: "old", ComponentCondition: dorisv1.ComponentCondition{Phase: dorisv1.Available}}
+	d.CompareConfigmapAndTriggerRestart(ctx, dcr, oldStatus, dorisv1.Component_BE)
+	if dcr.Annotations[dorisv1.GetRestartAnnotationKey(dorisv1.Component_BE)] == "" {
+		t.Errorf("expected restart triggered for the changed configs")
+	}
+	if applied, _ := k8s.GetConfigMap(ctx, k8sclient, "default", appliedName); applied != nil {
+		t.Errorf("expected the applied configs not saved before the restart finished")
+	}
+
+	newHash := d.BuildCoreConfigmapStatusHash(ctx, dcr, dorisv1.Component_BE)
+	oldStatus = dorisv1.ComponentStatus{CoreConfigMapHashValue: newHash, ComponentCondition: dorisv1.ComponentCondition{Phase: dorisv1.Restarting}}
+	d.CompareConfigmapAndTriggerRestart(ctx, dcr, oldStatus, dorisv1.Component_BE)
+	if applied, _ := k8s.GetConfigMap(ctx, k8sclient, "default", appliedName); applied != nil {
+		t.Errorf("expected the applied configs not saved when restarting")
+	}
+
+	oldStatus.ComponentCondition.Phase = dorisv1.Available
+	d.CompareConfigmapAndTriggerRestart(ctx, dcr, oldStatus, dorisv1.Component_BE)
+	if applied, _ := k8s.GetConfigMap(ctx, k8sclient, "default", appliedName); applied == nil || applied.Data["be.conf"] != coreCm.Data["be.conf"] {
+		t.Errorf("expected the applied configs saved when the restart finished, configmap=%v", applied)
+	}
+}
+
+func Test_backendConfigApplier(t *testing.T) {
+	ctx := context.Background()
+	updated := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/show_config":
+			w.Write([]byte(`[["disable_auto_compaction","bool","false","true"],["be_port","int32","9060","false"]]`))
+		case "/api/update_config":
+			updated++
+			w.Write([]byte(`[{"config_name":"disable_auto_compaction","status":"OK","msg":""}]`))
+		}
+	}))
+	defer srv.Close()
+	hostPort := strings.Split(strings.TrimPrefix(srv.URL, "http://"), ":")
+	port, _ := strconv.Atoi(hostPort[1])
+
+	sdb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock new failed %s", err.Error())
+	}
+	columns := []string{"BackendId", "Host", "HttpPort", "NodeRole"}
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("show backends").WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("10001", hostPort[0], port, "mix").
+			AddRow("10002", "cn-0", 8040, mysql.BE_COMPUTATION_ROLE))
+	}
+	ba := &backendConfigApplier{masterDB: &mysql.DB{DB: sqlx.NewDb(sdb, "mysql")}, user: "root"}
+
+	mutableConfigs, err := ba.mutableConfigs(ctx)
+	if err != nil {
+		t.Fatalf("get mutable configs failed, err=%s", err.Error())
+	}
+	if !mutableConfigs["disable_auto_compaction"] || mutableConfigs["be_port"] {
+		t.Errorf("mutable configs not expected, configs=%v", mutableConfigs)
+	}
+	if err := ba.apply(ctx, map[string]string{"disable_auto_compaction": "true"}); err != nil {
+		t.Fatalf("apply configs failed, err=%s", err.Error())
+	}
+	if updated != 1 {
+		t.Errorf("expected the configs only updated on be, updated times=%d", updated)
+	}
+}
+
+func Test_backendConfigApplier_mutableOnEveryBE(t *testing.T) {
+	ctx := context.Background()
+	newBE := func(configs string) (string, int, func()) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(configs))
+		}))
+		hostPort := strings.Split(strings.TrimPrefix(srv.URL, "http://"), ":")
+		port, _ := strconv.Atoi(hostPort[1])
+		return hostPort[0], port, srv.Close
+	}
+	host1, port1, close1 := newBE(`[["disable_auto_compaction","bool","false","true"],["max_tablet_version_num","int32","2000","true"]]`)
+	defer close1()
+	host2, port2, close2 := newBE(`[["disable_auto_compaction","bool","false","false"]]`)
+	defer close2()
+
+	sdb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock new failed %s", err.Error())
+	}
+	columns := []string{"BackendId", "Host", "HttpPort", "NodeRole"}
+	mock.ExpectQuery("show backends").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("10001", host1, port1, "mix").
+		AddRow("10002", host2, port2, "mix"))
+	mock.ExpectQuery("show backends").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("10001", host1, port1, "mix").
+		AddRow("10003", "127.0.0.1", 1, "mix"))
+	ba := &backendConfigApplier{masterDB: &mysql.DB{DB: sqlx.NewDb(sdb, "mysql")}, user: "root"}
+
+	mutableConfigs, err := ba.mutableConfigs(ctx)
+	if err != nil {
+		t.Fatalf("get mutable configs failed, err=%s", err.Error())
+	}
+	if mutableConfigs["disable_auto_compaction"] || mutableConfigs["max_tablet_version_num"] {
+		t.Errorf("expected the configs not mutable on every be are immutable, configs=%v", mutableConfigs)
+	}
+
+	if _, err := ba.mutableConfigs(ctx); err == nil {
+		t.Errorf("expected error when a be can not answer")
+	}
+}
+
+func Test_getBackendHttpsTLSConfig(t *testing.T) {
+	ctx := context.Background()
+	dcr := &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: dorisv1.DorisClusterSpec{
+			BeSpec: &dorisv1.BeSpec{BaseSpec: dorisv1.BaseSpec{ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "be-conf"}}},
+		},
+	}
+	coreCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "be-conf"},
+		Data:       map[string]string{"be.conf": "be_port = 9060\n"},
+	}
+	k8sclient := fake.NewClientBuilder().WithObjects(coreCm).Build()
+	d := &SubDefaultController{K8sclient: k8sclient}
+
+	if tlsConfig, err := d.getBackendHttpsTLSConfig(ctx, dcr); tlsConfig != nil || err != nil {
+		t.Errorf("expected the webserver requested by http when enable_https not enabled, err=%v", err)
+	}
+
+	coreCm.Data["be.conf"] = "be_port = 9060\nenable_https = true\n"
+	if err := k8sclient.Update(ctx, coreCm); err != nil {
+		t.Fatalf("update core configmap failed, err=%s", err.Error())
+	}
+	if _, err := d.getBackendHttpsTLSConfig(ctx, dcr); err == nil {
+		t.Errorf("expected error when the certificates of be not issued by operator")
+	}
+
+	dcr.Spec.TLS = &dorisv1.TLS{Enabled: true}
+	ti := &tlsIssuer{k8sclient: k8sclient, namespace: "default", clusterName: "test", validity: 24 * time.Hour, renewBefore: time.Hour}
+	ca, err := ti.applyCA(ctx)
+	if err != nil {
+		t.Fatalf("tlsIssuer applyCA failed, err=%s", err.Error())
+	}
+	if _, err := ti.applyCertificate(ctx, ca, resource.GetTLSClientSecretName("test"), "operator", nil); err != nil {
+		t.Fatalf("tlsIssuer applyCertificate failed, err=%s", err.Error())
+	}
+	tlsConfig, err := d.getBackendHttpsTLSConfig(ctx, dcr)
+	if err != nil || tlsConfig == nil || tlsConfig.RootCAs == nil || len(tlsConfig.Certificates) != 1 {
+		t.Errorf("expected the tls config built from the client certificate issued by operator, err=%v", err)
+	}
+}
@@ -0,0 +1,306 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configSchema describes the configs validated in the config file of component.
+type configSchema struct {
+	// the component of the list of known configs, empty represents the unknown configs not checked.
+	component string
+	// the ports should be valid and not collide with each other.
+	portKeys []string
+	intKeys  []string
+	boolKeys []string
+	// the configs in the format of `storage_root_path`.
+	storagePathKeys []string
+	// the configs in the format of `file_cache_path`.
+	fileCachePathKeys []string
+}
+
+var (
+	feConfigSchema = configSchema{
+		component: doris.ConfigComponentFE,
+		portKeys:  []string{resource.HTTP_PORT, resource.RPC_PORT, resource.QUERY_PORT, resource.EDIT_LOG_PORT, resource.ARROW_FLIGHT_SQL_PORT},
+		boolKeys:  []string{resource.ENABLE_FQDN},
+	}
+	beConfigSchema = configSchema{
+		component:         doris.ConfigComponentBE,
+		portKeys:          []string{resource.BE_PORT, resource.WEBSERVER_PORT, resource.HEARTBEAT_SERVICE_PORT, resource.BRPC_PORT, resource.ARROW_FLIGHT_SQL_PORT},
+		intKeys:           []string{resource.GRACE_SHUTDOWN_WAIT_SECONDS},
+		storagePathKeys:   []string{resource.STORAGE_ROOT_PATH_KEY, resource.SPILL_STORAGE_ROOT_PATH_KEY},
+		fileCachePathKeys: []string{FileCachePathKey},
+	}
+	brokerConfigSchema = configSchema{
+		portKeys: []string{resource.BROKER_IPC_PORT},
+	}
+	msConfigSchema = configSchema{
+		component: doris.ConfigComponentMS,
+		portKeys:  []string{resource.BRPC_LISTEN_PORT},
+	}
+)
+
+// ConfigMapValidator validates the configs in the configmaps referenced by cluster with the resolvers used by controllers,
+// the configs that make the pods fail to start or collide are errors, the configs close to but not the known configs of the doris version of image are warnings.
+type ConfigMapValidator struct {
+	K8sclient client.Client
+}
+
+// ValidateDorisCluster validates the fe.conf, be.conf and apache_hdfs_broker.conf of DorisCluster.
+func (cv *ConfigMapValidator) ValidateDorisCluster(ctx context.Context, dcr *dorisv1.DorisCluster) ([]string, []error) {
+	cmNames := resource.GetDorisCoreConfigMapNames(dcr)
+	var warnings []string
+	var errs []error
+	for _, componentType := range []dorisv1.ComponentType{dorisv1.Component_FE, dorisv1.Component_BE, dorisv1.Component_CN, dorisv1.Component_Broker} {
+		cmName, ok := cmNames[componentType]
+		if !ok {
+			continue
+		}
+
+		cm, err := k8s.GetConfigMap(ctx, cv.K8sclient, dcr.Namespace, cmName)
+		if err != nil {
+			warnings = append(warnings, getConfigMapWarning(cmName, string(componentType), err))
+			continue
+		}
+		configs, err := resource.ResolveConfigMaps([]*corev1.ConfigMap{cm}, componentType)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("configmap %s of %s not validated: %s", cmName, componentType, err.Error()))
+			continue
+		}
+
+		schema, image := getDorisClusterConfigSchema(dcr, componentType)
+		w, es := validateConfigs(configs, schema, image, fmt.Sprintf("configmap %s of %s", cmName, componentType))
+		warnings = append(warnings, w...)
+		errs = append(errs, es...)
+	}
+
+	return warnings, errs
+}
+
+// ValidateDisaggregatedCluster validates the doris_cloud.conf of meta service, fe.conf of fe and be.conf of every compute group.
+func (cv *ConfigMapValidator) ValidateDisaggregatedCluster(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) ([]string, []error) {
+	var warnings []string
+	var errs []error
+	validate := func(cms []dv1.ConfigMap, resolveKey string, schema configSchema, image, scope string) {
+		w, es := cv.validateDisaggregatedConfigMaps(ctx, ddc.Namespace, cms, resolveKey, schema, image, scope)
+		warnings = append(warnings, w...)
+		errs = append(errs, es...)
+	}
+
+	validate(ddc.Spec.MetaService.ConfigMaps, resource.MS_RESOLVEKEY, msConfigSchema, ddc.Spec.MetaService.Image, "metaService")
+	validate(ddc.Spec.FeSpec.ConfigMaps, resource.FE_RESOLVEKEY, feConfigSchema, ddc.Spec.FeSpec.Image, "fe")
+	for i := range ddc.Spec.ComputeGroups {
+		cg := &ddc.Spec.ComputeGroups[i]
+		validate(cg.ConfigMaps, resource.BE_RESOLVEKEY, beConfigSchema, cg.Image, "compute group "+cg.UniqueId)
+	}
+
+	return warnings, errs
+}
+
+// validateDisaggregatedConfigMaps validates the first configmap that contains the resolveKey as the component resolved.
+func (cv *ConfigMapValidator) validateDisaggregatedConfigMaps(ctx context.Context, namespace string, cms []dv1.ConfigMap, resolveKey string, schema configSchema, image, scope string) ([]string, []error) {
+	var warnings []string
+	d := &DisaggregatedSubDefaultController{K8sclient: cv.K8sclient}
+	for _, cm := range cms {
+		kcm, err := k8s.GetConfigMap(ctx, cv.K8sclient, namespace, cm.Name)
+		if err != nil {
+			warnings = append(warnings, getConfigMapWarning(cm.Name, scope, err))
+			continue
+		}
+		v, ok := kcm.Data[resolveKey]
+		if !ok {
+			continue
+		}
+
+		configs := d.resolveStartConfig([]byte(v), resolveKey)
+		w, errs := validateConfigs(configs, schema, image, fmt.Sprintf("configmap %s of %s", cm.Name, scope))
+		return append(warnings, w...), errs
+	}
+
+	return warnings, nil
+}
+
+func getConfigMapWarning(cmName, scope string, err error) string {
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("configmap %s of %s not found, the configs are not validated", cmName, scope)
+	}
+	return fmt.Sprintf("configmap %s of %s not validated: %s", cmName, scope, err.Error())
+}
+
+func getDorisClusterConfigSchema(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) (configSchema, string) {
+	switch componentType {
+	case dorisv1.Component_FE:
+		return feConfigSchema, dcr.Spec.FeSpec.Image
+	case dorisv1.Component_BE:
+		return beConfigSchema, dcr.Spec.BeSpec.Image
+	case dorisv1.Component_CN:
+		return beConfigSchema, dcr.Spec.CnSpec.Image
+	default:
+		return brokerConfigSchema, dcr.Spec.BrokerSpec.Image
+	}
+}
+
+// validateConfigs validates the resolved configs by schema, the scope describes where the configs from in messages.
+func validateConfigs(configs map[string]interface{}, schema configSchema, image, scope string) ([]string, []error) {
+	var errs []error
+	errs = append(errs, validatePorts(configs, schema.portKeys, scope)...)
+	for _, key := range schema.intKeys {
+		if v, ok := getConfigString(configs, key); ok {
+			if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+				errs = append(errs, fmt.Errorf("'%s' in %s error: %q is not an integer", key, scope, v))
+			}
+		}
+	}
+	for _, key := range schema.boolKeys {
+		if v, ok := getConfigString(configs, key); ok {
+			if _, err := strconv.ParseBool(v); err != nil {
+				errs = append(errs, fmt.Errorf("'%s' in %s error: %q is not a boolean", key, scope, v))
+			}
+		}
+	}
+	for _, key := range schema.storagePathKeys {
+		if v, ok := getConfigString(configs, key); ok {
+			errs = append(errs, validateStorageRootPath(key, v, scope)...)
+		}
+	}
+	for _, key := range schema.fileCachePathKeys {
+		if v, ok := getConfigString(configs, key); ok {
+			errs = append(errs, validateFileCachePath(key, v, scope)...)
+		}
+	}
+
+	return getUnknownConfigWarnings(configs, schema.component, image, scope), errs
+}
+
+// validatePorts checks the ports are in range and the effective ports, with default values of not configured, not collide with each other.
+func validatePorts(configs map[string]interface{}, portKeys []string, scope string) []error {
+	var errs []error
+	usedPorts := map[int64]string{}
+	for _, key := range portKeys {
+		port := int64(resource.GetDefaultPort(key))
+		if v, ok := getConfigString(configs, key); ok {
+			p, err := strconv.ParseInt(v, 10, 32)
+			// arrow flight sql is disabled when port is not positive.
+			if err != nil || p > 65535 || (p <= 0 && key != resource.ARROW_FLIGHT_SQL_PORT) {
+				errs = append(errs, fmt.Errorf("'%s' in %s error: %q is not a valid port", key, scope, v))
+				continue
+			}
+			port = p
+		}
+		if port <= 0 {
+			continue
+		}
+
+		if used, ok := usedPorts[port]; ok {
+			errs = append(errs, fmt.Errorf("'%s' in %s error: port %d is already used by '%s'", key, scope, port, used))
+			continue
+		}
+		usedPorts[port] = key
+	}
+
+	return errs
+}
+
+// validateStorageRootPath checks every path is absolute and the medium is SSD or HDD.
+func validateStorageRootPath(key, value, scope string) []error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	srps := doris.ResolveStorageRootPathInfos(value)
+	if len(srps) == 0 {
+		return []error{fmt.Errorf("'%s' in %s error: no path resolved from %q", key, scope, value)}
+	}
+
+	var errs []error
+	for _, srp := range srps {
+		if !strings.HasPrefix(srp.Path, "/") {
+			errs = append(errs, fmt.Errorf("'%s' in %s error: path %q is not absolute", key, scope, srp.Path))
+		}
+		if srp.Medium != "" && srp.Medium != doris.StorageMediumSSD && srp.Medium != doris.StorageMediumHDD {
+			errs = append(errs, fmt.Errorf("'%s' in %s error: medium %q of path %q is not SSD or HDD", key, scope, srp.Medium, srp.Path))
+		}
+	}
+	return errs
+}
+
+// validateFileCachePath checks `file_cache_path` is a json array of objects with string `path` and numeric `total_size`.
+func validateFileCachePath(key, value, scope string) []error {
+	var paths []map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &paths); err != nil {
+		return []error{fmt.Errorf("'%s' in %s error: not a json array of cache paths, %s", key, scope, err.Error())}
+	}
+
+	var errs []error
+	for i, p := range paths {
+		if path, ok := p[FileCacheSubConfigPathKey].(string); !ok || path == "" {
+			errs = append(errs, fmt.Errorf("'%s' in %s error: the %d-th cache path has no string '%s'", key, scope, i, FileCacheSubConfigPathKey))
+		}
+		if _, ok := p[FileCacheSubConfigTotalSizeKey].(float64); !ok {
+			errs = append(errs, fmt.Errorf("'%s' in %s error: the %d-th cache path has no numeric '%s'", key, scope, i, FileCacheSubConfigTotalSizeKey))
+		}
+	}
+	return errs
+}
+
+// getUnknownConfigWarnings warns the configs not known by the doris version of image but close to a known config, they may be misspelled.
+// the configs not close to any known config are not warned, as the lists of known configs are not complete.
+func getUnknownConfigWarnings(configs map[string]interface{}, component, image, scope string) []string {
+	known := doris.KnownConfigKeys(component, image)
+	if known == nil {
+		return nil
+	}
+
+	var keys []string
+	for key := range configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		if similar, ok := doris.SimilarConfigKey(key, known); ok {
+			warnings = append(warnings, fmt.Sprintf("'%s' in %s is not a known config of %s %s, did you mean '%s'?", key, scope, component, image, similar))
+		}
+	}
+	return warnings
+}
+
+func getConfigString(configs map[string]interface{}, key string) (string, bool) {
+	v, ok := configs[key]
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return strings.TrimSpace(s), ok
+}
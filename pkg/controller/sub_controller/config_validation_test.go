@@ -0,0 +1,121 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_validateConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs map[string]interface{}
+		schema  configSchema
+		errs    int
+	}{
+		{"valid fe", map[string]interface{}{"http_port": "8030", "enable_fqdn_mode": "true", "arrow_flight_sql_port": "-1"}, feConfigSchema, 0},
+		{"invalid port", map[string]interface{}{"query_port": "90a0"}, feConfigSchema, 1},
+		{"port out of range", map[string]interface{}{"rpc_port": "70000"}, feConfigSchema, 1},
+		{"port collide with default", map[string]interface{}{"query_port": "8030"}, feConfigSchema, 1},
+		{"invalid bool", map[string]interface{}{"enable_fqdn_mode": "yes"}, feConfigSchema, 1},
+		{"invalid grace seconds", map[string]interface{}{"grace_shutdown_wait_seconds": "30s"}, beConfigSchema, 1},
+		{"valid storage path", map[string]interface{}{"storage_root_path": "/opt/doris/data1.SSD;/opt/doris/data2,medium:hdd"}, beConfigSchema, 0},
+		{"relative storage path", map[string]interface{}{"storage_root_path": "data1"}, beConfigSchema, 1},
+		{"invalid medium", map[string]interface{}{"storage_root_path": "/opt/doris/data1,medium:nvme"}, beConfigSchema, 1},
+		{"empty storage path", map[string]interface{}{"storage_root_path": ";"}, beConfigSchema, 1},
+		{"valid file cache path", map[string]interface{}{"file_cache_path": `[{"path":"/opt/doris/be/file_cache","total_size":107374182400}]`}, beConfigSchema, 0},
+		{"invalid file cache path", map[string]interface{}{"file_cache_path": `[{"path":"/opt/doris/be/file_cache","total_size":"100G"}]`}, beConfigSchema, 1},
+		{"not json file cache path", map[string]interface{}{"file_cache_path": "/opt/doris/be/file_cache"}, beConfigSchema, 1},
+	}
+
+	for _, test := range tests {
+		_, errs := validateConfigs(test.configs, test.schema, "", "test")
+		if len(errs) != test.errs {
+			t.Errorf("%s: expected %d errors, got %v", test.name, test.errs, errs)
+		}
+	}
+}
+
+func Test_getUnknownConfigWarnings(t *testing.T) {
+	// the config not in the lists and not close to any known config is not warned.
+	configs := map[string]interface{}{"http_port": "8030", "htp_port": "8030", "enable_new_feature_not_listed": "true"}
+	warnings := getUnknownConfigWarnings(configs, "fe", "apache/doris:fe-2.1.7", "test")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "'htp_port'") || !strings.Contains(warnings[0], "did you mean 'http_port'") {
+		t.Errorf("expected the misspelled config warned, warnings=%v", warnings)
+	}
+	if warnings = getUnknownConfigWarnings(configs, "", "apache/doris:broker-2.1.7", "test"); len(warnings) != 0 {
+		t.Errorf("expected no warnings for component without known configs, warnings=%v", warnings)
+	}
+}
+
+func TestConfigMapValidator_ValidateDorisCluster(t *testing.T) {
+	dcr := &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: dorisv1.DorisClusterSpec{
+			FeSpec: &dorisv1.FeSpec{BaseSpec: dorisv1.BaseSpec{Image: "apache/doris:fe-2.1.7", ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "fe-conf"}}},
+			BeSpec: &dorisv1.BeSpec{BaseSpec: dorisv1.BaseSpec{Image: "apache/doris:be-2.1.7", ConfigMapInfo: dorisv1.ConfigMapInfo{ConfigMapName: "be-conf"}}},
+		},
+	}
+	feCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fe-conf"},
+		Data:       map[string]string{"fe.conf": "http_port = 8030\nquery_port = 8030\nenable_fqdn_mod = true\n"},
+	}
+	cv := &ConfigMapValidator{K8sclient: fake.NewClientBuilder().WithObjects(feCm).Build()}
+
+	warnings, errs := cv.ValidateDorisCluster(context.Background(), dcr)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "query_port") {
+		t.Errorf("expected the port collision rejected, errs=%v", errs)
+	}
+	// the misspelled config of fe and the configmap of be not found.
+	if len(warnings) != 2 {
+		t.Errorf("expected 2 warnings, warnings=%v", warnings)
+	}
+}
+
+func TestConfigMapValidator_ValidateDisaggregatedCluster(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: dv1.DorisDisaggregatedClusterSpec{
+			ComputeGroups: []dv1.ComputeGroup{{
+				UniqueId:   "cg1",
+				CommonSpec: dv1.CommonSpec{Image: "apache/doris:be-3.0.3", ConfigMaps: []dv1.ConfigMap{{Name: "cg1-conf"}}},
+			}},
+		},
+	}
+	cgCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cg1-conf"},
+		Data:       map[string]string{"be.conf": "deploy_mode = cloud\nfile_cache_path = [{\"path\":\"/opt/doris/be/file_cache\"}]\n"},
+	}
+	cv := &ConfigMapValidator{K8sclient: fake.NewClientBuilder().WithObjects(cgCm).Build()}
+
+	warnings, errs := cv.ValidateDisaggregatedCluster(context.Background(), ddc)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "compute group cg1") {
+		t.Errorf("expected the file cache path without total_size rejected, errs=%v", errs)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, warnings=%v", warnings)
+	}
+}
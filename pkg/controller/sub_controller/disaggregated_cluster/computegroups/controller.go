@@ -28,6 +28,7 @@ import (
 	"sync"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	"github.com/apache/doris-operator/pkg/common/logging"
 	"github.com/apache/doris-operator/pkg/common/utils"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/mysql"
@@ -40,7 +41,6 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,36 +54,35 @@ var (
 
 type DisaggregatedComputeGroupsController struct {
 	sc.DisaggregatedSubDefaultController
-	RestConfig *rest.Config
 }
 
 func New(mgr ctrl.Manager) *DisaggregatedComputeGroupsController {
 	return &DisaggregatedComputeGroupsController{
 		DisaggregatedSubDefaultController: sc.DisaggregatedSubDefaultController{
 			K8sclient:      mgr.GetClient(),
-			K8srecorder:    mgr.GetEventRecorderFor(disaggregatedComputeGroupsController),
+			K8srecorder:    sc.NewDedupRecorder(mgr.GetEventRecorderFor(disaggregatedComputeGroupsController)),
 			ControllerName: disaggregatedComputeGroupsController,
+			RestConfig:     mgr.GetConfig(),
 		},
-		RestConfig: mgr.GetConfig(),
 	}
 }
 
 func (dcgs *DisaggregatedComputeGroupsController) Sync(ctx context.Context, obj client.Object) error {
 	ddc := obj.(*dv1.DorisDisaggregatedCluster)
 	if len(ddc.Spec.ComputeGroups) == 0 {
-		klog.Errorf("disaggregatedComputeGroupsController sync disaggregatedDorisCluster namespace=%s,name=%s have not compute group spec.", ddc.Namespace, ddc.Name)
+		klog.FromContext(ctx).Error(nil, "disaggregatedComputeGroupsController sync have not compute group spec", "namespace", ddc.Namespace, "name", ddc.Name)
 		dcgs.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.ComputeGroupsEmpty), "compute group empty, the cluster will not work normal.")
 		return nil
 	}
 
-	if !dcgs.feAvailable(ddc) {
+	if !dcgs.feAvailable(ctx, ddc) {
 		dcgs.K8srecorder.Event(ddc, string(sc.EventNormal), string(sc.WaitFEAvailable), "fe have not ready.")
 		return nil
 	}
 
 	// validating compute group information.
-	if event, res := dcgs.validateComputeGroup(ddc.Spec.ComputeGroups); !res {
-		klog.Errorf("disaggregatedComputeGroupsController namespace=%s name=%s validateComputeGroup have not match specifications %s.", ddc.Namespace, ddc.Name, sc.EventString(event))
+	if event, res := dcgs.validateComputeGroup(ctx, ddc.Spec.ComputeGroups); !res {
+		klog.FromContext(ctx).Error(nil, "disaggregatedComputeGroupsController validateComputeGroup have not match specifications", "namespace", ddc.Namespace, "name", ddc.Name, "message", sc.EventString(event))
 		dcgs.K8srecorder.Eventf(ddc, string(event.Type), string(event.Reason), event.Message)
 		return errors.New("validating compute group failed")
 	}
@@ -91,13 +90,13 @@ func (dcgs *DisaggregatedComputeGroupsController) Sync(ctx context.Context, obj
 	var errs []error
 	cgs := ddc.Spec.ComputeGroups
 	for i, _ := range cgs {
-
-		if event, err := dcgs.computeGroupSync(ctx, ddc, &cgs[i]); err != nil {
+		cgctx, logger := logging.IntoContext(ctx, logging.ComputeGroupKey, cgs[i].UniqueId)
+		if event, err := dcgs.computeGroupSync(cgctx, ddc, &cgs[i]); err != nil {
 			if event != nil {
 				dcgs.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 			}
 			errs = append(errs, err)
-			klog.Errorf("disaggregatedComputeGroupsController computeGroups sync failed, compute group Uniqueid %s  sync failed, err=%s", cgs[i].UniqueId, err.Error())
+			logger.Error(err, "disaggregatedComputeGroupsController compute group sync failed")
 		}
 	}
 
@@ -113,27 +112,27 @@ func (dcgs *DisaggregatedComputeGroupsController) Sync(ctx context.Context, obj
 }
 
 // validate compute group config information.
-func (dcgs *DisaggregatedComputeGroupsController) validateComputeGroup(cgs []dv1.ComputeGroup) (*sc.Event, bool) {
+func (dcgs *DisaggregatedComputeGroupsController) validateComputeGroup(ctx context.Context, cgs []dv1.ComputeGroup) (*sc.Event, bool) {
 	dupl := dcgs.validateDuplicated(cgs)
 	if dupl != "" {
-		klog.Errorf("disaggregatedComputeGroupsController validateComputeGroup validate Duplicated have duplicate unique identifier %s.", dupl)
+		klog.FromContext(ctx).Error(nil, "disaggregatedComputeGroupsController validateComputeGroup have duplicate unique identifier", "uniqueId", dupl)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGUniqueIdentifierDuplicate, Message: "unique identifier " + dupl + " duplicate in compute groups."}, false
 	}
 
-	if reg, res := dcgs.validateRegex(cgs); !res {
-		klog.Errorf("disaggregatedComputeGroupsController validateComputeGroup validateRegex %s have not match regular expression", reg)
+	if reg, res := dcgs.validateRegex(ctx, cgs); !res {
+		klog.FromContext(ctx).Error(nil, "disaggregatedComputeGroupsController validateComputeGroup have not match regular expression", "regex", reg)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGUniqueIdentifierNotMatchRegex, Message: reg}, false
 	}
 
 	return nil, true
 }
 
-func (dcgs *DisaggregatedComputeGroupsController) feAvailable(ddc *dv1.DorisDisaggregatedCluster) bool {
+func (dcgs *DisaggregatedComputeGroupsController) feAvailable(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) bool {
 	//if fe deploy in k8s, should wait fe available
 	//1. wait for fe ok.
 	endpoints := corev1.Endpoints{}
 	if err := dcgs.K8sclient.Get(context.Background(), types.NamespacedName{Namespace: ddc.Namespace, Name: ddc.GetFEServiceName()}, &endpoints); err != nil {
-		klog.Infof("disaggregatedComputeGroupsController Sync wait fe service name %s available occur failed %s\n", ddc.GetFEServiceName(), err.Error())
+		klog.FromContext(ctx).Info("disaggregatedComputeGroupsController Sync wait fe service available occur failed", "err", err, "name", ddc.GetFEServiceName())
 		return false
 	}
 
@@ -151,37 +150,52 @@ func (dcgs *DisaggregatedComputeGroupsController) computeGroupSync(ctx context.C
 	}
 	cvs := dcgs.GetConfigValuesFromConfigMaps(ddc.Namespace, resource.BE_RESOLVEKEY, cg.CommonSpec.ConfigMaps)
 	st := dcgs.NewStatefulset(ddc, cg, cvs)
+	if err := dcgs.ApplyConfigMapHash(ctx, ddc, st, cg.ConfigMaps); err != nil {
+		return nil, err
+	}
 	internalSvc := dcgs.newInternalService(ddc, cg, cvs)
 	externalSvc := dcgs.newExternalService(ddc, cg, cvs)
 	dcgs.initialCGStatus(ddc, cg)
 
-	dcgs.CheckSecretMountPath(ddc, cg.Secrets)
+	dcgs.CheckSecretMountPath(ctx, ddc, cg.Secrets)
 	dcgs.CheckSecretExist(ctx, ddc, cg.Secrets)
 
+	if ddc.IsTLSEnabled() {
+		if err := dcgs.ApplyTLS(ctx, ddc, st, cg.ConfigMaps, resource.BE_RESOLVEKEY, resource.DISAGGREGATED_BE_MAIN_CONTAINER_NAME,
+			ddc.GetCGServiceName(cg), ddc.GetCGExternalServiceName(cg)); errors.Is(err, sc.ErrTLSCertificateNotReady) {
+			return nil, nil
+		} else if err != nil {
+			klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController apply tls failed", "namespace", ddc.Namespace, "name", ddc.Name)
+			return &sc.Event{Type: sc.EventWarning, Reason: sc.TLSCertificateIssueFailed, Message: err.Error()}, err
+		}
+	}
+
 	// Reconcile internal headless service.
 	// During upgrade from older versions, the existing service may not be headless (has a ClusterIP assigned).
 	// Since K8s does not allow changing spec.clusterIP on an existing service, we must delete and recreate it.
 	if err := dcgs.reconcileInternalService(ctx, internalSvc); err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcile internal service namespace %s name %s failed, err=%s", internalSvc.Namespace, internalSvc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcile internal service failed", "namespace", internalSvc.Namespace, "name", internalSvc.Name)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.ServiceApplyedFailed, Message: err.Error()}, err
 	}
 
 	// Reconcile external service for load-balanced access.
 	event, err := dcgs.DefaultReconcileService(ctx, externalSvc)
 	if err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcile external service namespace %s name %s failed, err=%s", externalSvc.Namespace, externalSvc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcile external service failed", "namespace", externalSvc.Namespace, "name", externalSvc.Name)
 		return event, err
 	}
 
+	dcgs.ApplyMonitor(ctx, ddc, dv1.DisaggregatedBE, cg, st, externalSvc)
+
 	event, err = dcgs.reconcileStatefulset(ctx, st, ddc, cg)
 	if err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcile statefulset namespace %s name %s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcile statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return event, err
 	}
 
 	event, err = dcgs.ReconcilePVC(ctx, ddc, cvs, dv1.DisaggregatedBE, st, cg)
 	if err != nil {
-		klog.Errorf("computeGroupSync ReconcilePVC failed, namespace: %s, ddc name %s, cgName: %s, error=%s!", ddc.Namespace, ddc.Name, cg.UniqueId, err.Error())
+		klog.FromContext(ctx).Error(err, "computeGroupSync ReconcilePVC failed", "namespace", ddc.Namespace, "name", ddc.Name, "cgName", cg.UniqueId)
 	}
 
 	return event, err
@@ -207,8 +221,7 @@ func (dcgs *DisaggregatedComputeGroupsController) reconcileInternalService(ctx c
 	}
 
 	// Existing service is not headless — delete and recreate.
-	klog.Infof("reconcileInternalService existing service %s/%s is not headless (clusterIP=%s), deleting and recreating as headless.",
-		existingSvc.Namespace, existingSvc.Name, existingSvc.Spec.ClusterIP)
+	klog.FromContext(ctx).Info("reconcileInternalService existing service is not headless, deleting and recreating as headless", "namespace", existingSvc.Namespace, "name", existingSvc.Name, "clusterIP", existingSvc.Spec.ClusterIP)
 	if err = k8s.DeleteService(ctx, dcgs.K8sclient, svc.Namespace, svc.Name); err != nil {
 		return err
 	}
@@ -235,25 +248,25 @@ func (dcgs *DisaggregatedComputeGroupsController) reconcileStatefulset(ctx conte
 			//creating use the function to assign equal annotation.
 			return resource.StatefulsetDeepEqualWithKey(new, est, dv1.DisaggregatedSpecHashValueAnnotation, false)
 		}, ndf); err != nil {
-			klog.Errorf("disaggregatedComputeGroupsController reconcileStatefulset create statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcileStatefulset create statefulset failed", "namespace", st.Namespace, "name", st.Name)
 			return &sc.Event{Type: sc.EventWarning, Reason: sc.CGCreateResourceFailed, Message: err.Error()}, err
 		}
 
 		return nil, nil
 	} else if err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcileStatefulset get statefulset failed, namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcileStatefulset get statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return nil, err
 	}
 
 	if !volumeClaimTemplatesEqual(st.Spec.VolumeClaimTemplates, est.Spec.VolumeClaimTemplates) {
 		msg := fmt.Sprintf("compute group %s storage template is immutable after creation; modifying BE file_cache_path or persistent volume settings requires recreating the compute group", cg.UniqueId)
-		klog.Errorf("disaggregatedComputeGroupsController reconcileStatefulset immutable storage template changed, namespace=%s name=%s, err=%s", st.Namespace, st.Name, msg)
+		klog.FromContext(ctx).Error(nil, "disaggregatedComputeGroupsController reconcileStatefulset immutable storage template changed", "namespace", st.Namespace, "name", st.Name, "msg", msg)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGStorageTemplateImmutable, Message: msg}, errors.New(msg)
 	}
 
 	err := dcgs.preApplyStatefulSet(ctx, st, &est, cluster, cg)
 	if err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcileStatefulset preApplyStatefulSet namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcileStatefulset preApplyStatefulSet failed", "namespace", st.Namespace, "name", st.Name)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGSqlExecFailed, Message: err.Error()}, err
 	}
 
@@ -277,7 +290,7 @@ func (dcgs *DisaggregatedComputeGroupsController) reconcileStatefulset(ctx conte
 			// ensure OnDelete strategy to prevent K8s from auto-deleting pods.
 			skipApply, gracefulErr := dcgs.gracefulRolloutReconcile(ctx, dcgs.RestConfig, st, &est, cluster, cg, cgStatus)
 			if gracefulErr != nil {
-				klog.Errorf("reconcileStatefulset gracefulRolloutReconcile failed: %v", gracefulErr)
+				klog.FromContext(ctx).Error(gracefulErr, "reconcileStatefulset gracefulRolloutReconcile failed")
 				// Continue with normal reconcile on error, don't block.
 			}
 			if skipApply {
@@ -312,7 +325,7 @@ func (dcgs *DisaggregatedComputeGroupsController) reconcileStatefulset(ctx conte
 		return businessEqual && gracefulStatefulSetControlEqual(new, est)
 
 	}, ndf); err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController reconcileStatefulset apply statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController reconcileStatefulset apply statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGApplyResourceFailed, Message: err.Error()}, err
 	}
 	return nil, nil
@@ -406,7 +419,7 @@ func (dcgs *DisaggregatedComputeGroupsController) clearStatefulSetRollingUpdate(
 	st.Namespace = namespace
 	st.Name = name
 	if err := dcgs.K8sclient.Patch(ctx, st, client.RawPatch(types.MergePatchType, patch)); err != nil {
-		klog.Errorf("clearStatefulSetRollingUpdate: failed to clear rollingUpdate for StatefulSet %s/%s: %v", namespace, name, err)
+		klog.FromContext(ctx).Error(err, "clearStatefulSetRollingUpdate: failed to clear rollingUpdate of statefulset", "namespace", namespace, "name", name)
 	}
 }
 
@@ -425,7 +438,7 @@ func (dcgs *DisaggregatedComputeGroupsController) validateDuplicated(cgs []dv1.C
 }
 
 // checking the cg name compliant with regular expression or not.
-func (dcgs *DisaggregatedComputeGroupsController) validateRegex(cgs []dv1.ComputeGroup) (string, bool) {
+func (dcgs *DisaggregatedComputeGroupsController) validateRegex(ctx context.Context, cgs []dv1.ComputeGroup) (string, bool) {
 	var regStr = ""
 	for _, cg := range cgs {
 		res, err := regexp.Match(compute_group_name_regex, []byte(cg.UniqueId))
@@ -434,7 +447,7 @@ func (dcgs *DisaggregatedComputeGroupsController) validateRegex(cgs []dv1.Comput
 		}
 		//for debugging, output the error in log
 		if err != nil {
-			klog.Errorf("disaggregatedComputeGroupsController validateRegex compute group name %s failed, err=%s", cg.UniqueId, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController validateRegex compute group failed", "name", cg.UniqueId)
 		}
 	}
 	if regStr != "" {
@@ -469,12 +482,12 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearResources(ctx context.Con
 	cls := dcgs.GetCG2LayerCommonSchedulerLabels(ddc.Name)
 	svcs, err := k8s.ListServicesInNamespace(ctx, dcgs.K8sclient, ddc.Namespace, cls)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController ListServicesInNamespace failed, dorisdisaggregatedcluster name=%s", ddc.Name)
+		klog.FromContext(ctx).Error(nil, "DisaggregatedComputeGroupsController ListServicesInNamespace failed", "namespace", ddc.Namespace, "name", ddc.Name)
 		return false, err
 	}
 	stss, err := k8s.ListStatefulsetInNamespace(ctx, dcgs.K8sclient, ddc.Namespace, cls)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController ListStatefulsetInNamespace failed, dorisdisaggregatedcluster name=%s", ddc.Name)
+		klog.FromContext(ctx).Error(nil, "DisaggregatedComputeGroupsController ListStatefulsetInNamespace failed", "namespace", ddc.Namespace, "name", ddc.Name)
 		return false, err
 	}
 
@@ -496,7 +509,7 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearResources(ctx context.Con
 	for i := range eCGs {
 		err = dcgs.ClearStatefulsetUnusedPVCs(ctx, ddc, eCGs[i])
 		if err != nil {
-			klog.Errorf("disaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs clear ComputeGroup reduced replicas PVC failed, namespace=%s, ddc name=%s, uniqueId=%s err=%s", ddc.Namespace, ddc.Name, eCGs[i].UniqueId, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs clear ComputeGroup reduced replicas PVC failed", "namespace", ddc.Namespace, "name", ddc.Name, "uniqueId", eCGs[i].UniqueId)
 		}
 	}
 
@@ -507,7 +520,7 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearResources(ctx context.Con
 		}
 		err = dcgs.ClearStatefulsetUnusedPVCs(ctx, ddc, fakeCgs)
 		if err != nil {
-			klog.Errorf("disaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs clear deleted compute group failed, namespace=%s, ddc name=%s, uniqueId=%s err=%s", ddc.Namespace, ddc.Name, uniqueId, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs clear deleted compute group failed", "namespace", ddc.Namespace, "name", ddc.Name, "uniqueId", uniqueId)
 		}
 	}
 
@@ -518,7 +531,7 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearResources(ctx context.Con
 func (dcgs *DisaggregatedComputeGroupsController) clearStatefulsets(ctx context.Context, stsNames []string, ddc *dv1.DorisDisaggregatedCluster) error {
 	for _, name := range stsNames {
 		if err := k8s.DeleteStatefulset(ctx, dcgs.K8sclient, ddc.Namespace, name); err != nil {
-			klog.Errorf("DisaggregatedComputeGroupsController clear statefulset failed, namespace=%s, name =%s, err=%s", ddc.Namespace, name, err.Error())
+			klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController clear statefulset failed", "namespace", ddc.Namespace, "name", name)
 			return err
 		}
 	}
@@ -528,7 +541,7 @@ func (dcgs *DisaggregatedComputeGroupsController) clearStatefulsets(ctx context.
 func (dcgs *DisaggregatedComputeGroupsController) clearSvcs(ctx context.Context, svcNames []string, ddc *dv1.DorisDisaggregatedCluster) error {
 	for _, name := range svcNames {
 		if err := k8s.DeleteService(ctx, dcgs.K8sclient, ddc.Namespace, name); err != nil {
-			klog.Errorf("DisaggregatedComputeGroupsController clear service failed, namespace=%s, name =%s, err=%s", ddc.Namespace, name, err.Error())
+			klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController clear service failed", "namespace", ddc.Namespace, "name", name)
 			return err
 		}
 	}
@@ -542,7 +555,7 @@ func (dcgs *DisaggregatedComputeGroupsController) clearCGInDorisMeta(ctx context
 
 	sqlClient, err := dcgs.getMasterSqlClient(ctx, ddc)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController clearCGInDorisMeta dropCGBySQLClient getMasterSqlClient failed: %s", err.Error())
+		klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController clearCGInDorisMeta dropCGBySQLClient getMasterSqlClient failed")
 		dcgs.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.CGSqlExecFailed), "computeGroupSync dropCGBySQLClient failed: "+err.Error())
 		return err
 	}
@@ -550,9 +563,9 @@ func (dcgs *DisaggregatedComputeGroupsController) clearCGInDorisMeta(ctx context
 
 	for _, cgid := range cgids {
 		//clear cg, the keepAmount = 0
-		err = dcgs.scaledOutBENodesByDrop(sqlClient, cgid, 0)
+		err = dcgs.scaledOutBENodesByDrop(ctx, sqlClient, cgid, 0)
 		if err != nil {
-			klog.Errorf("DisaggregatedComputeGroupsController clearCGInDorisMeta dropCGBySQLClient failed: %s", err.Error())
+			klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController clearCGInDorisMeta dropCGBySQLClient failed")
 			dcgs.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.CGSqlExecFailed), "computeGroupSync dropCGBySQLClient failed: "+err.Error())
 			return err
 		}
@@ -644,13 +657,17 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearStatefulsetUnusedPVCs(ctx
 	if cg == nil {
 		return nil
 	}
+	// the pvcs of pods removed by scaling down are retained.
+	if !resource.DeleteDisaggregatedPVCWhenScaled(cg.PersistentVolumeClaimRetentionPolicy) {
+		return nil
+	}
 
 	var clearPVC []string
 	//we should use statefulset replicas for avoiding the phase=scaleDown, when phase `scaleDown` cg' replicas is less than statefuslet.
 	stsName := ddc.GetCGStatefulsetName(cg)
 	sts, err := k8s.GetStatefulSet(ctx, dcgs.K8sclient, ddc.Namespace, stsName)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs get statefulset namespace=%s, name=%s, failed, err=%s", ddc.Namespace, stsName, err.Error())
+		klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs get statefulset failed", "namespace", ddc.Namespace, "name", stsName)
 		//waiting next reconciling.
 		return nil
 	}
@@ -659,14 +676,14 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearStatefulsetUnusedPVCs(ctx
 		pvcName := pvc.Name
 		sl := strings.Split(pvcName, stsName+"-")
 		if len(sl) != 2 {
-			klog.Errorf("DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs namespace %s name %s not format pvc name format.", ddc.Namespace, pvcName)
+			klog.FromContext(ctx).Error(nil, "DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs pvc name is not match statefulset format", "namespace", ddc.Namespace, "name", pvcName)
 			continue
 		}
 		var index int64
 		var perr error
 		index, perr = strconv.ParseInt(sl[1], 10, 32)
 		if perr != nil {
-			klog.Errorf("DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs namespace %s name %s index parse failed, err=%s", ddc.Namespace, pvcName, perr.Error())
+			klog.FromContext(ctx).Error(perr, "DisaggregatedComputeGroupsController ClearStatefulsetUnusedPVCs index parse failed", "namespace", ddc.Namespace, "name", pvcName)
 			continue
 		}
 		if int32(index) >= replicas {
@@ -678,7 +695,7 @@ func (dcgs *DisaggregatedComputeGroupsController) ClearStatefulsetUnusedPVCs(ctx
 	for _, pvcName := range clearPVC {
 		if err = k8s.DeletePVC(ctx, dcgs.K8sclient, ddc.Namespace, pvcName, pvcLabels); err != nil {
 			dcgs.K8srecorder.Event(ddc, string(sc.EventWarning), sc.PVCDeleteFailed, err.Error())
-			klog.Errorf("ClearStatefulsetUnusedPVCs deletePVCs failed: namespace %s, name %s delete pvc %s, err: %s .", ddc.Namespace, pvcName, pvcName, err.Error())
+			klog.FromContext(ctx).Error(err, "ClearStatefulsetUnusedPVCs deletePVCs delete pvc failed", "namespace", ddc.Namespace, "name", pvcName)
 			mergeError = utils.MergeError(mergeError, err)
 		}
 	}
@@ -693,7 +710,7 @@ func (dcgs *DisaggregatedComputeGroupsController) UpdateComponentStatus(obj clie
 	ddc := obj.(*dv1.DorisDisaggregatedCluster)
 	cgss := ddc.Status.ComputeGroupStatuses
 	if len(cgss) == 0 {
-		klog.Errorf("disaggregatedComputeGroupsController updateComponentStatus compute group status is empty!")
+		klog.Background().Error(nil, "disaggregatedComputeGroupsController updateComponentStatus compute group status is empty")
 		return nil
 	}
 
@@ -718,7 +735,7 @@ func (dcgs *DisaggregatedComputeGroupsController) UpdateComponentStatus(obj clie
 
 	for _, cgs := range ddc.Status.ComputeGroupStatuses {
 		if cgs.ComputeGroupId == "" {
-			dcgs.recordComputeGroupIds(ddc)
+			dcgs.recordComputeGroupIds(context.Background(), ddc)
 			break
 		}
 	}
@@ -729,7 +746,7 @@ func (dcgs *DisaggregatedComputeGroupsController) UpdateComponentStatus(obj clie
 		if cgs.Phase == dv1.Ready {
 			fullAvailableCount++
 		}
-		if cgs.AvailableReplicas > 0 {
+		if cgs.AvailableStatus == dv1.Available {
 			availableCount++
 		}
 	}
@@ -742,7 +759,7 @@ func (dcgs *DisaggregatedComputeGroupsController) UpdateComponentStatus(obj clie
 	return errors.New(errMs)
 }
 
-func (dcgs *DisaggregatedComputeGroupsController) recordComputeGroupIds(ddc *dv1.DorisDisaggregatedCluster) error {
+func (dcgs *DisaggregatedComputeGroupsController) recordComputeGroupIds(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) error {
 	// get user and password
 	adminUserName, password := dcgs.GetManagementAdminUserAndPWD(context.Background(), ddc)
 
@@ -762,14 +779,14 @@ func (dcgs *DisaggregatedComputeGroupsController) recordComputeGroupIds(ddc *dv1
 
 	db, err := mysql.NewDorisSqlDB(cfg, tlsConfig, secret)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController recordComputeGroupIds new doris client failed,err=%s", err.Error())
+		klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController recordComputeGroupIds new doris client failed")
 		return err
 	}
 	defer db.Close()
 
 	backends, err := db.ShowBackends()
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController recordComputeGroupIds show backends failed, err=%s", err.Error())
+		klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController recordComputeGroupIds show backends failed")
 		return err
 	}
 
@@ -778,12 +795,12 @@ func (dcgs *DisaggregatedComputeGroupsController) recordComputeGroupIds(ddc *dv1
 		tags := map[string]string{}
 		err = json.Unmarshal([]byte(backend.Tag), &tags)
 		if err != nil {
-			klog.Errorf("DisaggregatedComputeGroupsController recordComputeGroupIds backend tag stirng to map failed, tag: %s, err: %s", backend.Tag, err.Error())
+			klog.FromContext(ctx).Error(err, "DisaggregatedComputeGroupsController recordComputeGroupIds backend tag string to map failed", "tag", backend.Tag)
 			return err
 		}
 		if _, ok := tags[mysql.COMPUTE_GROUP_ID]; !ok {
 			errMsg := fmt.Sprintf("DisaggregatedComputeGroupsController recordComputeGroupIds backend tag get compute_group_name failed, tag: %s, err: no compute_group_id field found ", backend.Tag)
-			klog.Errorf(errMsg)
+			klog.FromContext(ctx).Error(nil, "DisaggregatedComputeGroupsController recordComputeGroupIds backend tag get compute_group_name failed, no compute_group_id field found", "tag", backend.Tag)
 			return errors.New(errMsg)
 		}
 
@@ -804,7 +821,7 @@ func (dcgs *DisaggregatedComputeGroupsController) updateCGStatus(ddc *dv1.DorisD
 	stfName := cgs.StatefulsetName
 	sts, err := k8s.GetStatefulSet(context.Background(), dcgs.K8sclient, ddc.Namespace, stfName)
 	if err != nil {
-		klog.Errorf("DisaggregatedComputeGroupsController updateCGStatus get statefulset %s failed, err=%s", stfName, err.Error())
+		klog.Background().Error(err, "DisaggregatedComputeGroupsController updateCGStatus get failed", "statefulset", stfName)
 		return err
 	}
 
@@ -843,6 +860,11 @@ func (dcgs *DisaggregatedComputeGroupsController) updateCGStatus(ddc *dv1.DorisD
 	}
 
 	cgs.AvailableReplicas = availableReplicas
+	cgs.AvailableStatus = dv1.UnAvailable
+	// the compute group can not serve queries when the synthetic queries failed continuously, though the pods are ready.
+	if availableReplicas > 0 && !sc.QueryProbeFailing(ddc.Spec.QueryProbe, cgs.QueryProbe) {
+		cgs.AvailableStatus = dv1.Available
+	}
 	if hasGracefulAction(sts) {
 		switch cgs.Phase {
 		case dv1.Ready:
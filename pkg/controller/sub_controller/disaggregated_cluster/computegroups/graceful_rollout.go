@@ -26,6 +26,9 @@ import (
 	"time"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	"github.com/apache/doris-operator/pkg/common/logging"
+	"github.com/apache/doris-operator/pkg/common/metrics"
+	"github.com/apache/doris-operator/pkg/common/tracing"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/mysql"
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
@@ -92,7 +95,7 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 	cgStatus.GracefulAction = nil
 
 	// Determine what graceful action is needed.
-	action := dcgs.detectGracefulAction(st, est, cgStatus)
+	action := dcgs.detectGracefulAction(ctx, st, est, cgStatus)
 	storedAction, err := getGracefulAction(est)
 	if err != nil {
 		return true, err
@@ -108,7 +111,7 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 	if action != nil && storedAction == nil {
 		supported, reason := dcgs.supportsTerminatingSentinel(ctx, restConfig, cluster, cg, cgStatus, est, action)
 		if !supported {
-			klog.Warningf("gracefulRolloutReconcile: graceful action disabled for cg=%s type=%s: %s", cg.UniqueId, action.Type, reason)
+			klog.FromContext(ctx).Info("gracefulRolloutReconcile: graceful action disabled", "cg", cg.UniqueId, "type", action.Type, "reason", reason)
 			dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulActionDisabled),
 				"Graceful %s disabled for compute group %s: %s", action.Type, cg.UniqueId, reason)
 			return false, nil
@@ -121,19 +124,18 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 		case dv1.GracefulActionDelete:
 			cgStatus.Phase = dv1.GracefulDeleting
 		}
-		klog.Infof("gracefulRolloutReconcile: starting graceful action type=%s for cg=%s", action.Type, cg.UniqueId)
+		klog.FromContext(ctx).Info("gracefulRolloutReconcile: starting graceful action", "type", action.Type, "cg", cg.UniqueId)
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulDrainStarted),
 			"Starting graceful %s for compute group %s", action.Type, cg.UniqueId)
 		prepareGracefulStatefulSet(st, est, action)
-		setGracefulAction(st, action)
+		setGracefulAction(ctx, st, action)
 		return true, nil
 	}
 
 	// If there's a higher-priority action pending, abort current.
 	if action != nil && storedAction != nil && action.Type != storedAction.Type {
 		if gracefulActionPriority(action.Type) > gracefulActionPriority(storedAction.Type) {
-			klog.Infof("gracefulRolloutReconcile: aborting %s in favor of higher-priority %s for cg=%s",
-				storedAction.Type, action.Type, cg.UniqueId)
+			klog.FromContext(ctx).Info("gracefulRolloutReconcile: aborting graceful action in favor of a higher priority one", "aborting", storedAction.Type, "higherPriority", action.Type, "cg", cg.UniqueId)
 			storedAction = action
 		}
 	}
@@ -153,15 +155,14 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 	}
 
 	if ga.Type == dv1.GracefulActionRollingUpdate {
-		dcgs.refreshRollingUpdateTargetRevision(est, ga)
+		dcgs.refreshRollingUpdateTargetRevision(ctx, est, ga)
 	}
 
 	// Run the state machine.
 	err = dcgs.runGracefulStateMachine(ctx, restConfig, cluster, cg, cgStatus, est, ga)
 	if err != nil {
 		ga.LastMessage = err.Error()
-		klog.Errorf("gracefulRolloutReconcile: state machine error for cg=%s pod=%s phase=%s: %v",
-			cg.UniqueId, ga.CurrentPod, ga.Phase, err)
+		klog.FromContext(ctx).Error(err, "gracefulRolloutReconcile: state machine error", "cg", cg.UniqueId, "pod", ga.CurrentPod, "phase", ga.Phase)
 		return true, err
 	}
 
@@ -176,10 +177,10 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 				"Waiting for StatefulSet %s/%s outdated pods to be fully drained before finalizing graceful action (currentRevision=%s updateRevision=%s)",
 				est.Namespace, est.Name, est.Status.CurrentRevision, est.Status.UpdateRevision)
 			prepareGracefulStatefulSet(st, est, ga)
-			setGracefulAction(st, ga)
+			setGracefulAction(ctx, st, ga)
 			return true, nil
 		}
-		klog.Infof("gracefulRolloutReconcile: graceful action completed for cg=%s", cg.UniqueId)
+		klog.FromContext(ctx).Info("gracefulRolloutReconcile: graceful action completed", "cg", cg.UniqueId)
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulActionCompleted),
 			"Graceful %s completed for compute group %s", ga.Type, cg.UniqueId)
 		cgStatus.Phase = dv1.Reconciling
@@ -191,12 +192,13 @@ func (dcgs *DisaggregatedComputeGroupsController) gracefulRolloutReconcile(
 
 	// Still in progress, skip normal apply.
 	prepareGracefulStatefulSet(st, est, ga)
-	setGracefulAction(st, ga)
+	setGracefulAction(ctx, st, ga)
 	return true, nil
 }
 
 // detectGracefulAction determines if a new graceful action is needed.
 func (dcgs *DisaggregatedComputeGroupsController) detectGracefulAction(
+	ctx context.Context,
 	st *appv1.StatefulSet,
 	est *appv1.StatefulSet,
 	cgStatus *dv1.ComputeGroupStatus,
@@ -231,13 +233,13 @@ func (dcgs *DisaggregatedComputeGroupsController) detectGracefulAction(
 		if _, _, found := dcgs.selectNextRollingUpdatePod(context.Background(), cgStatus.StatefulsetName, est, recoverAction); found {
 			return recoverAction
 		}
-		klog.Infof("detectGracefulAction: skip recovering rolling update for statefulset %s because no outdated pods remain", est.Name)
+		klog.FromContext(ctx).Info("detectGracefulAction: skip recovering rolling update because no outdated pods remain", "statefulset", est.Name)
 	}
 
 	return nil
 }
 
-// runGracefulStateMachine executes the graceful action state machine.
+// runGracefulStateMachine executes the graceful action state machine, every phase handler is traced in a span.
 func (dcgs *DisaggregatedComputeGroupsController) runGracefulStateMachine(
 	ctx context.Context,
 	restConfig *rest.Config,
@@ -246,6 +248,40 @@ func (dcgs *DisaggregatedComputeGroupsController) runGracefulStateMachine(
 	cgStatus *dv1.ComputeGroupStatus,
 	est *appv1.StatefulSet,
 	ga *dv1.GracefulAction,
+) error {
+	ctx, span := tracing.Start(ctx, "GracefulAction."+string(ga.Phase), append(tracing.ClusterAttributes(metrics.DorisDisaggregatedClusterKind, cluster.Namespace, cluster.Name),
+		tracing.ComputeGroupKey.String(cg.UniqueId), tracing.PhaseKey.String(string(ga.Phase)))...)
+	ctx, _ = logging.IntoContext(ctx, logging.ComputeGroupKey, cg.UniqueId, "gracefulPhase", ga.Phase)
+	phase := ga.Phase
+	err := dcgs.runGracefulPhase(ctx, restConfig, cluster, cg, cgStatus, est, ga)
+	tracing.End(span, err)
+	dcgs.recordGracefulPhaseChange(cluster, cg, phase, ga)
+	return err
+}
+
+// recordGracefulPhaseChange records an event when the graceful action moved to another phase.
+func (dcgs *DisaggregatedComputeGroupsController) recordGracefulPhaseChange(cluster *dv1.DorisDisaggregatedCluster, cg *dv1.ComputeGroup, from dv1.GracefulActionPhase, ga *dv1.GracefulAction) {
+	if ga.Phase == from {
+		return
+	}
+	if ga.Phase == dv1.GracefulPhaseFailed {
+		dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulActionFailed),
+			"Graceful %s of compute group %s failed in phase %s: %s", ga.Type, cg.UniqueId, from, ga.LastMessage)
+		return
+	}
+	dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulPhaseChanged),
+		"Graceful %s of compute group %s moved from phase %s to %s, pod=%s", ga.Type, cg.UniqueId, from, ga.Phase, ga.CurrentPod)
+}
+
+// runGracefulPhase executes the handler of current phase of graceful action.
+func (dcgs *DisaggregatedComputeGroupsController) runGracefulPhase(
+	ctx context.Context,
+	restConfig *rest.Config,
+	cluster *dv1.DorisDisaggregatedCluster,
+	cg *dv1.ComputeGroup,
+	cgStatus *dv1.ComputeGroupStatus,
+	est *appv1.StatefulSet,
+	ga *dv1.GracefulAction,
 ) error {
 	switch ga.Phase {
 	case dv1.GracefulPhaseTriggerDrain:
@@ -276,7 +312,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleTriggerDrain(
 	ga *dv1.GracefulAction,
 ) error {
 	if ga.Type == dv1.GracefulActionRollingUpdate {
-		dcgs.refreshRollingUpdateTargetRevision(est, ga)
+		dcgs.refreshRollingUpdateTargetRevision(ctx, est, ga)
 	}
 
 	// If no current pod selected, pick the next one.
@@ -305,7 +341,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleTriggerDrain(
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Pod already deleted, move to next phase.
-			klog.Infof("handleTriggerDrain: pod %s already deleted, moving to next phase", ga.CurrentPod)
+			klog.FromContext(ctx).Info("handleTriggerDrain: pod already deleted, moving to next phase", "pod", ga.CurrentPod)
 			if ga.Type == dv1.GracefulActionRollingUpdate {
 				ga.Phase = dv1.GracefulPhaseWaitPodReady
 			} else {
@@ -334,8 +370,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleTriggerDrain(
 			ga.InitialBackendEpoch = epoch
 		}
 	} else {
-		klog.Warningf("handleTriggerDrain: failed to capture initial backend generation for pod %s uid=%s containerID=%s: %v",
-			ga.CurrentPod, ga.InitialPodUID, ga.InitialContainerID, backendErr)
+		klog.FromContext(ctx).Info("handleTriggerDrain: failed to capture initial backend generation", "err", backendErr, "pod", ga.CurrentPod, "uid", ga.InitialPodUID, "containerID", ga.InitialContainerID)
 		ga.InitialBackendStartTime = ""
 		ga.InitialBackendEpoch = ""
 	}
@@ -357,24 +392,20 @@ func (dcgs *DisaggregatedComputeGroupsController) handleTriggerDrain(
 			[]string{"sh", "-c", fmt.Sprintf("mkdir -p %s && touch %s", gracefulRuntimeMountPath, beTerminatingSentinelPath)},
 			execTimeout)
 		if sentinelErr != nil {
-			klog.Warningf("handleTriggerDrain: failed to write terminating sentinel on pod %s uid=%s containerID=%s: %v, stdout=%s, stderr=%s",
-				ga.CurrentPod, ga.InitialPodUID, ga.InitialContainerID, sentinelErr, sentinelStdout, sentinelStderr)
+			klog.FromContext(ctx).Info("handleTriggerDrain: failed to write terminating sentinel", "err", sentinelErr, "pod", ga.CurrentPod, "uid", ga.InitialPodUID, "containerID", ga.InitialContainerID, "stdout", sentinelStdout, "stderr", sentinelStderr)
 		} else {
 			ga.SentinelWritten = true
-			klog.Infof("handleTriggerDrain: wrote terminating sentinel on pod %s uid=%s containerID=%s path=%s",
-				ga.CurrentPod, ga.InitialPodUID, ga.InitialContainerID, beTerminatingSentinelPath)
+			klog.FromContext(ctx).Info("handleTriggerDrain: wrote terminating sentinel", "pod", ga.CurrentPod, "uid", ga.InitialPodUID, "containerID", ga.InitialContainerID, "path", beTerminatingSentinelPath)
 		}
 
-		klog.Infof("handleTriggerDrain: executing stop_be.sh --grace on pod %s uid=%s containerID=%s oldStartTime=%s oldEpoch=%s sentinelWritten=%t",
-			ga.CurrentPod, ga.InitialPodUID, ga.InitialContainerID, ga.InitialBackendStartTime, ga.InitialBackendEpoch, ga.SentinelWritten)
+		klog.FromContext(ctx).Info("handleTriggerDrain: executing stop_be.sh --grace", "pod", ga.CurrentPod, "uid", ga.InitialPodUID, "containerID", ga.InitialContainerID, "oldStartTime", ga.InitialBackendStartTime, "oldEpoch", ga.InitialBackendEpoch, "sentinelWritten", ga.SentinelWritten)
 		stdout, stderr, execErr := execInPod(ctx, restConfig,
 			cluster.Namespace, ga.CurrentPod, beMainContainerName,
 			[]string{stopBEGraceCommand, stopBEGraceArg},
 			execTimeout)
 
 		if execErr != nil {
-			klog.Warningf("handleTriggerDrain: exec failed on pod %s uid=%s containerID=%s: %v, stdout=%s, stderr=%s",
-				ga.CurrentPod, ga.InitialPodUID, ga.InitialContainerID, execErr, stdout, stderr)
+			klog.FromContext(ctx).Info("handleTriggerDrain: exec stop_be.sh failed", "err", execErr, "pod", ga.CurrentPod, "uid", ga.InitialPodUID, "containerID", ga.InitialContainerID, "stdout", stdout, "stderr", stderr)
 			dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulDrainExecFailed),
 				"Failed to exec stop_be.sh --grace on pod %s: %v", ga.CurrentPod, execErr)
 			// Even if exec fails, proceed to WaitDrain to handle timeout or already-exiting BE.
@@ -400,7 +431,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitDrain(
 	pod, err := dcgs.getPod(ctx, cluster.Namespace, ga.CurrentPod)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			klog.Infof("handleWaitDrain: pod %s already gone", ga.CurrentPod)
+			klog.FromContext(ctx).Info("handleWaitDrain: pod already gone", "pod", ga.CurrentPod)
 			if ga.Type == dv1.GracefulActionRollingUpdate {
 				resetGracefulPhaseTimer(ga)
 				ga.Phase = dv1.GracefulPhaseWaitPodReady
@@ -414,8 +445,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitDrain(
 
 	// Check if main container has terminated.
 	if isContainerTerminated(pod, beMainContainerName) {
-		klog.Infof("handleWaitDrain: BE container terminated on pod %s uid=%s restartCount=%d lastState=%s",
-			ga.CurrentPod, string(pod.UID), getContainerRestartCount(pod, beMainContainerName), describeLastTerminatedState(pod, beMainContainerName))
+		klog.FromContext(ctx).Info("handleWaitDrain: BE container terminated", "pod", ga.CurrentPod, "uid", string(pod.UID), "restartCount", getContainerRestartCount(pod, beMainContainerName), "lastState", describeLastTerminatedState(pod, beMainContainerName))
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulDrainCompleted),
 			"Graceful drain completed on pod %s (container exited)", ga.CurrentPod)
 		ga.Phase = dv1.GracefulPhaseDeletePod
@@ -426,8 +456,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitDrain(
 	currentRestartCount := getContainerRestartCount(pod, beMainContainerName)
 	if currentRestartCount > ga.InitialRestartCount {
 		ga.RestartAnomalyDetected = true
-		klog.Infof("handleWaitDrain: restart anomaly on pod %s uid=%s (%d -> %d), oldContainerID=%s currentContainerID=%s lastState=%s",
-			ga.CurrentPod, string(pod.UID), ga.InitialRestartCount, currentRestartCount, ga.InitialContainerID, getContainerID(pod, beMainContainerName), describeLastTerminatedState(pod, beMainContainerName))
+		klog.FromContext(ctx).Info("handleWaitDrain: restart anomaly", "pod", ga.CurrentPod, "uid", string(pod.UID), "initialRestartCount", ga.InitialRestartCount, "currentRestartCount", currentRestartCount, "oldContainerID", ga.InitialContainerID, "currentContainerID", getContainerID(pod, beMainContainerName), "lastState", describeLastTerminatedState(pod, beMainContainerName))
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulDrainCompleted),
 			"Graceful drain completed on pod %s (container restarted by kubelet, restartCount %d -> %d)",
 			ga.CurrentPod, ga.InitialRestartCount, currentRestartCount)
@@ -437,9 +466,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitDrain(
 
 	// Check timeout.
 	if time.Now().After(ga.DeadlineAt.Time) {
-		klog.Warningf("handleWaitDrain: drain timeout reached for pod %s uid=%s containerID=%s restartCount=%d stateTerminated=%t lastState=%s deadline=%s now=%s sentinelWritten=%t",
-			ga.CurrentPod, string(pod.UID), getContainerID(pod, beMainContainerName), getContainerRestartCount(pod, beMainContainerName),
-			isContainerTerminated(pod, beMainContainerName), describeTerminationState(pod, beMainContainerName), ga.DeadlineAt.Format(time.RFC3339), time.Now().Format(time.RFC3339), ga.SentinelWritten)
+		klog.FromContext(ctx).Info("handleWaitDrain: drain timeout reached", "pod", ga.CurrentPod, "uid", string(pod.UID), "containerID", getContainerID(pod, beMainContainerName), "restartCount", getContainerRestartCount(pod, beMainContainerName), "stateTerminated", isContainerTerminated(pod, beMainContainerName), "lastState", describeTerminationState(pod, beMainContainerName), "deadline", ga.DeadlineAt.Format(time.RFC3339), "now", time.Now().Format(time.RFC3339), "sentinelWritten", ga.SentinelWritten)
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulDrainTimeout),
 			"Graceful drain timeout on pod %s, continuing with deletion", ga.CurrentPod)
 		ga.Phase = dv1.GracefulPhaseDeletePod
@@ -465,7 +492,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleDeletePod(
 	pod, err := dcgs.getPod(ctx, cluster.Namespace, ga.CurrentPod)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			klog.Infof("handleDeletePod: pod %s already deleted", ga.CurrentPod)
+			klog.FromContext(ctx).Info("handleDeletePod: pod already deleted", "pod", ga.CurrentPod)
 			dcgs.afterPodDeleted(ga, cluster, cg, cgStatus, est)
 			return nil
 		}
@@ -473,17 +500,16 @@ func (dcgs *DisaggregatedComputeGroupsController) handleDeletePod(
 	}
 
 	// Delete the pod.
-	klog.Infof("handleDeletePod: deleting pod %s uid=%s containerID=%s restartCount=%d deletionTimestamp=%v",
-		ga.CurrentPod, string(pod.UID), getContainerID(pod, beMainContainerName), getContainerRestartCount(pod, beMainContainerName), pod.DeletionTimestamp)
+	klog.FromContext(ctx).Info("handleDeletePod: deleting pod", "pod", ga.CurrentPod, "uid", string(pod.UID), "containerID", getContainerID(pod, beMainContainerName), "restartCount", getContainerRestartCount(pod, beMainContainerName), "deletionTimestamp", pod.DeletionTimestamp)
 	if err := dcgs.K8sclient.Delete(ctx, pod); err != nil {
 		if apierrors.IsNotFound(err) {
-			klog.Infof("handleDeletePod: pod %s uid=%s already deleted before delete call completed", ga.CurrentPod, string(pod.UID))
+			klog.FromContext(ctx).Info("handleDeletePod: already deleted before delete call completed", "pod", ga.CurrentPod, "uid", string(pod.UID))
 			dcgs.afterPodDeleted(ga, cluster, cg, cgStatus, est)
 			return nil
 		}
 		return fmt.Errorf("failed to delete pod %s: %w", ga.CurrentPod, err)
 	}
-	klog.Infof("handleDeletePod: delete accepted for pod %s uid=%s resourceVersion=%s", ga.CurrentPod, string(pod.UID), pod.ResourceVersion)
+	klog.FromContext(ctx).Info("handleDeletePod: delete accepted", "pod", ga.CurrentPod, "uid", string(pod.UID), "resourceVersion", pod.ResourceVersion)
 
 	dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulPodDeleted),
 		"Deleted pod %s during graceful %s", ga.CurrentPod, ga.Type)
@@ -521,7 +547,7 @@ func (dcgs *DisaggregatedComputeGroupsController) afterPodDeleted(
 func (dcgs *DisaggregatedComputeGroupsController) updateStatefulSetReplicas(ctx context.Context, est *appv1.StatefulSet, replicas int32) {
 	var current appv1.StatefulSet
 	if err := dcgs.K8sclient.Get(ctx, types.NamespacedName{Namespace: est.Namespace, Name: est.Name}, &current); err != nil {
-		klog.Errorf("updateStatefulSetReplicas: failed to get StatefulSet %s/%s: %v", est.Namespace, est.Name, err)
+		klog.FromContext(ctx).Error(err, "updateStatefulSetReplicas: failed to get statefulset", "namespace", est.Namespace, "name", est.Name)
 		return
 	}
 	if *current.Spec.Replicas == replicas {
@@ -529,10 +555,9 @@ func (dcgs *DisaggregatedComputeGroupsController) updateStatefulSetReplicas(ctx
 	}
 	current.Spec.Replicas = &replicas
 	if err := dcgs.K8sclient.Update(ctx, &current); err != nil {
-		klog.Errorf("updateStatefulSetReplicas: failed to update StatefulSet %s/%s replicas to %d: %v",
-			est.Namespace, est.Name, replicas, err)
+		klog.FromContext(ctx).Error(err, "updateStatefulSetReplicas: failed to update statefulset replicas", "namespace", est.Namespace, "name", est.Name, "replicas", replicas)
 	} else {
-		klog.Infof("updateStatefulSetReplicas: updated StatefulSet %s/%s replicas to %d", est.Namespace, est.Name, replicas)
+		klog.FromContext(ctx).Info("updateStatefulSetReplicas: updated statefulset replicas", "namespace", est.Namespace, "name", est.Name, "replicas", replicas)
 	}
 }
 
@@ -556,7 +581,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitPodReady(
 		if apierrors.IsNotFound(err) {
 			// Pod hasn't been recreated yet, wait.
 			if time.Now().After(ga.DeadlineAt.Time) {
-				klog.Warningf("handleWaitPodReady: timeout waiting for replacement pod %s to be created, extending deadline", ga.CurrentPod)
+				klog.FromContext(ctx).Info("handleWaitPodReady: timeout waiting for replacement to be created, extending deadline", "pod", ga.CurrentPod)
 				dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulReplacementReady),
 					"Timed out waiting for replacement pod %s to be created, continuing to wait", ga.CurrentPod)
 				now := metav1.Now()
@@ -574,9 +599,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitPodReady(
 	if k8s.PodIsReady(&pod.Status) {
 		ga.ReplacementPodUID = string(pod.UID)
 		ga.ReplacementContainerID = getContainerID(pod, beMainContainerName)
-		klog.Infof("handleWaitPodReady: replacement pod %s is ready uid=%s containerID=%s podIP=%s restartCount=%d creationTimestamp=%s nodeName=%s revisionHash=%s",
-			ga.CurrentPod, ga.ReplacementPodUID, ga.ReplacementContainerID, pod.Status.PodIP, getContainerRestartCount(pod, beMainContainerName),
-			pod.CreationTimestamp.Format(time.RFC3339), pod.Spec.NodeName, pod.Labels[resource.POD_CONTROLLER_REVISION_HASH_KEY])
+		klog.FromContext(ctx).Info("handleWaitPodReady: replacement is ready", "pod", ga.CurrentPod, "uid", ga.ReplacementPodUID, "containerID", ga.ReplacementContainerID, "podIP", pod.Status.PodIP, "restartCount", getContainerRestartCount(pod, beMainContainerName), "creationTimestamp", pod.CreationTimestamp.Format(time.RFC3339), "nodeName", pod.Spec.NodeName, "revisionHash", pod.Labels[resource.POD_CONTROLLER_REVISION_HASH_KEY])
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulReplacementReady),
 			"Replacement pod %s is ready", ga.CurrentPod)
 
@@ -586,7 +609,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitPodReady(
 	}
 
 	if time.Now().After(ga.DeadlineAt.Time) {
-		klog.Warningf("handleWaitPodReady: timeout waiting for replacement pod %s to become ready, extending deadline", ga.CurrentPod)
+		klog.FromContext(ctx).Info("handleWaitPodReady: timeout waiting for replacement to become ready, extending deadline", "pod", ga.CurrentPod)
 		dcgs.K8srecorder.Eventf(cluster, string(sc.EventWarning), string(sc.GracefulReplacementReady),
 			"Timed out waiting for replacement pod %s to become ready, continuing to wait", ga.CurrentPod)
 		now := metav1.Now()
@@ -600,7 +623,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitPodReady(
 	return nil
 }
 
-func (dcgs *DisaggregatedComputeGroupsController) refreshRollingUpdateTargetRevision(est *appv1.StatefulSet, ga *dv1.GracefulAction) {
+func (dcgs *DisaggregatedComputeGroupsController) refreshRollingUpdateTargetRevision(ctx context.Context, est *appv1.StatefulSet, ga *dv1.GracefulAction) {
 	if ga == nil || ga.Type != dv1.GracefulActionRollingUpdate {
 		return
 	}
@@ -608,8 +631,7 @@ func (dcgs *DisaggregatedComputeGroupsController) refreshRollingUpdateTargetRevi
 		return
 	}
 	if ga.TargetRevision != "" && ga.TargetRevision != est.Status.UpdateRevision {
-		klog.Infof("refreshRollingUpdateTargetRevision: statefulset %s/%s target revision changed from %s to %s",
-			est.Namespace, est.Name, ga.TargetRevision, est.Status.UpdateRevision)
+		klog.FromContext(ctx).Info("refreshRollingUpdateTargetRevision: target revision changed", "namespace", est.Namespace, "name", est.Name, "targetRevision", ga.TargetRevision, "updateRevision", est.Status.UpdateRevision)
 	}
 	ga.TargetRevision = est.Status.UpdateRevision
 }
@@ -631,7 +653,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitBEAlive(
 	backend, err := dcgs.getBackendByPodName(ctx, cluster, cgStatus, ga.CurrentPod)
 	if err != nil {
 		if !ga.DeadlineAt.IsZero() && time.Now().After(ga.DeadlineAt.Time) {
-			klog.Warningf("handleWaitBEAlive: timeout waiting for backend %s to appear in FE, advancing rollout", ga.CurrentPod)
+			klog.FromContext(ctx).Info("handleWaitBEAlive: timeout waiting for backend to appear in FE, advancing rollout", "backend", ga.CurrentPod)
 			ga.LastMessage = fmt.Sprintf("Timed out waiting for backend %s to appear in FE, forcing advance", ga.CurrentPod)
 			dcgs.advanceToNextPod(ga)
 			return nil
@@ -643,7 +665,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitBEAlive(
 	shutdown, err := backendIsShutdown(backend)
 	if err != nil {
 		if !ga.DeadlineAt.IsZero() && time.Now().After(ga.DeadlineAt.Time) {
-			klog.Warningf("handleWaitBEAlive: timeout waiting for parseable backend status on %s, advancing rollout", ga.CurrentPod)
+			klog.FromContext(ctx).Info("handleWaitBEAlive: timeout waiting for parseable backend status, advancing rollout", "backend", ga.CurrentPod)
 			ga.LastMessage = fmt.Sprintf("Timed out waiting for backend %s status to become parseable, forcing advance", ga.CurrentPod)
 			dcgs.advanceToNextPod(ga)
 			return nil
@@ -669,8 +691,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitBEAlive(
 			ga.StableBackendObservations++
 		}
 		if ga.StableBackendObservations >= requiredStableBackendObservations {
-			klog.Infof("handleWaitBEAlive: backend %s accepted new generation oldStartTime=%s newStartTime=%s oldEpoch=%s newEpoch=%s stableObservations=%d restartAnomaly=%t",
-				ga.CurrentPod, ga.InitialBackendStartTime, ga.ReplacementBackendStartTime, ga.InitialBackendEpoch, ga.ReplacementBackendEpoch, ga.StableBackendObservations, ga.RestartAnomalyDetected)
+			klog.FromContext(ctx).Info("handleWaitBEAlive: accepted new generation", "backend", ga.CurrentPod, "oldStartTime", ga.InitialBackendStartTime, "newStartTime", ga.ReplacementBackendStartTime, "oldEpoch", ga.InitialBackendEpoch, "newEpoch", ga.ReplacementBackendEpoch, "stableObservations", ga.StableBackendObservations, "restartAnomaly", ga.RestartAnomalyDetected)
 			dcgs.K8srecorder.Eventf(cluster, string(sc.EventNormal), string(sc.GracefulReplacementReady),
 				"Backend %s is alive in FE with new generation", ga.CurrentPod)
 			dcgs.advanceToNextPod(ga)
@@ -679,8 +700,7 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitBEAlive(
 		ga.LastMessage = fmt.Sprintf(
 			"Waiting for backend %s new generation to stabilize in FE (alive=%t shutdown=%t oldStartTime=%s newStartTime=%s oldEpoch=%s newEpoch=%s stableObservations=%d/%d)",
 			ga.CurrentPod, backend.Alive, shutdown, ga.InitialBackendStartTime, currentStartTime, ga.InitialBackendEpoch, currentEpoch, ga.StableBackendObservations, requiredStableBackendObservations)
-		klog.Infof("handleWaitBEAlive: backend %s observed candidate new generation alive=%t shutdown=%t oldStartTime=%s newStartTime=%s oldEpoch=%s newEpoch=%s epochKnown=%t stableObservations=%d/%d",
-			ga.CurrentPod, backend.Alive, shutdown, ga.InitialBackendStartTime, currentStartTime, ga.InitialBackendEpoch, currentEpoch, currentEpochKnown, ga.StableBackendObservations, requiredStableBackendObservations)
+		klog.FromContext(ctx).Info("handleWaitBEAlive: observed candidate new generation", "backend", ga.CurrentPod, "alive", backend.Alive, "shutdown", shutdown, "oldStartTime", ga.InitialBackendStartTime, "newStartTime", currentStartTime, "oldEpoch", ga.InitialBackendEpoch, "newEpoch", currentEpoch, "epochKnown", currentEpochKnown, "stableObservations", ga.StableBackendObservations, "requiredStableBackendObservations", requiredStableBackendObservations)
 		return nil
 	}
 
@@ -699,11 +719,10 @@ func (dcgs *DisaggregatedComputeGroupsController) handleWaitBEAlive(
 	case ga.InitialBackendEpoch != "" && !currentEpochKnown:
 		rejectReason = "epoch unknown"
 	}
-	klog.Infof("handleWaitBEAlive: backend %s rejected current generation reason=%s alive=%t shutdown=%t oldStartTime=%s currentStartTime=%s oldEpoch=%s currentEpoch=%s epochKnown=%t heartbeatFailures=%d err=%s",
-		ga.CurrentPod, rejectReason, backend.Alive, shutdown, ga.InitialBackendStartTime, currentStartTime, ga.InitialBackendEpoch, currentEpoch, currentEpochKnown, backend.HeartbeatFailureCounter, backend.ErrMsg)
+	klog.FromContext(ctx).Info("handleWaitBEAlive: rejected current generation", "backend", ga.CurrentPod, "reason", rejectReason, "alive", backend.Alive, "shutdown", shutdown, "oldStartTime", ga.InitialBackendStartTime, "currentStartTime", currentStartTime, "oldEpoch", ga.InitialBackendEpoch, "currentEpoch", currentEpoch, "epochKnown", currentEpochKnown, "heartbeatFailures", backend.HeartbeatFailureCounter, "errMsg", backend.ErrMsg)
 
 	if !ga.DeadlineAt.IsZero() && time.Now().After(ga.DeadlineAt.Time) {
-		klog.Warningf("handleWaitBEAlive: timeout waiting for backend %s to become alive in FE with new generation, lastRejectReason=%s", ga.CurrentPod, rejectReason)
+		klog.FromContext(ctx).Info("handleWaitBEAlive: timeout waiting for backend to become alive in FE with new generation", "backend", ga.CurrentPod, "lastRejectReason", rejectReason)
 		ga.LastMessage = fmt.Sprintf(
 			"Timed out waiting for backend %s to become alive in FE (alive=%t shutdown=%t heartbeatFailures=%d err=%s), forcing advance",
 			ga.CurrentPod, backend.Alive, shutdown, backend.HeartbeatFailureCounter, backend.ErrMsg)
@@ -872,12 +891,12 @@ func (dcgs *DisaggregatedComputeGroupsController) selectNextRollingUpdatePod(
 
 	selector, err := metav1.LabelSelectorAsSelector(est.Spec.Selector)
 	if err != nil {
-		klog.Errorf("selectNextRollingUpdatePod: failed to build selector for statefulset %s/%s: %v", est.Namespace, est.Name, err)
+		klog.FromContext(ctx).Error(err, "selectNextRollingUpdatePod: failed to build selector", "namespace", est.Namespace, "name", est.Name)
 		return "", 0, false
 	}
 	var podList corev1.PodList
 	if err := dcgs.K8sclient.List(ctx, &podList, client.InNamespace(est.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
-		klog.Errorf("selectNextRollingUpdatePod: failed to list pods: %v", err)
+		klog.FromContext(ctx).Error(err, "selectNextRollingUpdatePod: failed to list pods", "namespace", est.Namespace, "name", est.Name)
 		return "", 0, false
 	}
 
@@ -1101,13 +1120,13 @@ func getGracefulAction(st *appv1.StatefulSet) (*dv1.GracefulAction, error) {
 	return &ga, nil
 }
 
-func setGracefulAction(st *appv1.StatefulSet, ga *dv1.GracefulAction) {
+func setGracefulAction(ctx context.Context, st *appv1.StatefulSet, ga *dv1.GracefulAction) {
 	if st.Annotations == nil {
 		st.Annotations = map[string]string{}
 	}
 	bs, err := json.Marshal(ga)
 	if err != nil {
-		klog.Errorf("setGracefulAction: failed to marshal graceful action for statefulset %s/%s: %v", st.Namespace, st.Name, err)
+		klog.FromContext(ctx).Error(err, "setGracefulAction: failed to marshal graceful action", "namespace", st.Namespace, "name", st.Name)
 		return
 	}
 	st.Annotations[gracefulActionAnnotation] = string(bs)
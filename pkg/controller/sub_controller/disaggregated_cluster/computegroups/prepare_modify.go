@@ -19,13 +19,16 @@ package computegroups
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/mysql"
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	sc "github.com/apache/doris-operator/pkg/controller/sub_controller"
 	appv1 "k8s.io/api/apps/v1"
 	"k8s.io/klog/v2"
 )
@@ -39,6 +42,11 @@ func (dcgs *DisaggregatedComputeGroupsController) preApplyStatefulSet(ctx contex
 			break
 		}
 	}
+	// the decommission in progress is canceled when replicas reverted or cancel annotation is set.
+	if cgStatus.Phase == dv1.Decommissioning && (*(st.Spec.Replicas) >= *(est.Spec.Replicas) || cancelDecommissionAnnotated(cluster, uniqueId)) {
+		return dcgs.cancelDecommission(ctx, st, est, cluster, cg, cgStatus)
+	}
+
 	optType := getOperationType(st, est, cgStatus.Phase)
 
 	switch optType {
@@ -58,7 +66,7 @@ func (dcgs *DisaggregatedComputeGroupsController) preApplyStatefulSet(ctx contex
 func (dcgs *DisaggregatedComputeGroupsController) scaleOut(ctx context.Context, cgStatus *dv1.ComputeGroupStatus, cluster *dv1.DorisDisaggregatedCluster, cg *dv1.ComputeGroup) error {
 	sqlClient, err := dcgs.getMasterSqlClient(ctx, cluster)
 	if err != nil {
-		klog.Errorf("ScaleOut getMasterSqlClient failed, get fe master node connection err:%s", err.Error())
+		klog.FromContext(ctx).Error(err, "ScaleOut getMasterSqlClient failed, get fe master node connection", "namespace", cluster.Namespace, "name", cluster.Name)
 		return err
 	}
 	defer sqlClient.Close()
@@ -67,13 +75,13 @@ func (dcgs *DisaggregatedComputeGroupsController) scaleOut(ctx context.Context,
 	cgid := cgStatus.ComputeGroupId
 
 	if cluster.Spec.EnableDecommission {
-		if err := dcgs.scaledOutBENodesByDecommission(cluster, cgStatus, sqlClient, cgid, cgKeepAmount); err != nil {
+		if err := dcgs.scaledOutBENodesByDecommission(ctx, cluster, cgStatus, sqlClient, cgid, cgKeepAmount); err != nil {
 			return err
 		}
 	} else { // not decommission , drop node
-		if err := dcgs.scaledOutBENodesByDrop(sqlClient, cgid, cgKeepAmount); err != nil {
+		if err := dcgs.scaledOutBENodesByDrop(ctx, sqlClient, cgid, cgKeepAmount); err != nil {
 			cgStatus.Phase = dv1.ScaleDownFailed
-			klog.Errorf("ScaleOut scaledOutBENodesByDrop ddcName:%s, namespace:%s, computeGroupName:%s, drop nodes failed:%s ", cluster.Name, cluster.Namespace, cgid, err.Error())
+			klog.FromContext(ctx).Error(err, "ScaleOut scaledOutBENodesByDrop, drop nodes failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupName", cgid)
 			return err
 		}
 		cgStatus.Phase = dv1.Scaling
@@ -82,32 +90,155 @@ func (dcgs *DisaggregatedComputeGroupsController) scaleOut(ctx context.Context,
 	return nil
 }
 
-func (dcgs *DisaggregatedComputeGroupsController) scaledOutBENodesByDecommission(cluster *dv1.DorisDisaggregatedCluster, cgStatus *dv1.ComputeGroupStatus, sqlClient *mysql.DB, cgid string, cgKeepAmount int32) error {
-	decommissionPhase, err := dcgs.decommissionProgressCheck(sqlClient, cgid, cgKeepAmount)
+func (dcgs *DisaggregatedComputeGroupsController) scaledOutBENodesByDecommission(ctx context.Context, cluster *dv1.DorisDisaggregatedCluster, cgStatus *dv1.ComputeGroupStatus, sqlClient *mysql.DB, cgid string, cgKeepAmount int32) error {
+	decommissionPhase, err := dcgs.decommissionProgressCheck(ctx, sqlClient, cgid, cgKeepAmount)
 	if err != nil {
 		return err
 	}
 	switch decommissionPhase {
 	case resource.DecommissionAcceptable:
-		err = dcgs.decommissionBENodes(sqlClient, cgid, cgKeepAmount)
+		err = dcgs.decommissionBENodes(ctx, sqlClient, cgid, cgKeepAmount)
 		if err != nil {
 			cgStatus.Phase = dv1.ScaleDownFailed
-			klog.Errorf("scaledOutBENodesByDecommission ddcName:%s, namespace:%s, computeGroupId:%s , Decommission failed, err:%s ", cluster.Name, cluster.Namespace, cgid, err.Error())
+			klog.FromContext(ctx).Error(err, "scaledOutBENodesByDecommission, Decommission failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
 			return err
 		}
+		dcgs.K8srecorder.Event(cluster, string(sc.EventNormal), string(sc.DecommissionStarted), fmt.Sprintf("decommission be nodes of compute group %s for scaling down to %d replicas.", cgStatus.UniqueId, cgKeepAmount))
 		cgStatus.Phase = dv1.Decommissioning
+		cgStatus.DecommissionStatus = nil
+		dcgs.recordDecommissionProgress(ctx, cluster, cgStatus, sqlClient, cgid, cgKeepAmount)
 		return nil
-	case resource.Decommissioning, resource.DecommissionPhaseUnknown:
+	case resource.Decommissioning:
 		cgStatus.Phase = dv1.Decommissioning
-		klog.Infof("scaledOutBENodesByDecommission ddcName:%s, namespace:%s, computeGroupId:%s, Decommission in progress", cluster.Name, cluster.Namespace, cgid)
+		klog.FromContext(ctx).Info("scaledOutBENodesByDecommission, Decommission in progress", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		dcgs.recordDecommissionProgress(ctx, cluster, cgStatus, sqlClient, cgid, cgKeepAmount)
 		return nil
+	case resource.DecommissionPhaseUnknown:
+		// the phase is queried again in next reconcile, the backends dropped by hand or missing in SHOW BACKENDS never resolve it,
+		// so the statefulset is applied when the tablets not migrated for DecommissionStalledTimeout.
+		dp := dcgs.recordDecommissionProgress(ctx, cluster, cgStatus, sqlClient, cgid, cgKeepAmount)
+		if dp == nil || !dp.NoProgressTimeout(time.Now()) {
+			cgStatus.Phase = dv1.Decommissioning
+			klog.FromContext(ctx).Info("scaledOutBENodesByDecommission, Decommission phase unknown, wait for resolving", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+			return nil
+		}
+		klog.FromContext(ctx).Info("scaledOutBENodesByDecommission, Decommission phase not resolved, apply the statefulset", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		dcgs.K8srecorder.Event(cluster, string(sc.EventWarning), string(sc.DecommissionUnresolved), fmt.Sprintf("the decommission phase of be nodes in compute group %s can not be resolved and no tablets migrated since %s, scale down to %d replicas without waiting.", cgStatus.UniqueId, dp.LastProgressTime.Format(time.RFC3339), cgKeepAmount))
+		cgStatus.DecommissionStatus = nil
 	case resource.Decommissioned:
-		dcgs.scaledOutBENodesByDrop(sqlClient, cgid, cgKeepAmount)
+		if err := dcgs.scaledOutBENodesByDrop(ctx, sqlClient, cgid, cgKeepAmount); err != nil {
+			cgStatus.Phase = dv1.ScaleDownFailed
+			klog.FromContext(ctx).Error(err, "scaledOutBENodesByDecommission, drop decommissioned nodes failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+			dcgs.K8srecorder.Event(cluster, string(sc.EventWarning), sc.BEDecommissionFailed, fmt.Sprintf("drop decommissioned be in compute group %s failed, %s", cgStatus.UniqueId, err.Error()))
+			return err
+		}
+		cgStatus.DecommissionStatus = nil
+		dcgs.K8srecorder.Event(cluster, string(sc.EventNormal), string(sc.DecommissionFinished), fmt.Sprintf("decommission of be nodes in compute group %s finished, the nodes dropped.", cgStatus.UniqueId))
 	}
 	cgStatus.Phase = dv1.Scaling
 	return nil
 }
 
+// recordDecommissionProgress update the decommission progress of compute group in status and return it, nil when the backends not got. a warning event is sent when the decommission stalled.
+func (dcgs *DisaggregatedComputeGroupsController) recordDecommissionProgress(ctx context.Context, cluster *dv1.DorisDisaggregatedCluster, cgStatus *dv1.ComputeGroupStatus, sqlClient *mysql.DB, cgid string, cgKeepAmount int32) *resource.DecommissionProgress {
+	decommissionBackends, err := getScaledOutBENode(ctx, sqlClient, cgid, cgKeepAmount)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "recordDecommissionProgress, getScaledOutBENode failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		return nil
+	}
+
+	last := resource.NewDecommissionProgressFromDisaggregatedStatus(cgStatus.DecommissionStatus)
+	dp := resource.CalculateDecommissionProgress(last, decommissionBackends, time.Now())
+	if dp.IsNewlyStalled(last) {
+		dcgs.K8srecorder.Event(cluster, string(sc.EventWarning), string(sc.DecommissionStalled), fmt.Sprintf("the tablets number of decommissioning be nodes in compute group %s have not decreased since %s, remaining %d tablets.", cgStatus.UniqueId, dp.LastProgressTime.Format(time.RFC3339), dp.RemainingTabletNum))
+	}
+	cgStatus.DecommissionStatus = dp.ToDisaggregatedStatus()
+	return dp
+}
+
+// cancelDecommission cancel the decommission of be nodes in compute group. the pods are kept, so the replicas is reverted to the statefulset's when it is less.
+func (dcgs *DisaggregatedComputeGroupsController) cancelDecommission(ctx context.Context, st, est *appv1.StatefulSet, cluster *dv1.DorisDisaggregatedCluster, cg *dv1.ComputeGroup, cgStatus *dv1.ComputeGroupStatus) error {
+	sqlClient, err := dcgs.getMasterSqlClient(ctx, cluster)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "cancelDecommission getMasterSqlClient failed, get fe master node connection", "namespace", cluster.Namespace, "name", cluster.Name)
+		return err
+	}
+	defer sqlClient.Close()
+
+	cgid := cgStatus.ComputeGroupId
+	allBackends, err := sqlClient.GetBackendsByComputeGroupId(cgid)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "cancelDecommission, GetBackendsByComputeGroupId failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		return err
+	}
+	var decommissionBackends []*mysql.Backend
+	for _, backend := range allBackends {
+		if backend.SystemDecommissioned {
+			decommissionBackends = append(decommissionBackends, backend)
+		}
+	}
+	if err = sqlClient.CancelDecommissionBE(decommissionBackends); err != nil {
+		klog.FromContext(ctx).Error(err, "cancelDecommission, CancelDecommissionBE failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		dcgs.K8srecorder.Event(cluster, string(sc.EventWarning), sc.BEDecommissionFailed, fmt.Sprintf("cancel decommission be in compute group %s failed, %s", cg.UniqueId, err.Error()))
+		return err
+	}
+
+	// the annotation is not in spec, the ddc is not updated for it after reconciling, so patch it explicitly. otherwise, it cancels the next scale down of compute group.
+	if err = dcgs.removeCancelDecommissionAnnotation(ctx, cluster, cg.UniqueId); err != nil {
+		klog.FromContext(ctx).Error(err, "cancelDecommission, remove cancel decommission annotation failed", "namespace", cluster.Namespace, "name", cluster.Name, "computeGroupId", cgid)
+		return err
+	}
+
+	// keep the pods, the reverted replicas are updated with the spec of ddc after reconciling.
+	if *(cg.Replicas) < *(est.Spec.Replicas) {
+		*(cg.Replicas) = *(est.Spec.Replicas)
+		*(st.Spec.Replicas) = *(est.Spec.Replicas)
+		cgStatus.Replicas = *(est.Spec.Replicas)
+	}
+
+	dcgs.K8srecorder.Event(cluster, string(sc.EventNormal), string(sc.DecommissionCanceled), fmt.Sprintf("the decommission of %d be nodes in compute group %s canceled, replicas keep %d.", len(decommissionBackends), cg.UniqueId, *(cg.Replicas)))
+	cgStatus.Phase = dv1.Ready
+	cgStatus.DecommissionStatus = nil
+	return nil
+}
+
+func cancelDecommissionAnnotated(ddc *dv1.DorisDisaggregatedCluster, uniqueId string) bool {
+	for _, id := range strings.Split(ddc.Annotations[dv1.CancelDecommissionAnnotation], ",") {
+		if strings.TrimSpace(id) == uniqueId {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCancelDecommissionAnnotation remove the uniqueId from the cancel decommission annotation, and patch the annotation of ddc.
+func (dcgs *DisaggregatedComputeGroupsController) removeCancelDecommissionAnnotation(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster, uniqueId string) error {
+	value, ok := ddc.Annotations[dv1.CancelDecommissionAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" && id != uniqueId {
+			ids = append(ids, id)
+		}
+	}
+	var newValue interface{}
+	if len(ids) == 0 {
+		delete(ddc.Annotations, dv1.CancelDecommissionAnnotation)
+	} else {
+		ddc.Annotations[dv1.CancelDecommissionAnnotation] = strings.Join(ids, ",")
+		newValue = ddc.Annotations[dv1.CancelDecommissionAnnotation]
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{dv1.CancelDecommissionAnnotation: newValue},
+		},
+	}
+	return k8s.MergePatchClientObject(ctx, dcgs.K8sclient, ddc, patch)
+}
+
 func getOperationType(st, est *appv1.StatefulSet, phase dv1.Phase) string {
 	//Should not check 'phase == dv1.Ready', because the default value of the state initialization is Reconciling in the new Reconcile
 	// *st.Spec.Replicas < *est.Spec.Replicas represents need initial scaleDown, it belongs to the start phase.
@@ -118,13 +249,14 @@ func getOperationType(st, est *appv1.StatefulSet, phase dv1.Phase) string {
 }
 
 func (dcgs *DisaggregatedComputeGroupsController) scaledOutBENodesByDrop(
+	ctx context.Context,
 	masterDBClient *mysql.DB,
 	cgid string,
 	cgKeepAmount int32) error {
 
-	dropNodes, err := getScaledOutBENode(masterDBClient, cgid, cgKeepAmount)
+	dropNodes, err := getScaledOutBENode(ctx, masterDBClient, cgid, cgKeepAmount)
 	if err != nil {
-		klog.Errorf("scaledOutBENodesByDrop getScaledOutBENode cgid %s failed, err:%s ", cgid, err.Error())
+		klog.FromContext(ctx).Error(err, "scaledOutBENodesByDrop getScaledOutBENode failed", "cgid", cgid)
 		return err
 	}
 
@@ -133,20 +265,21 @@ func (dcgs *DisaggregatedComputeGroupsController) scaledOutBENodesByDrop(
 	}
 	err = masterDBClient.DropBE(dropNodes)
 	if err != nil {
-		klog.Errorf("scaledOutBENodesByDrop cgid %s DropBENodes failed, err:%s ", cgid, err.Error())
+		klog.FromContext(ctx).Error(err, "scaledOutBENodesByDrop DropBENodes failed", "cgid", cgid)
 		return err
 	}
 	return nil
 }
 
 func (dcgs *DisaggregatedComputeGroupsController) decommissionBENodes(
+	ctx context.Context,
 	masterDBClient *mysql.DB,
 	cgName string,
 	cgKeepAmount int32) error {
 
-	dropNodes, err := getScaledOutBENode(masterDBClient, cgName, cgKeepAmount)
+	dropNodes, err := getScaledOutBENode(ctx, masterDBClient, cgName, cgKeepAmount)
 	if err != nil {
-		klog.Errorf("decommissionBENodes getScaledOutBENode cgName %s failed, err:%s ", cgName, err.Error())
+		klog.FromContext(ctx).Error(err, "decommissionBENodes getScaledOutBENode failed", "cgName", cgName)
 		return err
 	}
 
@@ -155,7 +288,7 @@ func (dcgs *DisaggregatedComputeGroupsController) decommissionBENodes(
 	}
 	err = masterDBClient.DecommissionBE(dropNodes)
 	if err != nil {
-		klog.Errorf("decommissionBENodes cgName %s DropBENodes failed, err:%s ", cgName, err.Error())
+		klog.FromContext(ctx).Error(err, "decommissionBENodes DropBENodes failed", "cgName", cgName)
 		return err
 	}
 	return nil
@@ -186,17 +319,17 @@ func (dcgs *DisaggregatedComputeGroupsController) getMasterSqlClient(ctx context
 	// Connect to the master and run the SQL statement of system admin, because it is not excluded that the user can shrink be and fe at the same time
 	masterDBClient, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, secret)
 	if err != nil {
-		klog.Errorf("getMasterSqlClient NewDorisMasterSqlDB failed for ddc %s namespace %s, get fe node connection err:%s", cluster.Namespace, cluster.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "getMasterSqlClient NewDorisMasterSqlDB failed, get fe node connection", "namespace", cluster.Namespace, "name", cluster.Name)
 		return nil, err
 	}
-	return masterDBClient, nil
+	return masterDBClient.WithContext(ctx), nil
 }
 
 // isDecommissionProgressFinished check decommission status
-func (dcgs *DisaggregatedComputeGroupsController) decommissionProgressCheck(masterDBClient *mysql.DB, cgid string, cgKeepAmount int32) (resource.DecommissionPhase, error) {
+func (dcgs *DisaggregatedComputeGroupsController) decommissionProgressCheck(ctx context.Context, masterDBClient *mysql.DB, cgid string, cgKeepAmount int32) (resource.DecommissionPhase, error) {
 	allBackends, err := masterDBClient.GetBackendsByComputeGroupId(cgid)
 	if err != nil {
-		klog.Errorf("decommissionProgressCheck failed, cgid %s ShowBackends err:%s", cgid, err.Error())
+		klog.FromContext(ctx).Error(err, "decommissionProgressCheck failed, ShowBackends", "cgid", cgid)
 		return resource.DecommissionPhaseUnknown, err
 	}
 	dts := resource.ConstructDecommissionTaskStatus(allBackends, cgKeepAmount)
@@ -204,13 +337,14 @@ func (dcgs *DisaggregatedComputeGroupsController) decommissionProgressCheck(mast
 }
 
 func getScaledOutBENode(
+	ctx context.Context,
 	masterDBClient *mysql.DB,
 	cgid string,
 	cgKeepAmount int32) ([]*mysql.Backend, error) {
 
 	allBackends, err := masterDBClient.GetBackendsByComputeGroupId(cgid)
 	if err != nil {
-		klog.Errorf("scaledOutBEPreprocessing failed,  cgid %s ShowBackends err:%s", cgid, err.Error())
+		klog.FromContext(ctx).Error(err, "scaledOutBEPreprocessing failed, ShowBackends", "cgid", cgid)
 		return nil, err
 	}
 
@@ -221,7 +355,7 @@ func getScaledOutBENode(
 		splitCGIDArr := strings.Split(split[0], "-")
 		podNum, err := strconv.Atoi(splitCGIDArr[len(splitCGIDArr)-1])
 		if err != nil {
-			klog.Errorf("scaledOutBEPreprocessing  cgid %s splitCGIDArr can not split host : %s,err:%s", cgid, node.Host, err.Error())
+			klog.FromContext(ctx).Error(err, "scaledOutBEPreprocessing splitCGIDArr can not split", "cgid", cgid, "host", node.Host)
 			return nil, err
 		}
 		if podNum >= int(cgKeepAmount) {
@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package computegroups
+
+import (
+	"context"
+	"testing"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCancelDecommissionAnnotation(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test",
+			Annotations: map[string]string{dv1.CancelDecommissionAnnotation: "cg1, cg2"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := dv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add disaggregated scheme failed: %v", err)
+	}
+	dcgs := &DisaggregatedComputeGroupsController{}
+	dcgs.K8sclient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(ddc.DeepCopy()).Build()
+
+	if !cancelDecommissionAnnotated(ddc, "cg1") || !cancelDecommissionAnnotated(ddc, "cg2") {
+		t.Fatalf("compute groups in annotation should be canceled.")
+	}
+	if cancelDecommissionAnnotated(ddc, "cg3") {
+		t.Fatalf("compute group not in annotation should not be canceled.")
+	}
+
+	stored := func() *dv1.DorisDisaggregatedCluster {
+		var eddc dv1.DorisDisaggregatedCluster
+		if err := dcgs.K8sclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "test"}, &eddc); err != nil {
+			t.Fatalf("get ddc failed: %v", err)
+		}
+		return &eddc
+	}
+	if err := dcgs.removeCancelDecommissionAnnotation(context.Background(), ddc, "cg1"); err != nil {
+		t.Fatalf("remove cg1 from annotation failed: %v", err)
+	}
+	if v := ddc.Annotations[dv1.CancelDecommissionAnnotation]; v != "cg2" {
+		t.Fatalf("remove cg1 from annotation failed, value=%s", v)
+	}
+	if v := stored().Annotations[dv1.CancelDecommissionAnnotation]; v != "cg2" {
+		t.Fatalf("the removal of cg1 not patched, value=%s", v)
+	}
+	if err := dcgs.removeCancelDecommissionAnnotation(context.Background(), ddc, "cg2"); err != nil {
+		t.Fatalf("remove cg2 from annotation failed: %v", err)
+	}
+	if _, ok := ddc.Annotations[dv1.CancelDecommissionAnnotation]; ok {
+		t.Fatalf("annotation should be deleted when no compute group left.")
+	}
+	if _, ok := stored().Annotations[dv1.CancelDecommissionAnnotation]; ok {
+		t.Fatalf("the deleted annotation not patched.")
+	}
+}
@@ -52,24 +52,27 @@ func New(mgr ctrl.Manager) *DisaggregatedFEController {
 	return &DisaggregatedFEController{
 		DisaggregatedSubDefaultController: sc.DisaggregatedSubDefaultController{
 			K8sclient:      mgr.GetClient(),
-			K8srecorder:    mgr.GetEventRecorderFor(disaggregatedFEController),
-			ControllerName: disaggregatedFEController},
+			K8srecorder:    sc.NewDedupRecorder(mgr.GetEventRecorderFor(disaggregatedFEController)),
+			ControllerName: disaggregatedFEController,
+			RestConfig:     mgr.GetConfig()},
 	}
 }
 
 func (dfc *DisaggregatedFEController) Sync(ctx context.Context, obj client.Object) error {
 	ddc := obj.(*v1.DorisDisaggregatedCluster)
 	//deploying fe when ms is available.
-	if !dfc.msAvailable(ddc) {
+	if !dfc.msAvailable(ctx, ddc) {
 		dfc.K8srecorder.Event(ddc, string(sc.EventNormal), string(sc.WaitMetaServiceAvailable), "meta service have not ready.")
 		return nil
 	}
 
-	dfc.CheckSecretMountPath(ddc, ddc.Spec.FeSpec.Secrets)
+	dfc.CheckSecretMountPath(ctx, ddc, ddc.Spec.FeSpec.Secrets)
 	dfc.CheckSecretExist(ctx, ddc, ddc.Spec.FeSpec.Secrets)
+	// rotate the password before the fe status initialized, the rotation needs the available status of last reconciling.
+	dfc.RotateManagementPassword(ctx, ddc)
 
 	if ddc.Spec.FeSpec.Replicas == nil {
-		klog.Errorf("disaggregatedFEController sync disaggregatedDorisCluster namespace=%s,name=%s ,The number of disaggregated fe replicas is nil and has been corrected to the default value %d", ddc.Namespace, ddc.Name, v1.DefaultFeReplicaNumber)
+		klog.FromContext(ctx).Error(nil, "disaggregatedFEController Sync the number of disaggregated fe replicas is nil and has been corrected to the default", "namespace", ddc.Namespace, "name", ddc.Name, "value", v1.DefaultFeReplicaNumber)
 		dfc.K8srecorder.Event(ddc, string(sc.EventNormal), string(sc.FESpecSetError), "The number of disaggregated fe replicas is nil and has been corrected to the default value 2")
 		ddc.Spec.FeSpec.Replicas = &v1.DefaultFeReplicaNumber
 	}
@@ -78,7 +81,7 @@ func (dfc *DisaggregatedFEController) Sync(ctx context.Context, obj client.Objec
 
 	if *(ddc.Spec.FeSpec.Replicas) < electionNumber {
 		dfc.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.FESpecSetError), "The number of disaggregated fe ElectionNumber is large than Replicas, Replicas has been corrected to the correct minimum value")
-		klog.Errorf("disaggregatedFEController Sync disaggregatedDorisCluster namespace=%s,name=%s ,The number of disaggregated fe ElectionNumber(%d) is large than Replicas(%d), Replicas has been corrected to the correct minimum value", ddc.Namespace, ddc.Name, electionNumber, *(ddc.Spec.FeSpec.Replicas))
+		klog.FromContext(ctx).Error(nil, "disaggregatedFEController Sync the election number of disaggregated fe is larger than replicas, replicas has been corrected to the minimum value", "namespace", ddc.Namespace, "name", ddc.Name, "electionNumber", electionNumber, "replicas", *(ddc.Spec.FeSpec.Replicas))
 		ddc.Spec.FeSpec.Replicas = &electionNumber
 	}
 
@@ -87,15 +90,28 @@ func (dfc *DisaggregatedFEController) Sync(ctx context.Context, obj client.Objec
 	svc := dfc.newService(ddc, confMap)
 
 	st := dfc.NewStatefulset(ddc, confMap)
+	if err := dfc.ApplyConfigMapHash(ctx, ddc, st, ddc.Spec.FeSpec.ConfigMaps); err != nil {
+		return err
+	}
 	//initial fe status on start. in resource process step, may be use the status record the process.
 	dfc.initialFEStatus(ddc)
 
+	if ddc.IsTLSEnabled() {
+		if err := dfc.ApplyTLS(ctx, ddc, st, ddc.Spec.FeSpec.ConfigMaps, resource.FE_RESOLVEKEY, resource.DISAGGREGATED_FE_MAIN_CONTAINER_NAME,
+			ddc.GetFEInternalServiceName(), ddc.GetFEServiceName()); errors.Is(err, sc.ErrTLSCertificateNotReady) {
+			return nil
+		} else if err != nil {
+			klog.FromContext(ctx).Error(err, "disaggregatedFEController apply tls failed", "namespace", ddc.Namespace, "name", ddc.Name)
+			return err
+		}
+	}
+
 	event, err := dfc.DefaultReconcileService(ctx, svcInternal)
 	if err != nil {
 		if event != nil {
 			dfc.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 		}
-		klog.Errorf("disaggregatedFEController reconcile internal service namespace %s name %s failed, err=%s", svc.Namespace, svc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcile internal service failed", "namespace", svc.Namespace, "name", svc.Name)
 		return err
 	}
 
@@ -104,31 +120,34 @@ func (dfc *DisaggregatedFEController) Sync(ctx context.Context, obj client.Objec
 		if event != nil {
 			dfc.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 		}
-		klog.Errorf("disaggregatedFEController reconcile service namespace %s name %s failed, err=%s", svc.Namespace, svc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcile service failed", "namespace", svc.Namespace, "name", svc.Name)
 		return err
 	}
 
+	dfc.ApplyMonitor(ctx, ddc, v1.DisaggregatedFE, nil, st, svc)
+	dfc.ApplyPrometheusRule(ctx, ddc)
+
 	event, err = dfc.reconcileStatefulset(ctx, st, ddc)
 	if err != nil {
 		if event != nil {
 			dfc.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 		}
-		klog.Errorf("disaggregatedFEController reconcile statefulset namespace %s name %s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcile statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return err
 	}
 
 	event, err = dfc.ReconcilePVC(ctx, ddc, confMap, v1.DisaggregatedFE, st, nil)
 	if err != nil {
-		klog.Errorf("FE Sync ReconcilePVC failed, namespace: %s, ddc name %s, error=%s!", ddc.Namespace, ddc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "FE Sync ReconcilePVC failed", "namespace", ddc.Namespace, "name", ddc.Name)
 	}
 
 	return nil
 }
 
-func (dfc *DisaggregatedFEController) msAvailable(ddc *v1.DorisDisaggregatedCluster) bool {
+func (dfc *DisaggregatedFEController) msAvailable(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) bool {
 	endpoints := corev1.Endpoints{}
 	if err := dfc.K8sclient.Get(context.Background(), types.NamespacedName{Namespace: ddc.Namespace, Name: ddc.GetMSServiceName()}, &endpoints); err != nil {
-		klog.Infof("DisaggregatedFEController Sync wait meta service name %s available occur failed %s\n", ddc.GetMSServiceName(), err.Error())
+		klog.FromContext(ctx).Info("DisaggregatedFEController Sync wait meta service available occur failed", "err", err, "name", ddc.GetMSServiceName())
 		return false
 	}
 
@@ -144,7 +163,7 @@ func (dfc *DisaggregatedFEController) ClearResources(ctx context.Context, obj cl
 	ddc := obj.(*v1.DorisDisaggregatedCluster)
 
 	if err := dfc.recycleResources(ctx, ddc); err != nil {
-		klog.Errorf("DisaggregatedFE ClearResources RecycleResources failed, namespace %s name %s, err:%s.", ddc.Namespace, ddc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "DisaggregatedFE ClearResources RecycleResources failed", "namespace", ddc.Namespace, "name", ddc.Name)
 		return false, err
 	}
 
@@ -157,19 +176,19 @@ func (dfc *DisaggregatedFEController) ClearResources(ctx context.Context, obj cl
 	}
 
 	if err := k8s.DeleteService(ctx, dfc.K8sclient, ddc.Namespace, serviceName); err != nil {
-		klog.Errorf("disaggregatedFEController delete service namespace %s name %s failed, err=%s", ddc.Namespace, serviceName, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController delete service failed", "namespace", ddc.Namespace, "name", serviceName)
 		dfc.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.FEServiceDeleteFailed), err.Error())
 		return false, err
 	}
 
 	if err := k8s.DeleteService(ctx, dfc.K8sclient, ddc.Namespace, serviceInternalName); err != nil {
-		klog.Errorf("disaggregatedFEController delete internal service namespace %s name %s failed, err=%s", ddc.Namespace, serviceInternalName, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController delete internal service failed", "namespace", ddc.Namespace, "name", serviceInternalName)
 		dfc.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.FEServiceDeleteFailed), err.Error())
 		return false, err
 	}
 
 	if err := k8s.DeleteStatefulset(ctx, dfc.K8sclient, ddc.Namespace, statefulsetName); err != nil {
-		klog.Errorf("disaggregatedFEController delete statefulset namespace %s name %s failed, err=%s", ddc.Namespace, statefulsetName, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController delete statefulset failed", "namespace", ddc.Namespace, "name", statefulsetName)
 		dfc.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.FEStatefulsetDeleteFailed), err.Error())
 		return false, err
 	}
@@ -205,7 +224,7 @@ func (dfc *DisaggregatedFEController) UpdateComponentStatus(obj client.Object) e
 	stfName := ddc.GetFEStatefulsetName()
 	sts, err := k8s.GetStatefulSet(context.Background(), dfc.K8sclient, ddc.Namespace, stfName)
 	if err != nil {
-		klog.Errorf("DisaggregatedFEController UpdateComponentStatus get statefulset %s failed, err=%s", stfName, err.Error())
+		klog.Background().Error(err, "DisaggregatedFEController UpdateComponentStatus get failed", "statefulset", stfName)
 		return err
 	}
 
@@ -276,13 +295,13 @@ func (dfc *DisaggregatedFEController) reconcileStatefulset(ctx context.Context,
 	var est appv1.StatefulSet
 	if err := dfc.K8sclient.Get(ctx, types.NamespacedName{Namespace: st.Namespace, Name: st.Name}, &est); apierrors.IsNotFound(err) {
 		if err = k8s.CreateClientObject(ctx, dfc.K8sclient, st); err != nil {
-			klog.Errorf("disaggregatedFEController reconcileStatefulset create statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcileStatefulset create statefulset failed", "namespace", st.Namespace, "name", st.Name)
 			return &sc.Event{Type: sc.EventWarning, Reason: sc.FECreateResourceFailed, Message: err.Error()}, err
 		}
 
 		return nil, nil
 	} else if err != nil {
-		klog.Errorf("disaggregatedFEController reconcileStatefulset get statefulset failed, namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcileStatefulset get statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return nil, err
 	}
 
@@ -293,7 +312,7 @@ func (dfc *DisaggregatedFEController) reconcileStatefulset(ctx context.Context,
 	electionNumber := cluster.GetElectionNumber()
 	if replicas < electionNumber {
 		dfc.K8srecorder.Event(cluster, string(sc.EventWarning), string(sc.FESpecSetError), "The number of disaggregated fe ElectionNumber is large than Replicas, Replicas has been corrected to the correct minimum value")
-		klog.Errorf("disaggregatedFEController reconcileStatefulset disaggregatedDorisCluster namespace=%s,name=%s ,The number of disaggregated fe ElectionNumber(%d) is large than Replicas(%d)", cluster.Namespace, cluster.Name, electionNumber, *(cluster.Spec.FeSpec.Replicas))
+		klog.FromContext(ctx).Error(nil, "disaggregatedFEController reconcileStatefulset the election number of disaggregated fe is larger than replicas", "namespace", cluster.Namespace, "name", cluster.Name, "electionNumber", electionNumber, "replicas", *(cluster.Spec.FeSpec.Replicas))
 		cluster.Spec.FeSpec.Replicas = &electionNumber
 		st.Spec.Replicas = &electionNumber
 	}
@@ -305,7 +324,7 @@ func (dfc *DisaggregatedFEController) reconcileStatefulset(ctx context.Context,
 	if willRemovedAmount < 0 || cluster.Status.FEStatus.Phase == v1.ScaleDownFailed {
 		if err := dfc.dropFEBySQLClient(ctx, dfc.K8sclient, cluster); err != nil {
 			cluster.Status.FEStatus.Phase = v1.ScaleDownFailed
-			klog.Errorf("ScaleDownFE failed, err:%s ", err.Error())
+			klog.FromContext(ctx).Error(err, "ScaleDownFE failed")
 			return &sc.Event{Type: sc.EventWarning, Reason: sc.FEHTTPFailed, Message: err.Error()},
 				err
 		}
@@ -333,14 +352,18 @@ func (dfc *DisaggregatedFEController) reconcileStatefulset(ctx context.Context,
 		}
 		return equal
 	}); err != nil {
-		klog.Errorf("disaggregatedFEController reconcileStatefulset apply statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedFEController reconcileStatefulset apply statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.FEApplyResourceFailed, Message: err.Error()}, err
 	}
 	return nil, nil
 }
 
-// RecycleResources pvc resource for fe recycle
+// RecycleResources pvc resource for fe recycle, the pvcs are retained when `whenScaled` of retention policy is `Retain`.
 func (dfc *DisaggregatedFEController) recycleResources(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) error {
+	if !resource.DeleteDisaggregatedPVCWhenScaled(ddc.Spec.FeSpec.PersistentVolumeClaimRetentionPolicy) {
+		return nil
+	}
+
 	if ddc.Spec.FeSpec.PersistentVolume != nil || len(ddc.Spec.FeSpec.PersistentVolumes) != 0 {
 		return dfc.listAndDeletePersistentVolumeClaim(ctx, ddc)
 	}
@@ -371,14 +394,14 @@ func (dfc *DisaggregatedFEController) dropFEBySQLClient(ctx context.Context, k8s
 	}
 	masterDBClient, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, secret)
 	if err != nil {
-		klog.Errorf("NewDorisMasterSqlDB failed, get fe node connection err:%s", err.Error())
+		klog.FromContext(ctx).Error(err, "NewDorisMasterSqlDB failed, get fe node connection")
 		return err
 	}
 	defer masterDBClient.Close()
 
 	allObserves, err := masterDBClient.GetObservers()
 	if err != nil {
-		klog.Errorf("dropFEFromSQLClient failed, GetObservers err:%s", err.Error())
+		klog.FromContext(ctx).Error(err, "dropFEFromSQLClient failed, GetObservers")
 		return err
 	}
 
@@ -386,7 +409,7 @@ func (dfc *DisaggregatedFEController) dropFEBySQLClient(ctx context.Context, k8s
 	electionNumber := cluster.GetElectionNumber()
 	needRemovedAmount := int32(len(allObserves)) - *(cluster.Spec.FeSpec.Replicas) + electionNumber
 	if needRemovedAmount <= 0 {
-		klog.Errorf("dropFEFromSQLClient failed, Observers number(%d) is not larger than scale number(%d) ", len(allObserves), *(cluster.Spec.FeSpec.Replicas)-electionNumber)
+		klog.FromContext(ctx).Error(nil, "dropFEFromSQLClient failed, Observers is not larger than scale number", "observers", len(allObserves), "scaleNumber", *(cluster.Spec.FeSpec.Replicas)-electionNumber)
 		return nil
 	}
 
@@ -397,14 +420,14 @@ func (dfc *DisaggregatedFEController) dropFEBySQLClient(ctx context.Context, k8s
 	if resource.GetStartMode(confMap) == resource.START_MODEL_FQDN { // use host
 		frontendMap, err = mysql.BuildSeqNumberToFrontendMap(allObserves, nil, stsName)
 		if err != nil {
-			klog.Errorf("dropFEFromSQLClient failed, buildSeqNumberToFrontend err:%s", err.Error())
+			klog.FromContext(ctx).Error(err, "dropFEFromSQLClient failed, buildSeqNumberToFrontend")
 			return nil
 		}
 	} else { // use ip
 		podMap := make(map[string]string) // key is pod ip, value is pod name
 		pods, err := k8s.GetPods(ctx, k8sclient, cluster.Namespace, dfc.getFEPodLabels(cluster))
 		if err != nil {
-			klog.Errorf("dropFEFromSQLClient failed, GetPods err:%s", err)
+			klog.FromContext(ctx).Error(err, "dropFEFromSQLClient failed, GetPods")
 			return nil
 		}
 		for _, item := range pods.Items {
@@ -414,11 +437,17 @@ func (dfc *DisaggregatedFEController) dropFEBySQLClient(ctx context.Context, k8s
 		}
 		frontendMap, err = mysql.BuildSeqNumberToFrontendMap(allObserves, podMap, stsName)
 		if err != nil {
-			klog.Errorf("dropFEFromSQLClient failed, buildSeqNumberToFrontend err:%s", err.Error())
+			klog.FromContext(ctx).Error(err, "dropFEFromSQLClient failed, buildSeqNumberToFrontend")
 			return nil
 		}
 	}
 	observes := mysql.FindNeedDeletedObservers(frontendMap, needRemovedAmount)
 	// drop node and return
-	return masterDBClient.DropObserver(observes)
+	if err := masterDBClient.DropObserver(observes); err != nil {
+		return err
+	}
+	if len(observes) != 0 {
+		dfc.K8srecorder.Event(cluster, string(sc.EventNormal), string(sc.ObserverDropped), "dropped observers "+strings.Join(sc.FrontendHosts(observes), ",")+" for scaling down.")
+	}
+	return nil
 }
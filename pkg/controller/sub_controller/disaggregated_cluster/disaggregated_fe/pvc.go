@@ -57,13 +57,13 @@ func (dfc *DisaggregatedFEController) listAndDeletePersistentVolumeClaim(ctx con
 		var index int64 = -1
 		sl := strings.Split(pvcName, stsName+"-")
 		if len(sl) != 2 {
-			klog.Errorf("DisaggregatedFEController listAndDeletePersistentVolumeClaim namespace %s pvcName %s is not match statefulset format.", ddc.Namespace, pvcName)
+			klog.FromContext(ctx).Error(nil, "DisaggregatedFEController listAndDeletePersistentVolumeClaim pvc name is not match statefulset format", "namespace", ddc.Namespace, "name", pvcName)
 			continue
 		}
 		var perr error
 		index, perr = strconv.ParseInt(sl[1], 10, 32)
 		if perr != nil {
-			klog.Errorf("DisaggregatedFEController listAndDeletePersistentVolumeClaim namespace %s pvcName %s parse index faield, err=%s", ddc.Namespace, pvcName, perr.Error())
+			klog.FromContext(ctx).Error(perr, "DisaggregatedFEController listAndDeletePersistentVolumeClaim parse index failed", "namespace", ddc.Namespace, "name", pvcName)
 			continue
 		}
 
@@ -76,7 +76,7 @@ func (dfc *DisaggregatedFEController) listAndDeletePersistentVolumeClaim(ctx con
 	for _, pvcName := range clearPVC {
 		if err := k8s.DeletePVC(ctx, dfc.K8sclient, ddc.Namespace, pvcName, pvcLabels); err != nil {
 			dfc.K8srecorder.Event(ddc, string(sub_controller.EventWarning), sub_controller.PVCDeleteFailed, err.Error())
-			klog.Errorf("listAndDeletePersistentVolumeClaim deletePVCs failed: namespace %s, name %s delete pvc %s, err: %s .", ddc.Namespace, pvcName, pvcName, err.Error())
+			klog.FromContext(ctx).Error(err, "listAndDeletePersistentVolumeClaim deletePVCs delete pvc failed", "namespace", ddc.Namespace, "name", pvcName)
 			mergeError = utils.MergeError(mergeError, err)
 		}
 	}
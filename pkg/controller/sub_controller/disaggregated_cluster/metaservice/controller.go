@@ -52,13 +52,13 @@ func (dms *DisaggregatedMSController) ClearResources(ctx context.Context, obj cl
 	}
 
 	if err := k8s.DeleteService(ctx, dms.K8sclient, ddc.Namespace, serviceName); err != nil {
-		klog.Errorf("dms controller delete service namespace %s name %s failed, err=%s", ddc.Namespace, serviceName, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller delete service failed", "namespace", ddc.Namespace, "name", serviceName)
 		dms.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.MSServiceDeletedFailed), err.Error())
 		return false, err
 	}
 
 	if err := k8s.DeleteStatefulset(ctx, dms.K8sclient, ddc.Namespace, statefulsetName); err != nil {
-		klog.Errorf("dms controller delete statefulset namespace %s name %s failed, err=%s", ddc.Namespace, statefulsetName, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller delete statefulset failed", "namespace", ddc.Namespace, "name", statefulsetName)
 		dms.K8srecorder.Event(ddc, string(sc.EventWarning), string(sc.MSStatefulsetDeleteFailed), err.Error())
 		return false, err
 	}
@@ -91,7 +91,7 @@ func (dms *DisaggregatedMSController) UpdateComponentStatus(obj client.Object) e
 	stsName := ddc.GetMSStatefulsetName()
 	sts, err := k8s.GetStatefulSet(context.Background(), dms.K8sclient, ddc.Namespace, stsName)
 	if err != nil {
-		klog.Errorf("DisaggregatedMSController UpdateComponentStatus get statefulset %s failed, err=%s", stsName, err.Error())
+		klog.Background().Error(err, "DisaggregatedMSController UpdateComponentStatus get failed", "statefulset", stsName)
 		return err
 	}
 
@@ -152,8 +152,9 @@ func New(mgr ctrl.Manager) *DisaggregatedMSController {
 	return &DisaggregatedMSController{
 		sc.DisaggregatedSubDefaultController{
 			K8sclient:      mgr.GetClient(),
-			K8srecorder:    mgr.GetEventRecorderFor(metaServiceController),
+			K8srecorder:    sc.NewDedupRecorder(mgr.GetEventRecorderFor(metaServiceController)),
 			ControllerName: metaServiceController,
+			RestConfig:     mgr.GetConfig(),
 		}}
 }
 
@@ -164,9 +165,12 @@ func (dms *DisaggregatedMSController) Sync(ctx context.Context, obj client.Objec
 	svc := dms.newService(ddc, confMap)
 
 	st := dms.newStatefulset(ddc, confMap)
+	if err := dms.ApplyConfigMapHash(ctx, ddc, st, msSpec.ConfigMaps); err != nil {
+		return err
+	}
 	dms.initMSStatus(ddc)
 
-	dms.CheckSecretMountPath(ddc, ddc.Spec.MetaService.Secrets)
+	dms.CheckSecretMountPath(ctx, ddc, ddc.Spec.MetaService.Secrets)
 	dms.CheckSecretExist(ctx, ddc, ddc.Spec.MetaService.Secrets)
 
 	event, err := dms.DefaultReconcileService(ctx, svc)
@@ -174,22 +178,24 @@ func (dms *DisaggregatedMSController) Sync(ctx context.Context, obj client.Objec
 		if event != nil {
 			dms.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 		}
-		klog.Errorf("dms controller reconcile service namespace %s name %s failed, err=%s", svc.Namespace, svc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller reconcile service failed", "namespace", svc.Namespace, "name", svc.Name)
 		return err
 	}
 
+	dms.ApplyMonitor(ctx, ddc, v1.DisaggregatedMS, nil, st, svc)
+
 	event, err = dms.reconcileStatefulset(ctx, st, ddc)
 	if err != nil {
 		if event != nil {
 			dms.K8srecorder.Event(ddc, string(event.Type), string(event.Reason), event.Message)
 		}
-		klog.Errorf("dms controller reconcile statefulset namespace %s name %s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller reconcile statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return err
 	}
 
 	event, err = dms.ReconcilePVC(ctx, ddc, confMap, v1.DisaggregatedMS, st, nil)
 	if err != nil {
-		klog.Errorf("MS Sync ReconcilePVC failed, namespace: %s, ddc name %s, error=%s!", ddc.Namespace, ddc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "MS Sync ReconcilePVC failed", "namespace", ddc.Namespace, "name", ddc.Name)
 	}
 
 	return nil
@@ -199,13 +205,13 @@ func (dms *DisaggregatedMSController) reconcileStatefulset(ctx context.Context,
 	var est appv1.StatefulSet
 	if err := dms.K8sclient.Get(ctx, types.NamespacedName{Namespace: st.Namespace, Name: st.Name}, &est); apierrors.IsNotFound(err) {
 		if err = k8s.CreateClientObject(ctx, dms.K8sclient, st); err != nil {
-			klog.Errorf("dms controller reconcileStatefulset create statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "dms controller reconcileStatefulset create statefulset failed", "namespace", st.Namespace, "name", st.Name)
 			return &sc.Event{Type: sc.EventWarning, Reason: sc.CGCreateResourceFailed, Message: err.Error()}, err
 		}
 
 		return nil, nil
 	} else if err != nil {
-		klog.Errorf("dms controller reconcileStatefulset get statefulset failed, namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller reconcileStatefulset get statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return nil, err
 	}
 
@@ -230,7 +236,7 @@ func (dms *DisaggregatedMSController) reconcileStatefulset(ctx context.Context,
 
 		return equal
 	}); err != nil {
-		klog.Errorf("dms controller reconcileStatefulset apply statefulset namespace=%s name=%s failed, err=%s", st.Namespace, st.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "dms controller reconcileStatefulset apply statefulset failed", "namespace", st.Namespace, "name", st.Name)
 		return &sc.Event{Type: sc.EventWarning, Reason: sc.CGApplyResourceFailed, Message: err.Error()}, err
 	}
 
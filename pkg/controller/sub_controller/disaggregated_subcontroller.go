@@ -27,8 +27,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/doris-operator/api/disaggregated/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/hash"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/metadata"
 	"github.com/apache/doris-operator/pkg/common/utils/mysql"
@@ -38,8 +40,10 @@ import (
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kr "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,6 +85,8 @@ type DisaggregatedSubDefaultController struct {
 	K8sclient      client.Client
 	K8srecorder    record.EventRecorder
 	ControllerName string
+	// RestConfig is used to exec command in pods.
+	RestConfig *rest.Config
 }
 
 func (d *DisaggregatedSubDefaultController) GetConfigValuesFromConfigMaps(namespace string, resolveKey string, cms []v1.ConfigMap) map[string]interface{} {
@@ -91,7 +97,7 @@ func (d *DisaggregatedSubDefaultController) GetConfigValuesFromConfigMaps(namesp
 	for _, cm := range cms {
 		kcm, err := k8s.GetConfigMap(context.Background(), d.K8sclient, namespace, cm.Name)
 		if err != nil {
-			klog.Errorf("disaggregatedFEController getConfigValuesFromConfigMaps namespace=%s, name=%s, failed, err=%s", namespace, cm.Name, err.Error())
+			klog.Background().Error(err, "disaggregatedFEController getConfigValuesFromConfigMaps failed", "namespace", namespace, "name", cm.Name)
 			continue
 		}
 
@@ -106,6 +112,27 @@ func (d *DisaggregatedSubDefaultController) GetConfigValuesFromConfigMaps(namesp
 	return nil
 }
 
+// ApplyConfigMapHash record the hash of the configmaps mounted in pods in the pod template of statefulset, the pods are restarted when the contents of configmaps changed.
+// when a configmap can not be got, the error is returned and the statefulset should not be applied, as the hash without the configmap restarts the pods.
+func (d *DisaggregatedSubDefaultController) ApplyConfigMapHash(ctx context.Context, ddc *v1.DorisDisaggregatedCluster, st *appv1.StatefulSet, cms []v1.ConfigMap) error {
+	if !ddc.IsRestartWhenConfigChangeEnabled() || len(cms) == 0 {
+		return nil
+	}
+
+	contents := map[string]map[string]string{}
+	for _, cm := range cms {
+		kcm, err := k8s.GetConfigMap(ctx, d.K8sclient, ddc.Namespace, cm.Name)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController ApplyConfigMapHash get configmap failed", "namespace", ddc.Namespace, "name", cm.Name)
+			d.K8srecorder.Event(ddc, string(EventWarning), string(ConfigMapGetFailed), "ApplyConfigMapHash get configmap "+cm.Name+" failed, "+err.Error())
+			return err
+		}
+		contents[cm.Name] = kcm.Data
+	}
+	resource.SetConfigMapHash(&st.Spec.Template, hash.HashObject(contents))
+	return nil
+}
+
 func (d *DisaggregatedSubDefaultController) resolveStartConfig(vb []byte, resolveKey string) map[string]interface{} {
 	switch resolveKey {
 	case resource.MS_RESOLVEKEY:
@@ -236,7 +263,7 @@ func (d *DisaggregatedSubDefaultController) DefaultReconcileService(ctx context.
 	if err := k8s.ApplyService(ctx, d.K8sclient, svc, func(nsvc, osvc *corev1.Service) bool {
 		return resource.ServiceDeepEqualWithAnnoKey(nsvc, osvc, v1.DisaggregatedSpecHashValueAnnotation)
 	}); err != nil {
-		klog.Errorf("disaggregatedSubDefaultController reconcileService apply service namespace=%s name=%s failed, err=%s", svc.Namespace, svc.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "disaggregatedSubDefaultController reconcileService apply service failed", "namespace", svc.Namespace, "name", svc.Name)
 		return &Event{Type: EventWarning, Reason: ServiceApplyedFailed, Message: err.Error()}, err
 	}
 
@@ -244,12 +271,12 @@ func (d *DisaggregatedSubDefaultController) DefaultReconcileService(ctx context.
 }
 
 // generate map for mountpath:secret
-func (d *DisaggregatedSubDefaultController) CheckSecretMountPath(ddc *v1.DorisDisaggregatedCluster, secrets []v1.Secret) {
+func (d *DisaggregatedSubDefaultController) CheckSecretMountPath(ctx context.Context, ddc *v1.DorisDisaggregatedCluster, secrets []v1.Secret) {
 	var mountsMap = make(map[string]v1.Secret)
 	for _, secret := range secrets {
 		path := secret.MountPath
 		if s, exist := mountsMap[path]; exist {
-			klog.Errorf("CheckSecretMountPath error: the mountPath %s is repeated between secret: %s and secret: %s.", path, secret.SecretName, s.SecretName)
+			klog.FromContext(ctx).Error(nil, "CheckSecretMountPath error: the mountPath is repeated between secrets", "mountPath", path, "secret", secret.SecretName, "otherSecret", s.SecretName)
 			d.K8srecorder.Event(ddc, string(EventWarning), string(SecretPathRepeated), fmt.Sprintf("the mountPath %s is repeated between secret: %s and secret: %s.", path, secret.SecretName, s.SecretName))
 		}
 		mountsMap[path] = secret
@@ -266,7 +293,7 @@ func (d *DisaggregatedSubDefaultController) CheckSecretExist(ctx context.Context
 		}
 	}
 	if errMessage != "" {
-		klog.Errorf("CheckSecretExist error: %s.", errMessage)
+		klog.FromContext(ctx).Error(nil, "CheckSecretExist get secrets failed", "errors", errMessage)
 		d.K8srecorder.Event(ddc, string(EventWarning), string(SecretNotExist), fmt.Sprintf("CheckSecretExist error: %s.", errMessage))
 	}
 }
@@ -282,7 +309,14 @@ func (d *DisaggregatedSubDefaultController) RestrictConditionsEqual(new *appv1.S
 	}
 }
 
+// GetManagementAdminUserAndPWD return the credentials of management user, when password rotation enabled the confirmed credentials in the state of rotation are used.
 func (d *DisaggregatedSubDefaultController) GetManagementAdminUserAndPWD(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) (string, string) {
+	if ddc.Spec.AuthSecret != "" && ddc.Spec.PasswordRotation != nil {
+		if user, password, ok := getRotatedCredentials(ctx, d.K8sclient, ddc.Namespace, resource.GetPasswordRotationStateSecretName(ddc.Name)); ok {
+			return user, password
+		}
+	}
+
 	adminUserName := "root"
 	password := ""
 	if ddc.Spec.AuthSecret != "" {
@@ -297,6 +331,88 @@ func (d *DisaggregatedSubDefaultController) GetManagementAdminUserAndPWD(ctx con
 
 }
 
+// GetMasterSqlClient return the sql client connected to fe master by the management user.
+func (d *DisaggregatedSubDefaultController) GetMasterSqlClient(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) (*mysql.DB, error) {
+	dbConf, tlsConfig, tlsSecret := d.getSqlClientConfig(ctx, ddc)
+	var err error
+	if dbConf.Timeout, err = sqlTimeoutOfContext(ctx); err != nil {
+		return nil, err
+	}
+	db, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, tlsSecret)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx), nil
+}
+
+// getSqlClientConfig return the config of sql client that connects to the fe service, and the tls config with the secret of client certificate when tls enabled.
+func (d *DisaggregatedSubDefaultController) getSqlClientConfig(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) (mysql.DBConfig, *mysql.TLSConfig, *corev1.Secret) {
+	adminUserName, password := d.GetManagementAdminUserAndPWD(ctx, ddc)
+	confMap := d.GetConfigValuesFromConfigMaps(ddc.Namespace, resource.FE_RESOLVEKEY, ddc.Spec.FeSpec.ConfigMaps)
+	tlsConfig, secretName := d.FindSecretTLSConfig(confMap, ddc)
+	var tlsSecret *corev1.Secret
+	if tlsConfig != nil && secretName != "" {
+		tlsSecret, _ = k8s.GetSecret(ctx, d.K8sclient, ddc.Namespace, secretName)
+	}
+	return mysql.DBConfig{
+		User:     adminUserName,
+		Password: password,
+		Host:     ddc.GetFEVIPAddresss(),
+		Port:     strconv.FormatInt(int64(resource.GetPort(confMap, resource.QUERY_PORT)), 10),
+		Database: "mysql",
+	}, tlsConfig, tlsSecret
+}
+
+// RotateManagementPassword rotate the password of management user when fe is available, the state of rotation is cleared when rotation disabled.
+func (d *DisaggregatedSubDefaultController) RotateManagementPassword(ctx context.Context, ddc *v1.DorisDisaggregatedCluster) {
+	stateSecretName := resource.GetPasswordRotationStateSecretName(ddc.Name)
+	if ddc.Spec.AuthSecret == "" || ddc.Spec.PasswordRotation == nil {
+		clearPasswordRotationState(ctx, d.K8sclient, ddc.Namespace, stateSecretName)
+		return
+	}
+	if ddc.Status.FEStatus.AvailableStatus != v1.Available {
+		return
+	}
+
+	confMap := d.GetConfigValuesFromConfigMaps(ddc.Namespace, resource.FE_RESOLVEKEY, ddc.Spec.FeSpec.ConfigMaps)
+	tlsConfig, secretName := d.FindSecretTLSConfig(confMap, ddc)
+	var tlsSecret *corev1.Secret
+	if tlsConfig != nil && secretName != "" {
+		tlsSecret, _ = k8s.GetSecret(ctx, d.K8sclient, ddc.Namespace, secretName)
+	}
+	pr := &passwordRotator{
+		k8sclient:       d.K8sclient,
+		namespace:       ddc.Namespace,
+		authSecretName:  ddc.Spec.AuthSecret,
+		stateSecretName: stateSecretName,
+		ownerRef:        resource.GetOwnerReference(ddc),
+		labels:          map[string]string{v1.DorisDisaggregatedClusterName: ddc.Name},
+		connect: func(user, password string) (*mysql.DB, error) {
+			return mysql.NewDorisMasterSqlDB(mysql.DBConfig{
+				User:     user,
+				Password: password,
+				Host:     ddc.GetFEVIPAddresss(),
+				Port:     strconv.FormatInt(int64(resource.GetPort(confMap, resource.QUERY_PORT)), 10),
+				Database: "mysql",
+			}, tlsConfig, tlsSecret)
+		},
+	}
+	if ddc.Spec.PasswordRotation.Interval != nil {
+		pr.interval = ddc.Spec.PasswordRotation.Interval.Duration
+	}
+
+	rotated, err := pr.rotate(ctx)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController RotateManagementPassword failed", "namespace", ddc.Namespace, "name", ddc.Name)
+		d.K8srecorder.Event(ddc, string(EventWarning), string(PasswordRotationFailed), "rotate the password of management user failed, "+err.Error())
+		return
+	}
+	if rotated {
+		klog.FromContext(ctx).Info("DisaggregatedSubDefaultController RotateManagementPassword rotated the password of management user", "namespace", ddc.Namespace, "name", ddc.Name)
+		d.K8srecorder.Event(ddc, string(EventNormal), string(PasswordRotated), "the password of management user rotated.")
+	}
+}
+
 // add cluster specification on container spec. this is useful to add common spec on different type pods, example: kerberos volume for fe and be.
 func (d *DisaggregatedSubDefaultController) AddClusterSpecForPodTemplate(componentType v1.DisaggregatedComponentType, configMap map[string]interface{}, spec *v1.DorisDisaggregatedClusterSpec, pts *corev1.PodTemplateSpec) {
 	var c *corev1.Container
@@ -317,7 +433,7 @@ func (d *DisaggregatedSubDefaultController) AddClusterSpecForPodTemplate(compone
 		}
 
 	default:
-		klog.Errorf("DisaggregatedSubDefaultController AddClusterSpecForPodTemplate componentType %s not supported.", componentType)
+		klog.Background().Error(nil, "DisaggregatedSubDefaultController AddClusterSpecForPodTemplate not supported", "componentType", componentType)
 		return
 	}
 
@@ -397,15 +513,16 @@ func (d *DisaggregatedSubDefaultController) ReconcilePVC(
 	default:
 	}
 
-	_, _, pvcTemplates := d.BuildVolumesVolumeMountsAndPVCs(cm, componentType, commonSpec)
+	_, vms, pvcTemplates := d.BuildVolumesVolumeMountsAndPVCs(cm, componentType, commonSpec)
 
 	oldPvcList := corev1.PersistentVolumeClaimList{}
 	selector := sts.Spec.Selector.MatchLabels
 	if err := d.K8sclient.List(ctx, &oldPvcList, client.InNamespace(ddc.Namespace), client.MatchingLabels(selector)); err != nil {
 		message := fmt.Sprintf("ReconcilePVC list pvc failed, namespace: %s, name: %s, error: %s", ddc.Namespace, ddc.Name, err.Error())
-		klog.Error(message)
+		klog.FromContext(ctx).Error(err, "ReconcilePVC list pvc failed", "namespace", ddc.Namespace, "name", ddc.Name)
 		return &Event{Type: EventWarning, Reason: PVCListFailed, Message: message}, err
 	}
+	d.applyPVCRetentionPolicy(ctx, ddc, &oldPvcList, commonSpec.PersistentVolumeClaimRetentionPolicy)
 
 	for i := range pvcTemplates {
 		manager := pvcTemplates[i].Annotations[resource.PVCManagerAnnotationApache]
@@ -424,34 +541,38 @@ func (d *DisaggregatedSubDefaultController) ReconcilePVC(
 				if err := d.K8sclient.Create(ctx, &pvc); err != nil && !apierrors.IsAlreadyExists(err) {
 					message := fmt.Sprintf("ReconcilePVC create pvc failed, namespace: %s, name: %s create pvc %s, error %s.", ddc.Namespace, ddc.Name, pvc.Name, err.Error())
 					//d.K8srecorder.Event(ddc, string(EventWarning), PVCCreateFailed, message)
-					klog.Error(message)
+					klog.FromContext(ctx).Error(err, "ReconcilePVC create pvc failed", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
 					return &Event{Type: EventWarning, Reason: PVCCreateFailed, Message: message}, err
 				}
 				message := fmt.Sprintf("ReconcilePVC create pvc, namespace: %s, name: %s create pvc %s .", ddc.Namespace, ddc.Name, pvc.Name)
-				klog.Infof(message)
+				klog.FromContext(ctx).Info("ReconcilePVC create pvc", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
 				d.K8srecorder.Event(ddc, string(EventNormal), PVCCreate, message)
 				continue
 			}
 
 			oldQuantity := oldPvc.Spec.Resources.Requests[corev1.ResourceStorage]
 			newQuantity := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			// the volumes and pvcs are built in lockstep, so the mount path of pvc template is the mount path of vms[i].
+			if ae := getAutoExpansion(commonSpec, vms[i].MountPath); ae != nil && componentType != v1.DisaggregatedBE {
+				newQuantity = d.autoExpandedQuantity(ctx, ddc, componentType, oldPvc, sts.Name+"-"+strconv.FormatInt(int64(ordinal), 10), vms[i].MountPath, newQuantity, ae)
+			}
 			//if !oldQuantity.Equal(newQuantity){
 			if oldQuantity.Cmp(newQuantity) == -1 {
 				// pvc need update
 				oldPvc.Spec.Resources.Requests[corev1.ResourceStorage] = newQuantity
 				if err := d.K8sclient.Patch(ctx, oldPvc, client.Merge); err != nil {
 					message := fmt.Sprintf("ReconcilePVC patch pvc failed, namespace: %s, ddc name: %s, patch pvc %s, error: %s", ddc.Namespace, ddc.Name, pvc.Name, err.Error())
-					klog.Errorf(message)
+					klog.FromContext(ctx).Error(err, "ReconcilePVC patch pvc failed", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
 					return &Event{Type: EventWarning, Reason: PVCUpdateFailed, Message: message}, err
 				}
 				message := fmt.Sprintf("ReconcilePVC patch pvc, namespace: %s, ddc name: %s update pvc %s .", ddc.Namespace, ddc.Name, pvc.Name)
-				klog.Infof(message)
+				klog.FromContext(ctx).Info("ReconcilePVC patch pvc", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name, "storage", newQuantity.String())
 				d.K8srecorder.Event(ddc, string(EventNormal), PVCUpdate, message)
 			}
 
 			if oldQuantity.Cmp(newQuantity) == 1 {
 				message := fmt.Sprintf("ReconcilePVC pvc resize is rejected, PVC shrinking is not supported. namespace: %s, ddc name: %s, resize pvc %s", ddc.Namespace, ddc.Name, pvc.Name)
-				klog.Warningf(message)
+				klog.FromContext(ctx).Info("ReconcilePVC pvc resize is rejected, PVC shrinking is not supported", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
 				d.K8srecorder.Event(ddc, string(EventWarning), PVCUpdateFailed, message)
 			}
 
@@ -461,6 +582,129 @@ func (d *DisaggregatedSubDefaultController) ReconcilePVC(
 	return nil, nil
 }
 
+// applyPVCRetentionPolicy keeps the owner reference and finalizer of pvcs consistent with `whenDeleted` policy.
+// the pvcs retained by the deleted DorisDisaggregatedCluster that have the same name are re-attached.
+func (d *DisaggregatedSubDefaultController) applyPVCRetentionPolicy(ctx context.Context, ddc *v1.DorisDisaggregatedCluster, pvcList *corev1.PersistentVolumeClaimList, policy *v1.PersistentVolumeClaimRetentionPolicy) {
+	owner := resource.GetOwnerReference(ddc)
+	deleteWithCluster := resource.DeleteDisaggregatedPVCWhenDeleted(policy)
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		changed, reattached := resource.ApplyPVCRetentionPolicy(pvc, owner, deleteWithCluster)
+		if !changed {
+			continue
+		}
+		if err := d.K8sclient.Update(ctx, pvc); err != nil {
+			klog.FromContext(ctx).Error(err, "applyPVCRetentionPolicy update pvc failed", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
+			continue
+		}
+		if reattached {
+			message := fmt.Sprintf("pvc %s retained by the deleted cluster is re-attached.", pvc.Name)
+			klog.FromContext(ctx).Info("applyPVCRetentionPolicy", "namespace", ddc.Namespace, "name", ddc.Name, "message", message)
+			d.K8srecorder.Event(ddc, string(EventNormal), PVCReattached, message)
+		}
+	}
+}
+
+// getAutoExpansion returns the auto expansion policy of the PersistentVolume that the mountPath used.
+func getAutoExpansion(commonSpec *v1.CommonSpec, mountPath string) *v1.PVCAutoExpansion {
+	if commonSpec.PersistentVolume != nil {
+		return commonSpec.PersistentVolume.AutoExpansion
+	}
+
+	var template *v1.PersistentVolume
+	for i := range commonSpec.PersistentVolumes {
+		if len(commonSpec.PersistentVolumes[i].MountPaths) == 0 {
+			template = &commonSpec.PersistentVolumes[i]
+			continue
+		}
+		for _, mp := range commonSpec.PersistentVolumes[i].MountPaths {
+			if mp == mountPath {
+				return commonSpec.PersistentVolumes[i].AutoExpansion
+			}
+		}
+	}
+
+	if template != nil {
+		return template.AutoExpansion
+	}
+	return nil
+}
+
+// autoExpandedQuantity returns the storage request that the pvc should be resized to when auto expansion is configured.
+// the capacity expanded before is kept, and grows one step when the disk used percent of mountPath in pod crosses the threshold.
+func (d *DisaggregatedSubDefaultController) autoExpandedQuantity(ctx context.Context, ddc *v1.DorisDisaggregatedCluster, componentType v1.DisaggregatedComponentType,
+	pvc *corev1.PersistentVolumeClaim, podName, mountPath string, specQuantity kr.Quantity, ae *v1.PVCAutoExpansion) kr.Quantity {
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	quantity := specQuantity
+	// the pvc can't be shrunk, so the capacity expanded automatically should not be reverted to the spec.
+	if current.Cmp(quantity) > 0 {
+		quantity = current
+	}
+	if d.RestConfig == nil {
+		return quantity
+	}
+
+	// the last expansion not finished, the disk usage is not refreshed.
+	statusCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !statusCapacity.IsZero() && statusCapacity.Cmp(current) < 0 {
+		return quantity
+	}
+
+	var containerName string
+	switch componentType {
+	case v1.DisaggregatedFE:
+		containerName = resource.DISAGGREGATED_FE_MAIN_CONTAINER_NAME
+	case v1.DisaggregatedMS:
+		containerName = resource.DISAGGREGATED_MS_MAIN_CONTAINER_NAME
+	default:
+		return quantity
+	}
+
+	// exec `df` in pod is expensive, the disk usage of one pvc is checked at most once in AutoExpansionCheckInterval.
+	now := time.Now()
+	if !resource.AutoExpansionCheckDue(pvc, now) {
+		return quantity
+	}
+	patch := client.MergeFrom(pvc.DeepCopy())
+	resource.MarkAutoExpansionChecked(pvc, now)
+	if err := d.K8sclient.Patch(ctx, pvc, patch); err != nil {
+		klog.FromContext(ctx).Error(err, "autoExpandedQuantity patch the checked time of pvc failed", "namespace", ddc.Namespace, "name", ddc.Name, "pvc", pvc.Name)
+		return quantity
+	}
+
+	stdout, stderr, err := k8s.ExecInPod(ctx, d.RestConfig, ddc.Namespace, podName, containerName, []string{"df", "-P", mountPath}, 10*time.Second)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "autoExpandedQuantity exec df in pod failed", "namespace", ddc.Namespace, "name", ddc.Name, "pod", podName, "stderr", stderr)
+		return quantity
+	}
+	usedPercent, err := resource.ParseDfUsedPercent(stdout)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "autoExpandedQuantity parse df output failed", "namespace", ddc.Namespace, "name", ddc.Name, "pod", podName)
+		return quantity
+	}
+
+	expanded, expand := resource.CalculateAutoExpandedCapacity(quantity, usedPercent, ae.UsedPercentThreshold, ae.Step, ae.MaxCapacity)
+	if !expand {
+		return quantity
+	}
+
+	allow, err := k8s.StorageClassAllowVolumeExpansion(ctx, d.K8sclient, pvc)
+	if err != nil || !allow {
+		message := fmt.Sprintf("pvc %s disk used %.2f%%, but the storageclass not allow volume expansion.", pvc.Name, usedPercent)
+		if err != nil {
+			message = fmt.Sprintf("pvc %s disk used %.2f%%, but get storageclass failed, %s", pvc.Name, usedPercent, err.Error())
+		}
+		klog.FromContext(ctx).Error(nil, "autoExpandedQuantity failed", "namespace", ddc.Namespace, "name", ddc.Name, "message", message)
+		d.K8srecorder.Event(ddc, string(EventWarning), PVCAutoExpandFailed, message)
+		return quantity
+	}
+
+	message := fmt.Sprintf("pvc %s disk used %.2f%%, expand from %s to %s.", pvc.Name, usedPercent, current.String(), expanded.String())
+	klog.FromContext(ctx).Info("autoExpandedQuantity", "namespace", ddc.Namespace, "name", ddc.Name, "message", message)
+	d.K8srecorder.Event(ddc, string(EventNormal), PVCAutoExpand, message)
+	return expanded
+}
+
 func getPvc(pvcs corev1.PersistentVolumeClaimList, pvcName string) *corev1.PersistentVolumeClaim {
 	for _, pvc := range pvcs.Items {
 		if pvc.Name == pvcName {
@@ -746,7 +990,7 @@ func (d *DisaggregatedSubDefaultController) getCacheMaxSizeAndPaths(cvs map[stri
 	var pa []map[string]interface{}
 	err := json.Unmarshal([]byte(vbys), &pa)
 	if err != nil {
-		klog.Errorf("disaggregatedComputeGroupsController getStorageMaxSizeAndPaths json unmarshal file_cache_path failed, err=%s", err.Error())
+		klog.Background().Error(err, "disaggregatedComputeGroupsController getStorageMaxSizeAndPaths json unmarshal file_cache_path failed")
 		return []string{}, 0
 	}
 
@@ -754,7 +998,7 @@ func (d *DisaggregatedSubDefaultController) getCacheMaxSizeAndPaths(cvs map[stri
 		pv := mp[FileCacheSubConfigPathKey]
 		pv_str, ok := pv.(string)
 		if !ok {
-			klog.Errorf("disaggregatedComputeGroupsController getStorageMaxSizeAndPaths index %d have not path config.", i)
+			klog.Background().Error(nil, "disaggregatedComputeGroupsController getStorageMaxSizeAndPaths have not path config", "index", i)
 			continue
 		}
 		paths = append(paths, pv_str)
@@ -762,7 +1006,7 @@ func (d *DisaggregatedSubDefaultController) getCacheMaxSizeAndPaths(cvs map[stri
 		fc_size, ok := cache_v.(float64)
 		cache_size := int64(fc_size)
 		if !ok {
-			klog.Errorf("disaggregatedComputeGroupsController getStorageMaxSizeAndPaths index %d total_size is not number.", i)
+			klog.Background().Error(nil, "disaggregatedComputeGroupsController getStorageMaxSizeAndPaths total_size is not number", "index", i)
 			continue
 		}
 		if maxCacheSize < cache_size {
@@ -853,6 +1097,11 @@ func (d *DisaggregatedSubDefaultController) getFEMetaPath(confMap map[string]int
 }
 
 func (d *DisaggregatedSubDefaultController) FindSecretTLSConfig(feConfMap map[string]interface{}, ddc *v1.DorisDisaggregatedCluster) (*mysql.TLSConfig, string /*secret name*/) {
+	// use the client certificate issued by operator.
+	if ddc.IsTLSEnabled() {
+		return getOperatorTLSConfig(), resource.GetTLSClientSecretName(ddc.Name)
+	}
+
 	enableTLS := resource.GetString(feConfMap, resource.ENABLE_TLS_KEY)
 	if enableTLS == "" {
 		return nil, ""
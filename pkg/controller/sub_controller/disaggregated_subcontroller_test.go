@@ -17,10 +17,17 @@
 package sub_controller
 
 import (
+	"context"
+	"testing"
+
 	v1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dresource "github.com/apache/doris-operator/pkg/common/utils/resource"
+	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	"testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestDisaggregatedSubDefaultController_BuildVolumesVolumeMountsAndPVCs_empty_persistentVolume(t *testing.T) {
@@ -116,3 +123,89 @@ func TestDisaggregatedSubDefaultController_PersistentVolumeArrayBuildVolumesVolu
 		t.Errorf("build ms default volumes volumemounts and pvcs failed, the number is not right.")
 	}
 }
+
+func Test_getAutoExpansion(t *testing.T) {
+	metaExpansion := &v1.PVCAutoExpansion{Step: resource.MustParse("10Gi"), MaxCapacity: resource.MustParse("100Gi")}
+	templateExpansion := &v1.PVCAutoExpansion{Step: resource.MustParse("50Gi"), MaxCapacity: resource.MustParse("500Gi")}
+	commonSpec := &v1.CommonSpec{
+		PersistentVolumes: []v1.PersistentVolume{
+			{MountPaths: []string{"/opt/apache-doris/fe/doris-meta"}, AutoExpansion: metaExpansion},
+			{AutoExpansion: templateExpansion},
+		},
+	}
+
+	if ae := getAutoExpansion(commonSpec, "/opt/apache-doris/fe/doris-meta"); ae != metaExpansion {
+		t.Errorf("getAutoExpansion should use the persistentVolume that mountPaths contains the path.")
+	}
+	if ae := getAutoExpansion(commonSpec, "/opt/apache-doris/fe/log"); ae != templateExpansion {
+		t.Errorf("getAutoExpansion should use the template persistentVolume.")
+	}
+
+	oldSpec := &v1.CommonSpec{PersistentVolume: &v1.PersistentVolume{}}
+	if ae := getAutoExpansion(oldSpec, "/opt/apache-doris/fe/doris-meta"); ae != nil {
+		t.Errorf("getAutoExpansion should return nil when not configured.")
+	}
+}
+
+func TestDisaggregatedSubDefaultController_ApplyConfigMapHash(t *testing.T) {
+	ctx := context.Background()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "be-conf"},
+		Data:       map[string]string{"be.conf": "be_port = 9060"},
+	}
+	k8sclient := fake.NewClientBuilder().WithObjects(cm).Build()
+	d := &DisaggregatedSubDefaultController{K8sclient: k8sclient, K8srecorder: record.NewFakeRecorder(10)}
+	ddc := &v1.DorisDisaggregatedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	cms := []v1.ConfigMap{{Name: "be-conf", MountPath: "/etc/doris"}}
+
+	st := &appv1.StatefulSet{}
+	if err := d.ApplyConfigMapHash(ctx, ddc, st, cms); err != nil {
+		t.Fatalf("apply configmap hash failed, err=%s", err.Error())
+	}
+	if _, ok := st.Spec.Template.Annotations[dresource.ConfigMapHashAnnotation]; ok {
+		t.Errorf("expected no hash recorded when restart not configured")
+	}
+
+	enabled := true
+	ddc.Spec.EnableRestartWhenConfigChange = &enabled
+	st = &appv1.StatefulSet{}
+	if err := d.ApplyConfigMapHash(ctx, ddc, st, cms); err != nil {
+		t.Fatalf("apply configmap hash failed, err=%s", err.Error())
+	}
+	oldHash := st.Spec.Template.Annotations[dresource.ConfigMapHashAnnotation]
+	if oldHash == "" {
+		t.Fatalf("expected the hash of configmaps recorded in pod template")
+	}
+
+	cm.Data["be.conf"] = "be_port = 9061"
+	if err := k8sclient.Update(ctx, cm); err != nil {
+		t.Fatalf("update configmap failed, err=%s", err.Error())
+	}
+	st = &appv1.StatefulSet{}
+	if err := d.ApplyConfigMapHash(ctx, ddc, st, cms); err != nil {
+		t.Fatalf("apply configmap hash failed, err=%s", err.Error())
+	}
+	if newHash := st.Spec.Template.Annotations[dresource.ConfigMapHashAnnotation]; newHash == "" || newHash == oldHash {
+		t.Errorf("expected the hash changed when configmap changed, old=%s, new=%s", oldHash, newHash)
+	}
+
+	disabled := false
+	ddc.Spec.EnableRestartWhenConfigChange = &disabled
+	st = &appv1.StatefulSet{}
+	if err := d.ApplyConfigMapHash(ctx, ddc, st, cms); err != nil {
+		t.Fatalf("apply configmap hash failed, err=%s", err.Error())
+	}
+	if _, ok := st.Spec.Template.Annotations[dresource.ConfigMapHashAnnotation]; ok {
+		t.Errorf("expected no hash recorded when restart disabled")
+	}
+
+	ddc.Spec.EnableRestartWhenConfigChange = &enabled
+	st = &appv1.StatefulSet{}
+	missing := append(cms, v1.ConfigMap{Name: "not-exist", MountPath: "/etc/other"})
+	if err := d.ApplyConfigMapHash(ctx, ddc, st, missing); err == nil {
+		t.Errorf("expected error when the configmap not found")
+	}
+	if _, ok := st.Spec.Template.Annotations[dresource.ConfigMapHashAnnotation]; ok {
+		t.Errorf("expected no hash recorded when the configmap not found")
+	}
+}
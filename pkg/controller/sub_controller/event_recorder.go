@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventDedupWindow is the window in which the repeated events of an object are recorded only once, reconciling every few seconds should not flood the events.
+const EventDedupWindow = 5 * time.Minute
+
+// volatileParts matches the parts of warning messages that change between the repeated failures, eg: the time, the counts, the addresses or the durations.
+// the numbers in names, eg: the ordinal of pod, are kept as they identify the subject of event.
+var volatileParts = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T[\d:.]+(?:Z|[+-]\d{2}:\d{2})?|(^|[\s=:(,/\[])\d+(?:\.\d+)*`)
+
+// dedupRecorder suppress the repeated events of an object in EventDedupWindow.
+// the normal events are the state transitions, they are repeated only when the message is the same, the transitions of different subjects, eg: pvc, pod,
+// compute group or component, are all recorded. the warning events are the failures and waiting states that repeat every reconcile with the changing details,
+// they are compared by the message with the volatile parts stripped.
+// the suppressed times are appended to the latest message when the event recorded again after the window.
+type dedupRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	events    map[string]*dedupEntry
+	lastSweep time.Time
+}
+
+type dedupEntry struct {
+	lastTime   time.Time
+	suppressed int
+}
+
+// NewDedupRecorder wrap the recorder to aggregate the repeated events.
+func NewDedupRecorder(recorder record.EventRecorder) record.EventRecorder {
+	return newDedupRecorder(recorder, EventDedupWindow, time.Now)
+}
+
+func newDedupRecorder(recorder record.EventRecorder, window time.Duration, now func() time.Time) *dedupRecorder {
+	return &dedupRecorder{
+		recorder: recorder,
+		window:   window,
+		now:      now,
+		events:   map[string]*dedupEntry{},
+	}
+}
+
+func (r *dedupRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if msg, ok := r.admit(object, eventtype, reason, message); ok {
+		r.recorder.Event(object, eventtype, reason, msg)
+	}
+}
+
+func (r *dedupRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *dedupRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if msg, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", msg)
+	}
+}
+
+// admit return the message to record and true when the event not recorded in the window, otherwise count the event as suppressed and return false.
+func (r *dedupRecorder) admit(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	now := r.now()
+	key := eventKey(object, eventtype, reason, message)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweep(now)
+	entry, ok := r.events[key]
+	if ok && now.Sub(entry.lastTime) < r.window {
+		entry.suppressed++
+		return "", false
+	}
+
+	if ok && entry.suppressed > 0 {
+		message = fmt.Sprintf("%s (repeated %d times in the last %s)", message, entry.suppressed, r.window)
+	}
+	r.events[key] = &dedupEntry{lastTime: now}
+	return message, true
+}
+
+// sweep remove the entries out of window to keep the memory bounded, the sweep runs at most once in a window.
+func (r *dedupRecorder) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.window {
+		return
+	}
+	r.lastSweep = now
+	for key, entry := range r.events {
+		// keep the entry that have suppressed events for appending the repeated times when the event recorded again.
+		if now.Sub(entry.lastTime) >= 2*r.window || (entry.suppressed == 0 && now.Sub(entry.lastTime) >= r.window) {
+			delete(r.events, key)
+		}
+	}
+}
+
+func eventKey(object runtime.Object, eventtype, reason, message string) string {
+	objKey := fmt.Sprintf("%T", object)
+	if accessor, err := meta.Accessor(object); err == nil {
+		objKey = objKey + "/" + string(accessor.GetUID()) + "/" + accessor.GetNamespace() + "/" + accessor.GetName()
+	}
+	if eventtype == string(EventWarning) {
+		message = volatileParts.ReplaceAllString(message, "${1}#")
+	}
+	return objKey + "/" + eventtype + "/" + reason + "/" + message
+}
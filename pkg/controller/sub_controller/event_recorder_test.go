@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_DedupRecorder(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	now := time.Now()
+	r := newDedupRecorder(fake, time.Minute, func() time.Time { return now })
+	dcr := &dorisv1.DorisCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+
+	// the warnings are compared without the volatile parts of message.
+	r.Event(dcr, string(EventWarning), string(FEHTTPFailed), "connect fe 10.0.0.1:9030 failed, remaining 12 tablets since 2026-10-19T08:00:00Z")
+	r.Eventf(dcr, string(EventWarning), string(FEHTTPFailed), "connect fe %s failed, remaining %d tablets since %s", "10.0.0.2:9030", 10, "2026-10-19T08:01:00Z")
+	if len(fake.Events) != 1 {
+		t.Fatalf("the repeated events should be recorded once in window, got %d events", len(fake.Events))
+	}
+	<-fake.Events
+
+	// the events of other object, reason or subject are not suppressed.
+	r.Event(&dorisv1.DorisCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}}, string(EventWarning), string(FEHTTPFailed), "connect fe 10.0.0.1:9030 failed, remaining 12 tablets since 2026-10-19T08:00:00Z")
+	r.Event(dcr, string(EventNormal), string(FEAdded), "frontends 10.0.0.1 added into the cluster.")
+	r.Event(dcr, string(EventWarning), string(GracefulDrainTimeout), "drain pod test-be-0 timeout")
+	r.Event(dcr, string(EventWarning), string(GracefulDrainTimeout), "drain pod test-be-1 timeout")
+	if len(fake.Events) != 4 {
+		t.Fatalf("the different events should not be suppressed, got %d events", len(fake.Events))
+	}
+	for i := 0; i < 4; i++ {
+		<-fake.Events
+	}
+
+	// the normal events are transitions, only the same message is suppressed.
+	r.Event(dcr, string(EventNormal), PVCUpdate, "pvc be-storage-test-be-0 resized from 100Gi to 200Gi.")
+	r.Event(dcr, string(EventNormal), PVCUpdate, "pvc be-storage-test-be-1 resized from 100Gi to 200Gi.")
+	r.Event(dcr, string(EventNormal), PVCUpdate, "pvc be-storage-test-be-1 resized from 100Gi to 200Gi.")
+	if len(fake.Events) != 2 {
+		t.Fatalf("the transitions of different subjects should be recorded, got %d events", len(fake.Events))
+	}
+	<-fake.Events
+	<-fake.Events
+
+	now = now.Add(time.Minute)
+	r.Event(dcr, string(EventWarning), string(FEHTTPFailed), "connect fe 10.0.0.3:9030 failed, remaining 8 tablets since 2026-10-19T08:02:00Z")
+	if e := <-fake.Events; !strings.HasSuffix(e, "connect fe 10.0.0.3:9030 failed, remaining 8 tablets since 2026-10-19T08:02:00Z (repeated 1 times in the last 1m0s)") {
+		t.Errorf("the event recorded after window should have the repeated times, got %q", e)
+	}
+
+	now = now.Add(3 * time.Minute)
+	r.Event(dcr, string(EventWarning), string(FEHTTPFailed), "fe not reachable")
+	if len(r.events) != 1 {
+		t.Errorf("the stale events should be swept, remaining %d", len(r.events))
+	}
+	if e := <-fake.Events; !strings.HasSuffix(e, "fe not reachable") {
+		t.Errorf("the event not match, got %q", e)
+	}
+}
@@ -38,26 +38,28 @@ const (
 	PVCDeleteFailed         = "PVCDeleteFailed"
 	PVCCreate               = "PVCCreate"
 	PVCCreateFailed         = "PVCCreateFailed"
+	PVCAutoExpand           = "PVCAutoExpanded"
+	PVCAutoExpandFailed     = "PVCAutoExpandFailed"
+	PVCReattached           = "PVCReattached"
 	FollowerScaleDownFailed = "FollowerScaleDownFailed"
+	BEDecommissionFailed    = "BEDecommissionFailed"
+	ConfigRenderFailed      = "ConfigRenderFailed"
 )
 
 type EventReason string
 
 var (
-	ImageFormatError          EventReason = "ImageFormatError"
-	FDBSpecEmpty              EventReason = "SpecEmpty"
-	FDBAvailableButUnhealth   EventReason = "FDBAvailableButUnhealth"
-	FESpecSetError            EventReason = "FESpecSetError"
-	FECreateResourceFailed    EventReason = "FECreateResourceFailed"
-	FEApplyResourceFailed     EventReason = "FEApplyResourceFailed"
-	FEStatefulsetDeleteFailed EventReason = "FEStatefulsetDeleteFailed"
-	FEHTTPFailed              EventReason = "FEHTTPResponseFailed"
-	FEServiceDeleteFailed     EventReason = "FEServiceDeleteFailed"
-	ComputeGroupsEmpty        EventReason = "CGsEmpty"
-	CGSqlExecFailed           EventReason = "CGSqlExecFailed"
-	//CGSuspendStatusRequestFailed    EventReason = "CGSuspendStatusRequestFailed"
-	//CGResumeReplicasInconsistency   EventReason = "CGSuspendReplicasInconsistency"
-	//CGResumeStatusRequestFailed     EventReason = "CGResumeStatusRequestFailed"
+	ImageFormatError                EventReason = "ImageFormatError"
+	FDBSpecEmpty                    EventReason = "SpecEmpty"
+	FDBAvailableButUnhealth         EventReason = "FDBAvailableButUnhealth"
+	FESpecSetError                  EventReason = "FESpecSetError"
+	FECreateResourceFailed          EventReason = "FECreateResourceFailed"
+	FEApplyResourceFailed           EventReason = "FEApplyResourceFailed"
+	FEStatefulsetDeleteFailed       EventReason = "FEStatefulsetDeleteFailed"
+	FEHTTPFailed                    EventReason = "FEHTTPResponseFailed"
+	FEServiceDeleteFailed           EventReason = "FEServiceDeleteFailed"
+	ComputeGroupsEmpty              EventReason = "CGsEmpty"
+	CGSqlExecFailed                 EventReason = "CGSqlExecFailed"
 	CGUniqueIdentifierDuplicate     EventReason = "CGUniqueIdentifierDuplicate"
 	CGUniqueIdentifierNotMatchRegex EventReason = "CGUniqueIdentifierNotMatchRegex"
 	CGCreateResourceFailed          EventReason = "CGCreateResourceFailed"
@@ -86,6 +88,29 @@ var (
 	GracefulActionCompleted         EventReason = "GracefulActionCompleted"
 	GracefulActionFailed            EventReason = "GracefulActionFailed"
 	GracefulActionDisabled          EventReason = "GracefulActionDisabled"
+	GracefulPhaseChanged            EventReason = "GracefulPhaseChanged"
+	DecommissionStarted             EventReason = "DecommissionStarted"
+	DecommissionFinished            EventReason = "DecommissionFinished"
+	DecommissionStalled             EventReason = "DecommissionStalled"
+	DecommissionCanceled            EventReason = "DecommissionCanceled"
+	DecommissionUnresolved          EventReason = "DecommissionUnresolved"
+	TLSCertificateIssueFailed       EventReason = "TLSCertificateIssueFailed"
+	TLSCertificateWaiting           EventReason = "TLSCertificateWaiting"
+	TLSCertificateRotated           EventReason = "TLSCertificateRotated"
+	PasswordRotated                 EventReason = "PasswordRotated"
+	PasswordRotationFailed          EventReason = "PasswordRotationFailed"
+	AdminUserMigrated               EventReason = "AdminUserMigrated"
+	AdminUserMigrateFailed          EventReason = "AdminUserMigrateFailed"
+	ConfigHotReloaded               EventReason = "ConfigHotReloaded"
+	ConfigChangeNeedRestart         EventReason = "ConfigChangeNeedRestart"
+	ConfigHotReloadFailed           EventReason = "ConfigHotReloadFailed"
+	MonitorApplyFailed              EventReason = "MonitorApplyFailed"
+	VersionSkew                     EventReason = "VersionSkew"
+	FEAdded                         EventReason = "FEAdded"
+	FEDropped                       EventReason = "FEDropped"
+	ObserverDropped                 EventReason = "ObserverDropped"
+	QueryProbeFailed                EventReason = "QueryProbeFailed"
+	QueryProbeRecovered             EventReason = "QueryProbeRecovered"
 )
 
 type Event struct {
@@ -19,6 +19,7 @@ package fe
 
 import (
 	"context"
+	"errors"
 	v1 "github.com/apache/doris-operator/api/doris/v1"
 	"github.com/apache/doris-operator/pkg/common/utils/k8s"
 	"github.com/apache/doris-operator/pkg/common/utils/resource"
@@ -39,7 +40,7 @@ func (fc *Controller) ClearResources(ctx context.Context, cluster *v1.DorisClust
 		return true, nil
 	}
 	if err := fc.RecycleResources(ctx, cluster, v1.Component_FE); err != nil {
-		klog.Errorf("fe ClearResources recycle pvc resource for reconciling namespace %s name %s!", cluster.Namespace, cluster.Name)
+		klog.FromContext(ctx).Info("fe ClearResources recycle pvc resource for reconciling", "namespace", cluster.Namespace, "name", cluster.Name)
 		return false, err
 	}
 
@@ -80,28 +81,31 @@ func (fc *Controller) GetControllerName() string {
 // Sync DorisCluster to fe statefulset and service.
 func (fc *Controller) Sync(ctx context.Context, cluster *v1.DorisCluster) error {
 	if cluster.Spec.FeSpec == nil {
-		klog.Info("fe Controller Sync ", "the fe component is not needed ", "namespace ", cluster.Namespace, " doris cluster name ", cluster.Name)
+		klog.FromContext(ctx).Info("fe Controller Sync the fe component is not needed", "namespace", cluster.Namespace, "name", cluster.Name)
 		return nil
 	}
 	var oldStatus v1.ComponentStatus
 	if cluster.Status.FEStatus != nil {
 		oldStatus = *(cluster.Status.FEStatus.DeepCopy())
 	}
-	fc.InitStatus(cluster, v1.Component_FE)
+	fc.InitStatus(ctx, cluster, v1.Component_FE)
 
 	if cluster.Spec.EnableRestartWhenConfigChange {
-		fc.CompareConfigmapAndTriggerRestart(cluster, oldStatus, v1.Component_FE)
+		fc.CompareConfigmapAndTriggerRestart(ctx, cluster, oldStatus, v1.Component_FE)
 	}
 
 	feSpec := cluster.Spec.FeSpec
 	//get the fe configMap for resolve ports.
 	config, err := fc.GetConfig(ctx, &feSpec.BaseSpec.ConfigMapInfo, cluster.Namespace, v1.Component_FE)
 	if err != nil {
-		klog.Error("fe Controller Sync ", "resolve fe configmap failed, namespace ", cluster.Namespace, " error :", err)
+		klog.FromContext(ctx).Error(err, "fe Controller Sync resolve fe configmap failed", "namespace", cluster.Namespace)
 		return err
 	}
-	fc.CheckConfigMountPath(cluster, v1.Component_FE)
-	fc.CheckSecretMountPath(cluster, v1.Component_FE)
+	if cluster.IsTLSEnabled() {
+		config = resource.InjectTLSConfig(config)
+	}
+	fc.CheckConfigMountPath(ctx, cluster, v1.Component_FE)
+	fc.CheckSecretMountPath(ctx, cluster, v1.Component_FE)
 	fc.CheckSecretExist(ctx, cluster, v1.Component_FE)
 	fc.CheckSharedPVC(ctx, cluster)
 
@@ -110,18 +114,19 @@ func (fc *Controller) Sync(ctx context.Context, cluster *v1.DorisCluster) error
 	//create or update fe external and domain search service, update the status of fe on src.
 	internalService := resource.BuildInternalService(cluster, v1.Component_FE, config)
 	if err := k8s.ApplyService(ctx, fc.K8sclient, &internalService, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("fe controller sync apply internalService name=%s, namespace=%s, clusterName=%s failed.message=%s.",
-			internalService.Name, internalService.Namespace, cluster.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "fe controller sync apply internalService failed", "name", internalService.Name, "namespace", internalService.Namespace, "clusterName", cluster.Name)
 		return err
 	}
 	if err := k8s.ApplyService(ctx, fc.K8sclient, &svc, resource.ServiceDeepEqual); err != nil {
-		klog.Errorf("fe controller sync apply external service name=%s, namespace=%s, clusterName=%s failed. message=%s.",
-			svc.Name, svc.Namespace, cluster.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "fe controller sync apply external service failed", "name", svc.Name, "namespace", svc.Namespace, "clusterName", cluster.Name)
 		return err
 	}
 
+	fc.ApplyMonitor(ctx, cluster, v1.Component_FE)
+	fc.ApplyPrometheusRule(ctx, cluster)
+
 	if !fc.PrepareReconcileResources(ctx, cluster, v1.Component_FE) {
-		klog.Infof("fe controller sync preparing resource for reconciling namespace %s name %s!", cluster.Namespace, cluster.Name)
+		klog.FromContext(ctx).Info("fe controller sync preparing resource for reconciling", "namespace", cluster.Namespace, "name", cluster.Name)
 		return nil
 	}
 
@@ -129,15 +134,28 @@ func (fc *Controller) Sync(ctx context.Context, cluster *v1.DorisCluster) error
 		return err
 	}
 
+	if err = fc.ApplyRenderedConfigMap(ctx, cluster, v1.Component_FE); err != nil {
+		return err
+	}
+
 	st := fc.buildFEStatefulSet(cluster, config)
+	if cluster.IsTLSEnabled() {
+		certHash, err := fc.ApplyTLSCertificates(ctx, cluster, v1.Component_FE)
+		if errors.Is(err, sub_controller.ErrTLSCertificateNotReady) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resource.SetTLSCertificateHash(&st.Spec.Template, certHash)
+	}
 	if err = k8s.ApplyStatefulSet(ctx, fc.K8sclient, &st, func(new *appv1.StatefulSet, old *appv1.StatefulSet) bool {
 		fc.RestrictConditionsEqual(new, old)
 		return resource.StatefulSetDeepEqual(new, old, false)
 	}); err != nil {
-		klog.Errorf("fe controller sync statefulset name=%s, namespace=%s, clusterName=%s failed. message=%s.",
-			st.Name, st.Namespace, cluster.Name, err.Error())
+		klog.FromContext(ctx).Error(err, "fe controller sync statefulset failed", "name", st.Name, "namespace", st.Namespace, "clusterName", cluster.Name)
 		return err
 	}
 
+	fc.RotateManagementPassword(ctx, cluster)
 	return nil
 }
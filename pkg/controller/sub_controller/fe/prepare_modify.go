@@ -38,7 +38,7 @@ func (fc *Controller) prepareStatefulsetApply(ctx context.Context, cluster *v1.D
 	var oldSt appv1.StatefulSet
 	err := fc.K8sclient.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: v1.GenerateComponentStatefulSetName(cluster, v1.Component_FE)}, &oldSt)
 	if err != nil {
-		klog.Infof("fe controller controlClusterPhaseAndPreOperation get fe StatefulSet failed, err: %s", err.Error())
+		klog.FromContext(ctx).Info("fe controller controlClusterPhaseAndPreOperation get fe StatefulSet failed", "err", err)
 		return nil
 	}
 	if cluster.Spec.FeSpec.Replicas == nil {
@@ -52,7 +52,7 @@ func (fc *Controller) prepareStatefulsetApply(ctx context.Context, cluster *v1.D
 	// fe scale
 	if wroa < 0 {
 		if err := fc.dropObserverBySqlClient(ctx, fc.K8sclient, cluster); err != nil {
-			klog.Errorf("ScaleDownObserver failed, err:%s ", err.Error())
+			klog.FromContext(ctx).Error(err, "ScaleDownObserver failed")
 			return err
 		}
 		return nil
@@ -62,7 +62,7 @@ func (fc *Controller) prepareStatefulsetApply(ctx context.Context, cluster *v1.D
 	// check 1: fe Phase is Available
 	// check 2: fe RestartTime is not empty and useful
 	// check 3: fe RestartTime different from old(This condition does not need to be checked here. If it is allowed to pass, it will be processed idempotent when applying sts.)
-	if oldStatus.ComponentCondition.Phase == v1.Available && fc.CheckRestartTimeAndInject(cluster, v1.Component_FE) {
+	if oldStatus.ComponentCondition.Phase == v1.Available && fc.CheckRestartTimeAndInject(ctx, cluster, v1.Component_FE) {
 		cluster.Status.FEStatus.ComponentCondition.Phase = v1.Restarting
 	}
 
@@ -102,8 +102,7 @@ func (fc *Controller) safeScaleDown(cluster *v1.DorisCluster, ost *appv1.Statefu
 // targetDCR is new dcr
 func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient client.Client, targetDCR *v1.DorisCluster) error {
 	// get adminuserName and pwd
-	secret, _ := k8s.GetSecret(ctx, k8sclient, targetDCR.Namespace, targetDCR.Spec.AuthSecret)
-	adminUserName, password := v1.GetClusterSecret(targetDCR, secret)
+	adminUserName, password := fc.GetManagementAdminUserAndPWD(ctx, targetDCR)
 	// get host and port
 	serviceName := v1.GenerateExternalServiceName(targetDCR, v1.Component_FE)
 	// When the operator and dcr are deployed in different namespace, it will be inaccessible, so need to add the dcr svc namespace
@@ -130,7 +129,7 @@ func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient cli
 
 	masterDBClient, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, tlsSecret)
 	if err != nil {
-		klog.Errorf("NewDorisMasterSqlDB failed, get fe node connection err:%s", err.Error())
+		klog.FromContext(ctx).Error(err, "NewDorisMasterSqlDB failed, get fe node connection")
 		return err
 	}
 	defer masterDBClient.Close()
@@ -138,7 +137,7 @@ func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient cli
 	// get all Observes
 	allObserves, err := masterDBClient.GetObservers()
 	if err != nil {
-		klog.Errorf("DropObserverFromSqlClient failed, GetObservers err:%s", err.Error())
+		klog.FromContext(ctx).Error(err, "DropObserverFromSqlClient failed, GetObservers")
 		return err
 	}
 
@@ -148,7 +147,7 @@ func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient cli
 	// means: needRemovedAmount = allobservers - (replicas - election)
 	needRemovedAmount := int32(len(allObserves)) - *(targetDCR.Spec.FeSpec.Replicas) + electionNumber
 	if needRemovedAmount <= 0 {
-		klog.Errorf("DropObserverFromSqlClient failed, Observers number(%d) is not larger than scale number(%d) ", len(allObserves), *(targetDCR.Spec.FeSpec.Replicas)-electionNumber)
+		klog.FromContext(ctx).Error(nil, "DropObserverFromSqlClient failed, Observers is not larger than scale number", "observers", len(allObserves), "scaleNumber", *(targetDCR.Spec.FeSpec.Replicas)-electionNumber)
 		return nil
 	}
 
@@ -159,14 +158,14 @@ func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient cli
 	if resource.GetStartMode(maps) == resource.START_MODEL_FQDN { // use host
 		frontendMap, err = mysql.BuildSeqNumberToFrontendMap(allObserves, nil, podTemplateName)
 		if err != nil {
-			klog.Errorf("DropObserverFromSqlClient failed, buildSeqNumberToFrontend err:%s", err.Error())
+			klog.FromContext(ctx).Error(err, "DropObserverFromSqlClient failed, buildSeqNumberToFrontend")
 			return nil
 		}
 	} else { // use ip
 		podMap := make(map[string]string) // key is pod ip, value is pod name
 		pods, err := k8s.GetPods(ctx, k8sclient, targetDCR.Namespace, v1.GetPodLabels(targetDCR, v1.Component_FE))
 		if err != nil {
-			klog.Errorf("DropObserverFromSqlClient failed, GetPods err:%s", err)
+			klog.FromContext(ctx).Error(err, "DropObserverFromSqlClient failed, GetPods")
 			return nil
 		}
 		for _, item := range pods.Items {
@@ -176,12 +175,18 @@ func (fc *Controller) dropObserverBySqlClient(ctx context.Context, k8sclient cli
 		}
 		frontendMap, err = mysql.BuildSeqNumberToFrontendMap(allObserves, podMap, podTemplateName)
 		if err != nil {
-			klog.Errorf("DropObserverFromSqlClient failed, buildSeqNumberToFrontend err:%s", err.Error())
+			klog.FromContext(ctx).Error(err, "DropObserverFromSqlClient failed, buildSeqNumberToFrontend")
 			return nil
 		}
 	}
 	observes := mysql.FindNeedDeletedObservers(frontendMap, needRemovedAmount)
 	// drop node and return
-	return masterDBClient.DropObserver(observes)
+	if err := masterDBClient.DropObserver(observes); err != nil {
+		return err
+	}
+	if len(observes) != 0 {
+		fc.K8srecorder.Event(targetDCR, string(sc.EventNormal), string(sc.ObserverDropped), "dropped observers "+strings.Join(sc.FrontendHosts(observes), ",")+" for scaling down.")
+	}
+	return nil
 
 }
@@ -0,0 +1,249 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// the max time of querying nodes from fe for computing the health of cluster.
+const healthQueryTimeout = 10 * time.Second
+
+// UpdateClusterHealth compute the aggregated health of DorisCluster by the nodes in status and the status of components, and set the conditions of cluster.
+// the nodes are queried from fe master only when the nodes in status are stale, so fe is queried at most once in NodesRefreshInterval.
+// the fe is regarded as unavailable when fe not reachable.
+func (d *SubDefaultController) UpdateClusterHealth(ctx context.Context, dcr *dorisv1.DorisCluster) {
+	var fes []*mysql.Frontend
+	var bes []*mysql.Backend
+	if dcr.Status.FEStatus != nil && len(dcr.Status.FEStatus.RunningMembers) != 0 {
+		var err error
+		if dcr.Status.Nodes != nil && !nodesRefreshRequired(&dcr.Status.Nodes.LastRefreshTime) {
+			fes, bes = nodesOfStatus(dcr.Status.Nodes)
+		} else if fes, bes, err = showNodes(ctx, func(ctx context.Context) (*mysql.DB, error) {
+			return d.GetMasterSqlClient(ctx, dcr)
+		}); err != nil {
+			klog.FromContext(ctx).Error(err, "SubDefaultController UpdateClusterHealth show nodes failed")
+		} else {
+			podNames := getPodNames(ctx, d.K8sclient, dcr.Namespace, map[string]string{dorisv1.DorisClusterLabelKey: dcr.Name})
+			if dcr.Status.Nodes != nil {
+				var lastHosts []string
+				for _, n := range dcr.Status.Nodes.Frontends {
+					lastHosts = append(lastHosts, n.Host)
+				}
+				recordFrontendChanges(d.K8srecorder, dcr, lastHosts, fes)
+			}
+			dcr.Status.Nodes = BuildNodesStatus(fes, bes, podNames)
+		}
+	}
+
+	health, messages := ComputeClusterHealth(dcr, fes, bes)
+	dcr.Status.ClusterHealth = health
+	SetClusterConditions(&dcr.Status.Conditions, dcr.Generation, health.Health, classicProgressing(dcr), strings.Join(messages, "; "))
+	d.UpdateVersionSkew(ctx, dcr)
+}
+
+// ComputeClusterHealth compute the health of DorisCluster, the messages describe the reasons of not green.
+// red: fe master missing, fe followers lost quorum or no backend alive. yellow: some fe followers, backends or compute nodes not alive, broker not ready or components not available.
+func ComputeClusterHealth(dcr *dorisv1.DorisCluster, fes []*mysql.Frontend, bes []*mysql.Backend) (*dorisv1.ClusterHealth, []string) {
+	h := &dorisv1.ClusterHealth{}
+	for _, fe := range fes {
+		if fe.Role != mysql.FE_FOLLOWER_ROLE {
+			continue
+		}
+		h.FeFollowerCount++
+		if fe.Alive {
+			h.FeAliveFollowerCount++
+			if fe.IsMaster {
+				h.FeMasterAvailable = true
+			}
+		}
+	}
+	for _, be := range bes {
+		if be.NodeRole == mysql.BE_COMPUTATION_ROLE {
+			h.CnCount++
+			if be.Alive {
+				h.CnAliveCount++
+			}
+			continue
+		}
+		h.BeCount++
+		if be.Alive {
+			h.BeAliveCount++
+		}
+	}
+	status := &dcr.Status
+	h.BrokerReady = dcr.Spec.BrokerSpec != nil && status.BrokerStatus != nil && status.BrokerStatus.ComponentCondition.Phase == dorisv1.Available
+
+	var reds, yellows []string
+	if !h.FeMasterAvailable {
+		reds = append(reds, "fe master is missing")
+	}
+	if h.FeFollowerCount != 0 && h.FeAliveFollowerCount <= h.FeFollowerCount/2 {
+		reds = append(reds, fmt.Sprintf("fe followers lost quorum, %d/%d alive", h.FeAliveFollowerCount, h.FeFollowerCount))
+	} else if h.FeAliveFollowerCount < h.FeFollowerCount {
+		yellows = append(yellows, fmt.Sprintf("%d/%d fe followers alive", h.FeAliveFollowerCount, h.FeFollowerCount))
+	}
+	if dcr.Spec.BeSpec != nil {
+		if h.BeAliveCount == 0 {
+			reds = append(reds, "no backend alive")
+		} else if h.BeAliveCount < h.BeCount {
+			yellows = append(yellows, fmt.Sprintf("%d/%d backends alive", h.BeAliveCount, h.BeCount))
+		}
+	}
+	if dcr.Spec.CnSpec != nil && h.CnAliveCount < h.CnCount {
+		yellows = append(yellows, fmt.Sprintf("%d/%d compute nodes alive", h.CnAliveCount, h.CnCount))
+	}
+	if dcr.Spec.BrokerSpec != nil && !h.BrokerReady {
+		yellows = append(yellows, "broker not ready")
+	}
+	for _, c := range []struct {
+		componentType dorisv1.ComponentType
+		configured    bool
+		status        *dorisv1.ComponentStatus
+	}{
+		{dorisv1.Component_FE, dcr.Spec.FeSpec != nil, status.FEStatus},
+		{dorisv1.Component_BE, dcr.Spec.BeSpec != nil, status.BEStatus},
+		{dorisv1.Component_CN, dcr.Spec.CnSpec != nil, cnComponentStatus(status.CnStatus)},
+	} {
+		if c.configured && (c.status == nil || c.status.ComponentCondition.Phase != dorisv1.Available) {
+			yellows = append(yellows, string(c.componentType)+" not available")
+		}
+	}
+
+	switch {
+	case len(reds) != 0:
+		h.Health = dorisv1.Red
+	case len(yellows) != 0:
+		h.Health = dorisv1.Yellow
+	default:
+		h.Health = dorisv1.Green
+	}
+	return h, append(reds, yellows...)
+}
+
+func cnComponentStatus(cs *dorisv1.CnStatus) *dorisv1.ComponentStatus {
+	if cs == nil {
+		return nil
+	}
+	return &cs.ComponentStatus
+}
+
+// classicProgressing return true when any component of DorisCluster is in reconciling.
+func classicProgressing(dcr *dorisv1.DorisCluster) bool {
+	status := &dcr.Status
+	for _, cs := range []*dorisv1.ComponentStatus{status.FEStatus, status.BEStatus, cnComponentStatus(status.CnStatus), status.BrokerStatus} {
+		if cs == nil {
+			continue
+		}
+		switch cs.ComponentCondition.Phase {
+		case dorisv1.Available, dorisv1.HaveMemberFailed:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// SetDisaggregatedClusterConditions set the conditions of DorisDisaggregatedCluster by the health and the phases of components in status.
+func SetDisaggregatedClusterConditions(ddc *dv1.DorisDisaggregatedCluster) {
+	status := &ddc.Status
+	var messages []string
+	if status.MetaServiceStatus.AvailableStatus != dv1.Available {
+		messages = append(messages, "meta service not available")
+	}
+	if status.FEStatus.AvailableStatus != dv1.Available {
+		messages = append(messages, "fe not available")
+	}
+	if status.ClusterHealth.CGAvailableCount < status.ClusterHealth.CGCount {
+		messages = append(messages, fmt.Sprintf("%d/%d compute groups available", status.ClusterHealth.CGAvailableCount, status.ClusterHealth.CGCount))
+	}
+	if status.ClusterHealth.CGFullAvailableCount < status.ClusterHealth.CGCount {
+		messages = append(messages, fmt.Sprintf("%d/%d compute groups fully available", status.ClusterHealth.CGFullAvailableCount, status.ClusterHealth.CGCount))
+	}
+
+	SetClusterConditions(&status.Conditions, ddc.Generation, dorisv1.Health(status.ClusterHealth.Health), disaggregatedClusterProgressing(status), strings.Join(messages, "; "))
+}
+
+// disaggregatedClusterProgressing return true when any component of DorisDisaggregatedCluster is in reconciling.
+func disaggregatedClusterProgressing(status *dv1.DorisDisaggregatedClusterStatus) bool {
+	progressing := disaggregatedProgressing(status.MetaServiceStatus.Phase) || disaggregatedProgressing(status.FEStatus.Phase)
+	for _, cgs := range status.ComputeGroupStatuses {
+		progressing = progressing || disaggregatedProgressing(cgs.Phase)
+	}
+	return progressing
+}
+
+func disaggregatedProgressing(phase dv1.Phase) bool {
+	switch phase {
+	case dv1.Reconciling, dv1.Scaling, dv1.Decommissioning, dv1.GracefulRolling, dv1.GracefulScaling, dv1.GracefulDeleting:
+		return true
+	}
+	return false
+}
+
+// SetClusterConditions set the `Ready`, `Progressing` and `Degraded` conditions of cluster, the condition types of DorisCluster and DorisDisaggregatedCluster are the same.
+// the cluster is degraded when health is red, or yellow but not progressing.
+func SetClusterConditions(conditions *[]metav1.Condition, generation int64, health dorisv1.Health, progressing bool, message string) {
+	ready := metav1.Condition{Type: dorisv1.ConditionReady, Status: metav1.ConditionTrue, ObservedGeneration: generation, Reason: healthReason(health), Message: "all components are available."}
+	if health != dorisv1.Green {
+		ready.Status, ready.Message = metav1.ConditionFalse, message
+	}
+
+	prog := metav1.Condition{Type: dorisv1.ConditionProgressing, Status: metav1.ConditionFalse, ObservedGeneration: generation, Reason: "Reconciled", Message: "all components are reconciled."}
+	if progressing {
+		prog.Status, prog.Reason, prog.Message = metav1.ConditionTrue, "Reconciling", "some components are in reconciling."
+	}
+
+	degraded := metav1.Condition{Type: dorisv1.ConditionDegraded, Status: metav1.ConditionFalse, ObservedGeneration: generation, Reason: healthReason(health), Message: message}
+	switch {
+	case health == dorisv1.Red, health == dorisv1.Yellow && !progressing:
+		degraded.Status = metav1.ConditionTrue
+	case health == dorisv1.Yellow:
+		degraded.Reason = "Progressing"
+	}
+	if degraded.Message == "" {
+		degraded.Message = "all components are available."
+	}
+
+	for _, c := range []metav1.Condition{ready, prog, degraded} {
+		meta.SetStatusCondition(conditions, c)
+	}
+}
+
+// healthReason return the reason of conditions by health, e.g. `ClusterRed`.
+func healthReason(health dorisv1.Health) string {
+	switch health {
+	case dorisv1.Red:
+		return "ClusterRed"
+	case dorisv1.Yellow:
+		return "ClusterYellow"
+	case dorisv1.Green:
+		return "ClusterGreen"
+	}
+	return "ClusterHealthUnknown"
+}
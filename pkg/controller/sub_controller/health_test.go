@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"testing"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func newHealthTestCluster() *dorisv1.DorisCluster {
+	available := dorisv1.ComponentCondition{Phase: dorisv1.Available}
+	return &dorisv1.DorisCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+		Spec:       dorisv1.DorisClusterSpec{FeSpec: &dorisv1.FeSpec{}, BeSpec: &dorisv1.BeSpec{}, CnSpec: &dorisv1.CnSpec{}, BrokerSpec: &dorisv1.BrokerSpec{}},
+		Status: dorisv1.DorisClusterStatus{
+			FEStatus:     &dorisv1.ComponentStatus{ComponentCondition: available},
+			BEStatus:     &dorisv1.ComponentStatus{ComponentCondition: available},
+			CnStatus:     &dorisv1.CnStatus{ComponentStatus: dorisv1.ComponentStatus{ComponentCondition: available}},
+			BrokerStatus: &dorisv1.ComponentStatus{ComponentCondition: available},
+		},
+	}
+}
+
+func Test_ComputeClusterHealth(t *testing.T) {
+	fes := []*mysql.Frontend{
+		{Role: mysql.FE_FOLLOWER_ROLE, IsMaster: true, Alive: true},
+		{Role: mysql.FE_FOLLOWER_ROLE, Alive: true},
+		{Role: mysql.FE_FOLLOWER_ROLE, Alive: true},
+		{Role: mysql.FE_OBSERVE_ROLE, Alive: false},
+	}
+	bes := []*mysql.Backend{
+		{NodeRole: "mix", Alive: true},
+		{NodeRole: "mix", Alive: true},
+		{NodeRole: mysql.BE_COMPUTATION_ROLE, Alive: true},
+	}
+
+	dcr := newHealthTestCluster()
+	h, messages := ComputeClusterHealth(dcr, fes, bes)
+	if h.Health != dorisv1.Green || len(messages) != 0 {
+		t.Errorf("the cluster should be green, health=%s messages=%v", h.Health, messages)
+	}
+	if h.FeFollowerCount != 3 || h.BeCount != 2 || h.CnCount != 1 || !h.BrokerReady {
+		t.Errorf("the counts of nodes are wrong, %+v", h)
+	}
+
+	// one follower and one backend not alive.
+	fes[2].Alive = false
+	bes[1].Alive = false
+	h, messages = ComputeClusterHealth(dcr, fes, bes)
+	if h.Health != dorisv1.Yellow || len(messages) != 2 {
+		t.Errorf("the cluster should be yellow, health=%s messages=%v", h.Health, messages)
+	}
+
+	// followers lost quorum.
+	fes[1].Alive = false
+	if h, _ = ComputeClusterHealth(dcr, fes, bes); h.Health != dorisv1.Red {
+		t.Errorf("the cluster should be red when fe followers lost quorum, health=%s", h.Health)
+	}
+
+	// fe not reachable.
+	if h, messages = ComputeClusterHealth(dcr, nil, nil); h.Health != dorisv1.Red || messages[0] != "fe master is missing" {
+		t.Errorf("the cluster should be red when fe master missing, health=%s messages=%v", h.Health, messages)
+	}
+}
+
+func Test_UpdateClusterHealthReuseNodes(t *testing.T) {
+	dcr := newHealthTestCluster()
+	dcr.Status.FEStatus.RunningMembers = []string{"test-fe-0"}
+	dcr.Status.Nodes = &dorisv1.NodesStatus{
+		LastRefreshTime: metav1.Now(),
+		Frontends:       []dorisv1.NodeStatus{{Host: "10.0.0.1", Role: mysql.FE_FOLLOWER_ROLE, IsMaster: true, Alive: true}},
+		Backends: []dorisv1.NodeStatus{
+			{Host: "10.0.0.2", Role: "mix", Alive: true},
+			{Host: "10.0.0.3", Role: mysql.BE_COMPUTATION_ROLE, Alive: true},
+		},
+	}
+
+	// the nodes are fresh, fe should not be queried, the health is computed by the nodes in status.
+	d := &SubDefaultController{K8srecorder: record.NewFakeRecorder(10)}
+	d.UpdateClusterHealth(context.Background(), dcr)
+	if h := dcr.Status.ClusterHealth; h.Health != dorisv1.Green || h.BeCount != 1 || h.CnCount != 1 || !h.FeMasterAvailable {
+		t.Errorf("the health should be computed by the nodes in status, %+v", h)
+	}
+}
+
+func Test_SetClusterConditions(t *testing.T) {
+	var conditions []metav1.Condition
+	SetClusterConditions(&conditions, 1, dorisv1.Yellow, true, "1/2 backends alive")
+	if !meta.IsStatusConditionFalse(conditions, dorisv1.ConditionReady) || !meta.IsStatusConditionTrue(conditions, dorisv1.ConditionProgressing) ||
+		!meta.IsStatusConditionFalse(conditions, dorisv1.ConditionDegraded) {
+		t.Errorf("the yellow cluster in reconciling should be progressing and not degraded, %v", conditions)
+	}
+
+	SetClusterConditions(&conditions, 1, dorisv1.Yellow, false, "1/2 backends alive")
+	if c := meta.FindStatusCondition(conditions, dorisv1.ConditionDegraded); c.Status != metav1.ConditionTrue || c.Reason != "ClusterYellow" || c.Message != "1/2 backends alive" {
+		t.Errorf("the yellow cluster not in reconciling should be degraded, %v", c)
+	}
+
+	SetClusterConditions(&conditions, 2, dorisv1.Green, false, "")
+	if c := meta.FindStatusCondition(conditions, dorisv1.ConditionReady); c.Status != metav1.ConditionTrue || c.ObservedGeneration != 2 {
+		t.Errorf("the green cluster should be ready, %v", c)
+	}
+	if !meta.IsStatusConditionFalse(conditions, dorisv1.ConditionDegraded) {
+		t.Errorf("the green cluster should not be degraded.")
+	}
+}
+
+func Test_SetDisaggregatedClusterConditions(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{
+		Status: dv1.DorisDisaggregatedClusterStatus{
+			MetaServiceStatus:    dv1.MetaServiceStatus{Phase: dv1.Ready, AvailableStatus: dv1.Available},
+			FEStatus:             dv1.FEStatus{Phase: dv1.Ready, AvailableStatus: dv1.Available},
+			ClusterHealth:        dv1.ClusterHealth{Health: dv1.Red, CGCount: 2, CGAvailableCount: 1, CGFullAvailableCount: 1},
+			ComputeGroupStatuses: []dv1.ComputeGroupStatus{{Phase: dv1.Ready}, {Phase: dv1.Reconciling}},
+		},
+	}
+	SetDisaggregatedClusterConditions(ddc)
+	if !meta.IsStatusConditionTrue(ddc.Status.Conditions, dv1.ConditionDegraded) || !meta.IsStatusConditionTrue(ddc.Status.Conditions, dv1.ConditionProgressing) {
+		t.Errorf("the red cluster should be degraded and progressing, %v", ddc.Status.Conditions)
+	}
+	if c := meta.FindStatusCondition(ddc.Status.Conditions, dv1.ConditionReady); c.Status != metav1.ConditionFalse || c.Message != "1/2 compute groups available; 1/2 compute groups fully available" {
+		t.Errorf("the ready condition is wrong, %v", c)
+	}
+}
@@ -0,0 +1,273 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"strings"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyMonitor create the `ServiceMonitor` or `PodMonitor` that scrape the metrics of component when monitoring enabled.
+// the monitors are deleted when monitoring disabled. broker not exposes metrics.
+func (d *SubDefaultController) ApplyMonitor(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
+	m := dcr.Spec.Monitoring
+	if m == nil {
+		return
+	}
+
+	name := dorisv1.GenerateComponentStatefulSetName(dcr, componentType)
+	if !m.Enabled {
+		if err := deleteMonitors(ctx, d.K8sclient, dcr.Namespace, name); err != nil {
+			klog.FromContext(ctx).Error(err, "SubDefaultController ApplyMonitor delete monitors failed", "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		}
+		return
+	}
+
+	port := resource.GetPortKey(resource.WEBSERVER_PORT)
+	if componentType == dorisv1.Component_FE {
+		port = resource.GetPortKey(resource.HTTP_PORT)
+	}
+	selector := dorisv1.GenerateExternalServiceLabels(dcr, componentType)
+	if resource.GetMonitorKind(m.Kind) == resource.PodMonitorKind {
+		selector = dorisv1.GenerateStatefulSetSelector(dcr, componentType)
+	}
+
+	opts := newMonitorOptions(m.Kind, m.Interval, m.ScrapeTimeout, m.Labels)
+	opts.Name = name
+	opts.Namespace = dcr.Namespace
+	opts.Labels[dorisv1.OwnerReference] = dcr.Name
+	opts.OwnerReferences = []metav1.OwnerReference{resource.GetOwnerReference(dcr)}
+	opts.Selector = selector
+	opts.Port = port
+	opts.Path = resource.DEFAULT_METRICS_PATH
+	opts.MetricsLabels = map[string]string{
+		resource.MetricsClusterLabel:   dcr.Name,
+		resource.MetricsComponentLabel: string(componentType),
+	}
+	if err := applyMonitor(ctx, d.K8sclient, opts); err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ApplyMonitor apply monitor failed", "kind", opts.Kind, "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(MonitorApplyFailed), monitorFailedMessage(opts.Kind, string(componentType), err))
+	}
+}
+
+// ApplyMonitor create the `ServiceMonitor` or `PodMonitor` that scrape the metrics of the statefulset when monitoring enabled, cg is nil when the component is not compute group.
+// the ServiceMonitor selects the service by its labels, and the PodMonitor selects the pods of statefulset.
+func (d *DisaggregatedSubDefaultController) ApplyMonitor(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster, componentType dv1.DisaggregatedComponentType, cg *dv1.ComputeGroup,
+	st *appv1.StatefulSet, svc *corev1.Service) {
+	m := ddc.Spec.Monitoring
+	if m == nil {
+		return
+	}
+
+	if !m.Enabled {
+		if err := deleteMonitors(ctx, d.K8sclient, ddc.Namespace, st.Name); err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController ApplyMonitor delete monitors failed", "name", st.Name, "namespace", ddc.Namespace)
+		}
+		return
+	}
+
+	port, path := resource.GetPortKey(resource.WEBSERVER_PORT), resource.DEFAULT_METRICS_PATH
+	switch componentType {
+	case dv1.DisaggregatedFE:
+		port = resource.GetPortKey(resource.HTTP_PORT)
+	case dv1.DisaggregatedMS:
+		port, path = resource.GetPortKey(resource.BRPC_LISTEN_PORT), resource.MS_METRICS_PATH
+	}
+	selector := svc.Labels
+	if resource.GetMonitorKind(m.Kind) == resource.PodMonitorKind {
+		selector = st.Spec.Selector.MatchLabels
+	}
+
+	opts := newMonitorOptions(m.Kind, m.Interval, m.ScrapeTimeout, m.Labels)
+	opts.Name = st.Name
+	opts.Namespace = ddc.Namespace
+	opts.Labels[dv1.DorisDisaggregatedClusterName] = ddc.Name
+	opts.OwnerReferences = st.OwnerReferences
+	opts.Selector = selector
+	opts.Port = port
+	opts.Path = path
+	opts.MetricsLabels = map[string]string{
+		resource.MetricsClusterLabel:   ddc.Name,
+		resource.MetricsComponentLabel: strings.ToLower(string(componentType)),
+	}
+	if cg != nil {
+		opts.MetricsLabels[resource.MetricsComputeGroupLabel] = cg.UniqueId
+	}
+	if err := applyMonitor(ctx, d.K8sclient, opts); err != nil {
+		klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController ApplyMonitor apply monitor failed", "kind", opts.Kind, "name", st.Name, "namespace", ddc.Namespace)
+		d.K8srecorder.Event(ddc, string(EventWarning), string(MonitorApplyFailed), monitorFailedMessage(opts.Kind, st.Name, err))
+	}
+}
+
+// ApplyPrometheusRule create the `PrometheusRule` with the alerts of cluster when monitoring and rules enabled, the PrometheusRule is deleted when disabled.
+func (d *SubDefaultController) ApplyPrometheusRule(ctx context.Context, dcr *dorisv1.DorisCluster) {
+	m := dcr.Spec.Monitoring
+	if m == nil {
+		return
+	}
+
+	name := GeneratePrometheusRuleName(dcr.Name)
+	if !m.Enabled || m.Rules == nil || !m.Rules.Enabled {
+		if err := deletePrometheusRule(ctx, d.K8sclient, dcr.Namespace, name); err != nil {
+			klog.FromContext(ctx).Error(err, "SubDefaultController ApplyPrometheusRule delete failed", "namespace", dcr.Namespace, "name", name)
+		}
+		return
+	}
+
+	opts := newPrometheusRuleOptions((*dv1.MonitoringRules)(m.Rules))
+	opts.Name = name
+	opts.Namespace = dcr.Namespace
+	opts.Labels[dorisv1.OwnerReference] = dcr.Name
+	opts.OwnerReferences = []metav1.OwnerReference{resource.GetOwnerReference(dcr)}
+	opts.ClusterName = dcr.Name
+	if err := k8s.ServerSideApply(ctx, d.K8sclient, resource.BuildPrometheusRule(opts)); err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ApplyPrometheusRule apply failed", "namespace", dcr.Namespace, "name", name)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(MonitorApplyFailed), monitorFailedMessage(resource.PrometheusRuleKind, dcr.Name, err))
+	}
+}
+
+// ApplyPrometheusRule create the `PrometheusRule` with the alerts of cluster and compute groups when monitoring and rules enabled, the PrometheusRule is deleted when disabled.
+func (d *DisaggregatedSubDefaultController) ApplyPrometheusRule(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) {
+	m := ddc.Spec.Monitoring
+	if m == nil {
+		return
+	}
+
+	name := GeneratePrometheusRuleName(ddc.Name)
+	if !m.Enabled || m.Rules == nil || !m.Rules.Enabled {
+		if err := deletePrometheusRule(ctx, d.K8sclient, ddc.Namespace, name); err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController ApplyPrometheusRule delete failed", "namespace", ddc.Namespace, "name", name)
+		}
+		return
+	}
+
+	opts := newPrometheusRuleOptions(m.Rules)
+	opts.Name = name
+	opts.Namespace = ddc.Namespace
+	opts.Labels[dv1.DorisDisaggregatedClusterName] = ddc.Name
+	opts.OwnerReferences = []metav1.OwnerReference{resource.GetOwnerReference(ddc)}
+	opts.ClusterName = ddc.Name
+	opts.ComputeGroupAlert = true
+	if err := k8s.ServerSideApply(ctx, d.K8sclient, resource.BuildPrometheusRule(opts)); err != nil {
+		klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController ApplyPrometheusRule apply failed", "namespace", ddc.Namespace, "name", name)
+		d.K8srecorder.Event(ddc, string(EventWarning), string(MonitorApplyFailed), monitorFailedMessage(resource.PrometheusRuleKind, ddc.Name, err))
+	}
+}
+
+// GeneratePrometheusRuleName return the name of PrometheusRule of the cluster.
+func GeneratePrometheusRuleName(clusterName string) string {
+	return clusterName + "-alert-rules"
+}
+
+// the rules of DorisCluster and DorisDisaggregatedCluster have the same fields.
+func newPrometheusRuleOptions(rules *dv1.MonitoringRules) resource.PrometheusRuleOptions {
+	opts := resource.PrometheusRuleOptions{Labels: map[string]string{}}
+	for k, v := range rules.Labels {
+		opts.Labels[k] = v
+	}
+	if rules.For != nil {
+		opts.For = rules.For.Duration
+	}
+	if rules.DiskUsagePercentThreshold != nil {
+		opts.DiskUsagePercentThreshold = *rules.DiskUsagePercentThreshold
+	}
+	if rules.CompactionScoreThreshold != nil {
+		opts.CompactionScoreThreshold = *rules.CompactionScoreThreshold
+	}
+	if rules.DecommissionTimeout != nil {
+		opts.DecommissionTimeout = rules.DecommissionTimeout.Duration
+	}
+	return opts
+}
+
+// deletePrometheusRule delete the PrometheusRule, not found or the crd not installed is not an error.
+func deletePrometheusRule(ctx context.Context, k8sclient client.Client, namespace, name string) error {
+	return deleteMonitor(ctx, k8sclient, resource.PrometheusRuleKind, namespace, name)
+}
+
+func newMonitorOptions(kind string, interval, scrapeTimeout *metav1.Duration, labels map[string]string) resource.MonitorOptions {
+	opts := resource.MonitorOptions{
+		Kind:   resource.GetMonitorKind(kind),
+		Labels: map[string]string{},
+	}
+	for k, v := range labels {
+		opts.Labels[k] = v
+	}
+	if interval != nil {
+		opts.Interval = interval.Duration
+	}
+	if scrapeTimeout != nil {
+		opts.ScrapeTimeout = scrapeTimeout.Duration
+	}
+	return opts
+}
+
+// applyMonitor apply the monitor, the monitor of the other kind with the same name is deleted for switching kind.
+func applyMonitor(ctx context.Context, k8sclient client.Client, opts resource.MonitorOptions) error {
+	otherKind := resource.PodMonitorKind
+	if opts.Kind == resource.PodMonitorKind {
+		otherKind = resource.ServiceMonitorKind
+	}
+	if err := deleteMonitor(ctx, k8sclient, otherKind, opts.Namespace, opts.Name); err != nil {
+		return err
+	}
+	return k8s.ServerSideApply(ctx, k8sclient, resource.BuildMonitor(opts))
+}
+
+// deleteMonitors delete the ServiceMonitor and PodMonitor of the component.
+func deleteMonitors(ctx context.Context, k8sclient client.Client, namespace, name string) error {
+	for _, kind := range []string{resource.ServiceMonitorKind, resource.PodMonitorKind} {
+		if err := deleteMonitor(ctx, k8sclient, kind, namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteMonitor delete the monitor, not found or the crd not installed is not an error.
+func deleteMonitor(ctx context.Context, k8sclient client.Client, kind, namespace, name string) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: resource.MonitoringGroup, Version: resource.MonitoringVersion, Kind: kind})
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	if err := k8sclient.Delete(ctx, u); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+func monitorFailedMessage(kind, target string, err error) string {
+	if meta.IsNoMatchError(err) {
+		return "the crd of " + kind + " not installed, please install Prometheus Operator for monitoring " + target + "."
+	}
+	return "apply the " + kind + " of " + target + " failed, " + err.Error()
+}
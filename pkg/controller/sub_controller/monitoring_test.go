@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestMonitor(kind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: resource.MonitoringGroup, Version: resource.MonitoringVersion, Kind: kind})
+	u.SetNamespace("default")
+	u.SetName(name)
+	return u
+}
+
+func monitorExist(k8sclient client.Client, kind, name string) bool {
+	err := k8sclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, newTestMonitor(kind, name))
+	return !apierrors.IsNotFound(err)
+}
+
+func Test_ApplyMonitor(t *testing.T) {
+	dcr := &dorisv1.DorisCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "doris.selectdb.com/v1", Kind: "DorisCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       dorisv1.DorisClusterSpec{FeSpec: &dorisv1.FeSpec{}},
+	}
+	name := dorisv1.GenerateComponentStatefulSetName(dcr, dorisv1.Component_FE)
+	k8sclient := fake.NewClientBuilder().WithObjects(newTestMonitor(resource.ServiceMonitorKind, name)).Build()
+	recorder := record.NewFakeRecorder(10)
+	d := &SubDefaultController{K8sclient: k8sclient, K8srecorder: recorder}
+
+	// not configured, the existing monitor is not touched.
+	d.ApplyMonitor(context.Background(), dcr, dorisv1.Component_FE)
+	if !monitorExist(k8sclient, resource.ServiceMonitorKind, name) {
+		t.Fatalf("the monitor should not be deleted when monitoring not configured.")
+	}
+
+	// switching to PodMonitor deletes the ServiceMonitor.
+	dcr.Spec.Monitoring = &dorisv1.Monitoring{Enabled: true, Kind: resource.PodMonitorKind}
+	d.ApplyMonitor(context.Background(), dcr, dorisv1.Component_FE)
+	if monitorExist(k8sclient, resource.ServiceMonitorKind, name) {
+		t.Errorf("the ServiceMonitor should be deleted when kind switched to PodMonitor.")
+	}
+	// the fake client can not apply the monitor, the failure is recorded as event.
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, string(MonitorApplyFailed)) {
+			t.Errorf("unexpected event %s", e)
+		}
+	default:
+		t.Errorf("expected the event of applying monitor failed.")
+	}
+
+	// disabled, the monitors are deleted.
+	if err := k8sclient.Create(context.Background(), newTestMonitor(resource.PodMonitorKind, name)); err != nil {
+		t.Fatalf("create PodMonitor failed, err=%s", err.Error())
+	}
+	dcr.Spec.Monitoring.Enabled = false
+	d.ApplyMonitor(context.Background(), dcr, dorisv1.Component_FE)
+	if monitorExist(k8sclient, resource.PodMonitorKind, name) {
+		t.Errorf("the PodMonitor should be deleted when monitoring disabled.")
+	}
+}
+
+func Test_ApplyPrometheusRule(t *testing.T) {
+	ddc := &dv1.DorisDisaggregatedCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "disaggregated.cluster.doris.com/v1", Kind: "DorisDisaggregatedCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: dv1.DorisDisaggregatedClusterSpec{
+			Monitoring: &dv1.Monitoring{Enabled: true, Rules: &dv1.MonitoringRules{Enabled: true}},
+		},
+	}
+	name := GeneratePrometheusRuleName(ddc.Name)
+	k8sclient := fake.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
+	d := &DisaggregatedSubDefaultController{K8sclient: k8sclient, K8srecorder: recorder}
+
+	// the fake client can not apply the PrometheusRule, the failure is recorded as event.
+	d.ApplyPrometheusRule(context.Background(), ddc)
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, string(MonitorApplyFailed)) || !strings.Contains(e, resource.PrometheusRuleKind) {
+			t.Errorf("unexpected event %s", e)
+		}
+	default:
+		t.Errorf("expected the event of applying PrometheusRule failed.")
+	}
+
+	// rules disabled, the PrometheusRule is deleted.
+	if err := k8sclient.Create(context.Background(), newTestMonitor(resource.PrometheusRuleKind, name)); err != nil {
+		t.Fatalf("create PrometheusRule failed, err=%s", err.Error())
+	}
+	ddc.Spec.Monitoring.Rules.Enabled = false
+	d.ApplyPrometheusRule(context.Background(), ddc)
+	if monitorExist(k8sclient, resource.PrometheusRuleKind, name) {
+		t.Errorf("the PrometheusRule should be deleted when rules disabled.")
+	}
+}
@@ -0,0 +1,251 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/set"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodesRefreshInterval is the min interval of refreshing the nodes in status, the cluster is requeued in it to keep the nodes fresh.
+const NodesRefreshInterval = time.Minute
+
+// nodesRefreshRequired return true when the nodes never queried or the last refreshing is older than NodesRefreshInterval.
+func nodesRefreshRequired(lastRefreshTime *metav1.Time) bool {
+	return lastRefreshTime == nil || lastRefreshTime.IsZero() || time.Since(lastRefreshTime.Time) >= NodesRefreshInterval
+}
+
+// showNodes return the frontends and backends from fe master, the connecting and querying are bounded by healthQueryTimeout to not block reconciling when fe not reachable.
+func showNodes(ctx context.Context, connect func(ctx context.Context) (*mysql.DB, error)) ([]*mysql.Frontend, []*mysql.Backend, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthQueryTimeout)
+	defer cancel()
+	db, err := connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+	fes, err := db.ShowFrontends()
+	if err != nil {
+		return nil, nil, err
+	}
+	bes, err := db.ShowBackends()
+	if err != nil {
+		return nil, nil, err
+	}
+	return fes, bes, nil
+}
+
+// getPodNames return the names of pods selected by labels, the key is pod ip.
+func getPodNames(ctx context.Context, k8sclient client.Client, namespace string, labels map[string]string) map[string]string {
+	pods, err := k8s.GetPods(ctx, k8sclient, namespace, labels)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "getPodNames list pods failed", "labels", labels)
+		return nil
+	}
+	podNames := make(map[string]string)
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" {
+			podNames[pod.Status.PodIP] = pod.Name
+		}
+	}
+	return podNames
+}
+
+// nodePodName resolve the pod name of node by the pod ip, or the first segment of fqdn when the node registered with fqdn.
+func nodePodName(host string, podNames map[string]string) string {
+	if podName, ok := podNames[host]; ok {
+		return podName
+	}
+	// use fqdn, like: doriscluster-sample-be-0.doriscluster-sample-be-internal.doris.svc.cluster.local
+	if strings.Contains(host, ".") && net.ParseIP(host) == nil {
+		return strings.Split(host, ".")[0]
+	}
+	return ""
+}
+
+// BuildNodesStatus build the view of nodes from the frontends and backends displayed by fe, podNames is used to map the nodes to pods, the key is pod ip.
+func BuildNodesStatus(fes []*mysql.Frontend, bes []*mysql.Backend, podNames map[string]string) *dorisv1.NodesStatus {
+	ns := &dorisv1.NodesStatus{LastRefreshTime: metav1.Now()}
+	for _, fe := range fes {
+		ns.Frontends = append(ns.Frontends, dorisv1.NodeStatus{
+			PodName:       nodePodName(fe.Host, podNames),
+			Host:          fe.Host,
+			Role:          fe.Role,
+			IsMaster:      fe.IsMaster,
+			Alive:         fe.Alive,
+			Version:       stringValue(fe.Version),
+			LastStartTime: stringValue(fe.LastStartTime),
+		})
+	}
+	for _, be := range bes {
+		ns.Backends = append(ns.Backends, dorisv1.NodeStatus{
+			PodName:          nodePodName(be.Host, podNames),
+			Host:             be.Host,
+			Role:             be.NodeRole,
+			Alive:            be.Alive,
+			Version:          stringValue(be.Version),
+			LastStartTime:    stringValue(be.LastStartTime),
+			TabletNum:        be.TabletNum,
+			DataUsedCapacity: be.DataUsedCapacity,
+			Decommissioned:   be.SystemDecommissioned,
+		})
+	}
+	return ns
+}
+
+// nodesOfStatus convert the nodes in status to the frontends and backends, only the fields used for computing the health are kept.
+func nodesOfStatus(ns *dorisv1.NodesStatus) ([]*mysql.Frontend, []*mysql.Backend) {
+	var fes []*mysql.Frontend
+	var bes []*mysql.Backend
+	for _, n := range ns.Frontends {
+		fes = append(fes, &mysql.Frontend{Host: n.Host, Role: n.Role, IsMaster: n.IsMaster, Alive: n.Alive})
+	}
+	for _, n := range ns.Backends {
+		bes = append(bes, &mysql.Backend{Host: n.Host, NodeRole: n.Role, Alive: n.Alive, SystemDecommissioned: n.Decommissioned})
+	}
+	return fes, bes
+}
+
+// BuildDisaggregatedNodesStatus build the view of nodes for DorisDisaggregatedCluster, the backends carry the compute group parsed from tag.
+func BuildDisaggregatedNodesStatus(fes []*mysql.Frontend, bes []*mysql.Backend, podNames map[string]string) *dv1.NodesStatus {
+	ns := BuildNodesStatus(fes, bes, podNames)
+	dns := &dv1.NodesStatus{LastRefreshTime: ns.LastRefreshTime}
+	for _, n := range ns.Frontends {
+		dns.Frontends = append(dns.Frontends, toDisaggregatedNodeStatus(n))
+	}
+	for i, n := range ns.Backends {
+		dn := toDisaggregatedNodeStatus(n)
+		dn.ComputeGroup = computeGroupNameOfTag(bes[i].Tag)
+		dns.Backends = append(dns.Backends, dn)
+	}
+	return dns
+}
+
+func toDisaggregatedNodeStatus(n dorisv1.NodeStatus) dv1.NodeStatus {
+	return dv1.NodeStatus{
+		PodName:          n.PodName,
+		Host:             n.Host,
+		Role:             n.Role,
+		IsMaster:         n.IsMaster,
+		Alive:            n.Alive,
+		Version:          n.Version,
+		LastStartTime:    n.LastStartTime,
+		TabletNum:        n.TabletNum,
+		DataUsedCapacity: n.DataUsedCapacity,
+		Decommissioned:   n.Decommissioned,
+	}
+}
+
+// computeGroupNameOfTag return the compute group name in the tag of backend, empty when the tag not carry it.
+func computeGroupNameOfTag(tag string) string {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(tag), &m); err != nil {
+		return ""
+	}
+	if name, ok := m[mysql.COMPUTE_GROUP_NAME]; ok {
+		return fmt.Sprintf("%s", name)
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// RefreshNodes query the nodes from fe master and display them in status when the nodes are stale, the nodes keep unchanged when fe not available.
+func (d *DisaggregatedSubDefaultController) RefreshNodes(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) {
+	if ddc.Status.FEStatus.AvailableStatus != dv1.Available {
+		return
+	}
+	if ddc.Status.Nodes != nil && !nodesRefreshRequired(&ddc.Status.Nodes.LastRefreshTime) {
+		return
+	}
+
+	fes, bes, err := showNodes(ctx, func(ctx context.Context) (*mysql.DB, error) {
+		return d.GetMasterSqlClient(ctx, ddc)
+	})
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController RefreshNodes show nodes failed")
+		return
+	}
+	podNames := getPodNames(ctx, d.K8sclient, ddc.Namespace, map[string]string{dv1.DorisDisaggregatedClusterName: ddc.Name})
+	if ddc.Status.Nodes != nil {
+		var lastHosts []string
+		for _, n := range ddc.Status.Nodes.Frontends {
+			lastHosts = append(lastHosts, n.Host)
+		}
+		recordFrontendChanges(d.K8srecorder, ddc, lastHosts, fes)
+	}
+	ddc.Status.Nodes = BuildDisaggregatedNodesStatus(fes, bes, podNames)
+}
+
+// FrontendHosts return the hosts of frontends.
+func FrontendHosts(fes []*mysql.Frontend) []string {
+	var hosts []string
+	for _, fe := range fes {
+		hosts = append(hosts, fe.Host)
+	}
+	return hosts
+}
+
+// diffHosts return the hosts in current but not in last as added, and the hosts in last but not in current as dropped.
+func diffHosts(last, current []string) ([]string, []string) {
+	lastSet := set.NewSetString(last...)
+	currentSet := set.NewSetString(current...)
+	var added, dropped []string
+	for _, h := range current {
+		if !lastSet.Find(h) {
+			added = append(added, h)
+		}
+	}
+	for _, h := range last {
+		if !currentSet.Find(h) {
+			dropped = append(dropped, h)
+		}
+	}
+	return added, dropped
+}
+
+// recordFrontendChanges record events for the frontends added into or dropped from the cluster since the nodes refreshed last time.
+func recordFrontendChanges(recorder record.EventRecorder, obj runtime.Object, lastHosts []string, fes []*mysql.Frontend) {
+	added, dropped := diffHosts(lastHosts, FrontendHosts(fes))
+	if len(added) != 0 {
+		recorder.Event(obj, string(EventNormal), string(FEAdded), "frontends "+strings.Join(added, ",")+" added into the cluster.")
+	}
+	if len(dropped) != 0 {
+		recorder.Event(obj, string(EventNormal), string(FEDropped), "frontends "+strings.Join(dropped, ",")+" dropped from the cluster.")
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_BuildNodesStatus(t *testing.T) {
+	version := "doris-2.1.7"
+	fes := []*mysql.Frontend{
+		{Host: "10.0.0.1", Role: mysql.FE_FOLLOWER_ROLE, IsMaster: true, Alive: true, Version: &version},
+		{Host: "test-fe-1.test-fe-internal.default.svc.cluster.local", Role: mysql.FE_OBSERVE_ROLE, Alive: false},
+	}
+	bes := []*mysql.Backend{
+		{Host: "10.0.0.2", NodeRole: "mix", Alive: true, TabletNum: 12, DataUsedCapacity: "1.000 GB", Tag: "{\"location\" : \"default\",\"compute_group_name\":\"cg1\"}"},
+		{Host: "10.0.0.9", NodeRole: mysql.BE_COMPUTATION_ROLE, Alive: true, SystemDecommissioned: true, Tag: "{\"location\" : \"default\"}"},
+	}
+	podNames := map[string]string{"10.0.0.1": "test-fe-0", "10.0.0.2": "test-be-0"}
+
+	ns := BuildNodesStatus(fes, bes, podNames)
+	if len(ns.Frontends) != 2 || len(ns.Backends) != 2 {
+		t.Fatalf("the nodes not match, frontends=%d backends=%d", len(ns.Frontends), len(ns.Backends))
+	}
+	if fe := ns.Frontends[0]; fe.PodName != "test-fe-0" || !fe.IsMaster || !fe.Alive || fe.Version != version {
+		t.Errorf("the master fe not displayed as expected, %+v", fe)
+	}
+	if fe := ns.Frontends[1]; fe.PodName != "test-fe-1" || fe.Role != mysql.FE_OBSERVE_ROLE || fe.Alive {
+		t.Errorf("the fe registered by fqdn not displayed as expected, %+v", fe)
+	}
+	if be := ns.Backends[0]; be.PodName != "test-be-0" || be.TabletNum != 12 || be.DataUsedCapacity != "1.000 GB" || be.Decommissioned {
+		t.Errorf("the backend not displayed as expected, %+v", be)
+	}
+	if be := ns.Backends[1]; be.PodName != "" || !be.Decommissioned || be.Role != mysql.BE_COMPUTATION_ROLE {
+		t.Errorf("the backend without pod not displayed as expected, %+v", be)
+	}
+
+	dns := BuildDisaggregatedNodesStatus(fes, bes, podNames)
+	if dns.Backends[0].ComputeGroup != "cg1" || dns.Backends[1].ComputeGroup != "" {
+		t.Errorf("the compute groups of backends not match, %s, %s", dns.Backends[0].ComputeGroup, dns.Backends[1].ComputeGroup)
+	}
+	if dns.Frontends[0].PodName != "test-fe-0" || !dns.Frontends[0].IsMaster {
+		t.Errorf("the fe of disaggregated cluster not displayed as expected, %+v", dns.Frontends[0])
+	}
+}
+
+func Test_nodesRefreshRequired(t *testing.T) {
+	if !nodesRefreshRequired(nil) || !nodesRefreshRequired(&metav1.Time{}) {
+		t.Errorf("the nodes never refreshed should be refreshed.")
+	}
+	if nodesRefreshRequired(&metav1.Time{Time: time.Now()}) {
+		t.Errorf("the nodes refreshed just now should not be refreshed.")
+	}
+	if !nodesRefreshRequired(&metav1.Time{Time: time.Now().Add(-NodesRefreshInterval)}) {
+		t.Errorf("the nodes refreshed before the interval should be refreshed.")
+	}
+}
+
+func Test_recordFrontendChanges(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	fes := []*mysql.Frontend{{Host: "10.0.0.1"}, {Host: "10.0.0.3"}}
+	recordFrontendChanges(recorder, &dorisv1.DorisCluster{}, []string{"10.0.0.1", "10.0.0.2"}, fes)
+
+	expects := []string{
+		"Normal FEAdded frontends 10.0.0.3 added into the cluster.",
+		"Normal FEDropped frontends 10.0.0.2 dropped from the cluster.",
+	}
+	for _, expect := range expects {
+		if e := <-recorder.Events; e != expect {
+			t.Errorf("the event not match, expect %q, got %q", expect, e)
+		}
+	}
+
+	recordFrontendChanges(recorder, &dorisv1.DorisCluster{}, []string{"10.0.0.1", "10.0.0.3"}, fes)
+	if len(recorder.Events) != 0 {
+		t.Errorf("no event should be recorded when the frontends not changed.")
+	}
+}
+
+func Test_showNodesBounded(t *testing.T) {
+	connectErr := errors.New("fe not reachable")
+	var timeout time.Duration
+	_, _, err := showNodes(context.Background(), func(ctx context.Context) (*mysql.DB, error) {
+		var terr error
+		if timeout, terr = sqlTimeoutOfContext(ctx); terr != nil {
+			return nil, terr
+		}
+		return nil, connectErr
+	})
+	if !errors.Is(err, connectErr) {
+		t.Errorf("expected the error of connecting, got %v", err)
+	}
+	// the connections are bounded by the timeout of querying nodes.
+	if timeout <= 0 || timeout > healthQueryTimeout {
+		t.Errorf("expected the sql timeout in %s, got %s", healthQueryTimeout, timeout)
+	}
+}
@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the keys of the secret that stores the state of password rotation.
+const (
+	rotation_state_username_key         = "username"
+	rotation_state_password_key         = "password"
+	rotation_state_pending_password_key = "pendingPassword"
+	rotation_state_pending_source_key   = "pendingSource"
+	rotation_state_last_rotation_key    = "lastRotationTime"
+)
+
+// the pending password comes from AuthSecret or is generated by operator when the rotation interval elapsed.
+const (
+	rotation_source_secret   = "secret"
+	rotation_source_schedule = "schedule"
+)
+
+const (
+	generated_password_length  = 24
+	generated_password_charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// passwordRotator rotates the password of the management user in doris.
+// the confirmed password and the pending password are persisted in the state secret before changing the password in doris,
+// so the rotation can be resumed when operator crashed in the middle of rotation.
+type passwordRotator struct {
+	k8sclient       client.Client
+	namespace       string
+	authSecretName  string
+	stateSecretName string
+	ownerRef        metav1.OwnerReference
+	labels          map[string]string
+	interval        time.Duration
+	// connect build the sql client logged in with user and password, the client is closed by caller.
+	connect func(user, password string) (*mysql.DB, error)
+}
+
+// rotate the password when the password in AuthSecret changed or the rotation interval elapsed, return true when the password rotated.
+func (pr *passwordRotator) rotate(ctx context.Context) (bool, error) {
+	authSecret, err := k8s.GetSecret(ctx, pr.k8sclient, pr.namespace, pr.authSecretName)
+	if err != nil {
+		return false, err
+	}
+	user, desired := resource.GetDorisLoginInformation(authSecret)
+
+	state, err := k8s.GetSecret(ctx, pr.k8sclient, pr.namespace, pr.stateSecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	// rotation enabled first time or the management user changed, the password in AuthSecret is the password of user in doris.
+	if state == nil || string(state.Data[rotation_state_username_key]) != user {
+		return false, pr.saveState(ctx, state, map[string]string{
+			rotation_state_username_key:      user,
+			rotation_state_password_key:      desired,
+			rotation_state_last_rotation_key: time.Now().Format(time.RFC3339),
+		})
+	}
+
+	current := string(state.Data[rotation_state_password_key])
+	pending := string(state.Data[rotation_state_pending_password_key])
+	source := string(state.Data[rotation_state_pending_source_key])
+	if pending == "" {
+		if desired != current {
+			pending, source = desired, rotation_source_secret
+		} else if pr.nextRotation(state) == 0 {
+			if pending, err = generatePassword(); err != nil {
+				return false, err
+			}
+			source = rotation_source_schedule
+		} else {
+			return false, nil
+		}
+
+		// persist the pending password before changing it in doris.
+		if err := pr.saveState(ctx, state, map[string]string{
+			rotation_state_username_key:         user,
+			rotation_state_password_key:         current,
+			rotation_state_pending_password_key: pending,
+			rotation_state_pending_source_key:   source,
+			rotation_state_last_rotation_key:    string(state.Data[rotation_state_last_rotation_key]),
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	if err := pr.changePassword(user, current, pending); err != nil {
+		return false, err
+	}
+
+	// the generated password is written back to AuthSecret for the pods of cluster. if the password in AuthSecret was changed by user in the rotation,
+	// the password of user is kept and rotated in next reconciling.
+	if source == rotation_source_schedule && desired == current {
+		if authSecret.Data == nil {
+			authSecret.Data = map[string][]byte{}
+		}
+		authSecret.Data[rotation_state_password_key] = []byte(pending)
+		if err := k8s.UpdateSecret(ctx, pr.k8sclient, authSecret); err != nil {
+			return false, err
+		}
+	}
+
+	return true, pr.saveState(ctx, state, map[string]string{
+		rotation_state_username_key:      user,
+		rotation_state_password_key:      pending,
+		rotation_state_last_rotation_key: time.Now().Format(time.RFC3339),
+	})
+}
+
+// changePassword set the password of user from old to new, and verify the login with the new password.
+// if the new password can log in, the password has been changed before operator crashed.
+func (pr *passwordRotator) changePassword(user, oldPassword, newPassword string) error {
+	if db, err := pr.connect(user, newPassword); err == nil {
+		db.Close()
+		return nil
+	}
+
+	db, err := pr.connect(user, oldPassword)
+	if err != nil {
+		return fmt.Errorf("login with the old password failed, %s", err.Error())
+	}
+	defer db.Close()
+	// the user may be created with a host other than '%', the password is set on every identity of user.
+	hosts, err := db.GetUserHosts(user)
+	if err != nil {
+		return fmt.Errorf("get the hosts of user failed, %s", err.Error())
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("the user %s not found in mysql.user", user)
+	}
+	for _, host := range hosts {
+		if err := db.SetPassword(user, host, newPassword); err != nil {
+			return fmt.Errorf("set password of '%s'@'%s' failed, %s", user, host, err.Error())
+		}
+	}
+
+	vdb, err := pr.connect(user, newPassword)
+	if err != nil {
+		return fmt.Errorf("verify login with the new password failed, %s", err.Error())
+	}
+	vdb.Close()
+	return nil
+}
+
+// nextRotation return the duration to the next scheduled rotation, 0 means the rotation is due and -1 means not scheduled.
+func (pr *passwordRotator) nextRotation(state *corev1.Secret) time.Duration {
+	if pr.interval <= 0 || state == nil {
+		return -1
+	}
+	last, err := time.Parse(time.RFC3339, string(state.Data[rotation_state_last_rotation_key]))
+	if err != nil {
+		return -1
+	}
+	next := time.Until(last.Add(pr.interval))
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// saveState create the state secret when existing is nil, otherwise update the existing with data.
+func (pr *passwordRotator) saveState(ctx context.Context, existing *corev1.Secret, data map[string]string) error {
+	bytesData := map[string][]byte{}
+	for k, v := range data {
+		bytesData[k] = []byte(v)
+	}
+	if existing != nil {
+		existing.Data = bytesData
+		return k8s.UpdateSecret(ctx, pr.k8sclient, existing)
+	}
+
+	return k8s.CreateSecret(ctx, pr.k8sclient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pr.stateSecretName,
+			Namespace:       pr.namespace,
+			Labels:          pr.labels,
+			OwnerReferences: []metav1.OwnerReference{pr.ownerRef},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: bytesData,
+	})
+}
+
+// getRotatedCredentials return the confirmed credentials in the state of password rotation, ok is false when the state not exist.
+func getRotatedCredentials(ctx context.Context, k8sclient client.Client, namespace, stateSecretName string) (user, password string, ok bool) {
+	state, err := k8s.GetSecret(ctx, k8sclient, namespace, stateSecretName)
+	if err != nil {
+		return "", "", false
+	}
+	return string(state.Data[rotation_state_username_key]), string(state.Data[rotation_state_password_key]), true
+}
+
+// clearPasswordRotationState delete the state secret when password rotation disabled.
+func clearPasswordRotationState(ctx context.Context, k8sclient client.Client, namespace, stateSecretName string) {
+	if _, err := k8s.GetSecret(ctx, k8sclient, namespace, stateSecretName); err != nil {
+		return
+	}
+	if err := k8s.DeleteSecret(ctx, k8sclient, namespace, stateSecretName); err != nil {
+		klog.FromContext(ctx).Error(err, "clearPasswordRotationState delete secret failed", "namespace", namespace, "name", stateSecretName)
+	}
+}
+
+// PasswordRotationRequeueAfter return the duration to the next scheduled rotation of cluster, 0 means not scheduled.
+func PasswordRotationRequeueAfter(ctx context.Context, k8sclient client.Client, namespace, clusterName string, interval *metav1.Duration) time.Duration {
+	if interval == nil || interval.Duration <= 0 {
+		return 0
+	}
+	state, err := k8s.GetSecret(ctx, k8sclient, namespace, resource.GetPasswordRotationStateSecretName(clusterName))
+	if err != nil {
+		return 0
+	}
+	pr := &passwordRotator{interval: interval.Duration}
+	next := pr.nextRotation(state)
+	if next < 0 {
+		return 0
+	}
+	// the rotation is due but not finished, retry later.
+	if next == 0 {
+		return time.Minute
+	}
+	return next
+}
+
+func generatePassword() (string, error) {
+	password := make([]byte, generated_password_length)
+	max := big.NewInt(int64(len(generated_password_charset)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", errors.New("generate password failed, " + err.Error())
+		}
+		password[i] = generated_password_charset[n.Int64()]
+	}
+	return string(password), nil
+}
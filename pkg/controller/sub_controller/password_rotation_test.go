@@ -0,0 +1,127 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/jmoiron/sqlx"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_passwordRotator(t *testing.T) {
+	ctx := context.Background()
+	authSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-auth"},
+		Data:       map[string][]byte{"username": []byte("root"), "password": []byte("old")},
+	}
+	k8sclient := fake.NewClientBuilder().WithObjects(authSecret).Build()
+
+	// the password accepted by the mocked doris, it is changed when the sql of setting password executed.
+	dorisPassword := "old"
+	setPasswordTimes := 0
+	// the user is created with a host other than '%', the password should be set on the identity of the host.
+	setPasswordIdentity := ""
+	setPasswordRegexp := regexp.MustCompile(`FOR ('.*'@'.*') = PASSWORD\('(.*)'\);$`)
+	pr := &passwordRotator{
+		k8sclient:       k8sclient,
+		namespace:       "default",
+		authSecretName:  "test-auth",
+		stateSecretName: "test-auth-rotation",
+		ownerRef:        metav1.OwnerReference{APIVersion: "doris.apache.com/v1", Kind: "DorisCluster", Name: "test", UID: "uid"},
+		connect: func(user, password string) (*mysql.DB, error) {
+			if password != dorisPassword {
+				return nil, errors.New("access denied")
+			}
+			sdb, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+				if m := setPasswordRegexp.FindStringSubmatch(actualSQL); len(m) == 3 {
+					setPasswordIdentity = m[1]
+					dorisPassword = m[2]
+					setPasswordTimes++
+				}
+				return nil
+			})))
+			if err != nil {
+				return nil, err
+			}
+			mock.ExpectQuery("SELECT Host FROM mysql.user").WillReturnRows(sqlmock.NewRows([]string{"Host"}).AddRow("10.0.0.%"))
+			mock.ExpectExec("SET PASSWORD").WillReturnResult(sqlmock.NewResult(0, 0))
+			return &mysql.DB{DB: sqlx.NewDb(sdb, "mysql")}, nil
+		},
+	}
+
+	// the first time only records the password in AuthSecret.
+	if rotated, err := pr.rotate(ctx); err != nil || rotated {
+		t.Fatalf("passwordRotator initial state failed, rotated=%t, err=%v", rotated, err)
+	}
+
+	// the password in AuthSecret changed.
+	authSecret, _ = k8s.GetSecret(ctx, k8sclient, "default", "test-auth")
+	authSecret.Data["password"] = []byte("new")
+	if err := k8s.UpdateSecret(ctx, k8sclient, authSecret); err != nil {
+		t.Fatalf("update auth secret failed, err=%s", err.Error())
+	}
+	if rotated, err := pr.rotate(ctx); err != nil || !rotated {
+		t.Fatalf("passwordRotator rotate failed, rotated=%t, err=%v", rotated, err)
+	}
+	if dorisPassword != "new" || setPasswordTimes != 1 || setPasswordIdentity != "'root'@'10.0.0.%'" {
+		t.Errorf("passwordRotator not set the new password in doris, identity=%s, password=%s", setPasswordIdentity, dorisPassword)
+	}
+	if user, password, ok := getRotatedCredentials(ctx, k8sclient, "default", "test-auth-rotation"); !ok || user != "root" || password != "new" {
+		t.Errorf("the rotated credentials is %s:%s, expect root:new", user, password)
+	}
+
+	// crashed after the password changed in doris, the rotation is resumed by logging in with the pending password.
+	state, _ := k8s.GetSecret(ctx, k8sclient, "default", "test-auth-rotation")
+	state.Data[rotation_state_password_key] = []byte("old")
+	state.Data[rotation_state_pending_password_key] = []byte("new")
+	state.Data[rotation_state_pending_source_key] = []byte(rotation_source_secret)
+	if err := k8s.UpdateSecret(ctx, k8sclient, state); err != nil {
+		t.Fatalf("update state secret failed, err=%s", err.Error())
+	}
+	if rotated, err := pr.rotate(ctx); err != nil || !rotated || setPasswordTimes != 1 {
+		t.Errorf("passwordRotator resume rotation failed, rotated=%t, err=%v", rotated, err)
+	}
+
+	// the scheduled rotation writes the generated password back to AuthSecret.
+	pr.interval = time.Hour
+	state, _ = k8s.GetSecret(ctx, k8sclient, "default", "test-auth-rotation")
+	state.Data[rotation_state_last_rotation_key] = []byte(time.Now().Add(-2 * time.Hour).Format(time.RFC3339))
+	if err := k8s.UpdateSecret(ctx, k8sclient, state); err != nil {
+		t.Fatalf("update state secret failed, err=%s", err.Error())
+	}
+	if rotated, err := pr.rotate(ctx); err != nil || !rotated {
+		t.Fatalf("passwordRotator scheduled rotation failed, rotated=%t, err=%v", rotated, err)
+	}
+	authSecret, _ = k8s.GetSecret(ctx, k8sclient, "default", "test-auth")
+	if string(authSecret.Data["password"]) != dorisPassword || dorisPassword == "new" {
+		t.Errorf("passwordRotator scheduled rotation not write the generated password back to AuthSecret.")
+	}
+	if rotated, _ := pr.rotate(ctx); rotated {
+		t.Errorf("passwordRotator rotated the password before the interval elapsed.")
+	}
+}
@@ -0,0 +1,297 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/doris"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// the defaults of the synthetic query probing.
+const (
+	DefaultQueryProbeQuery                  = "SELECT 1"
+	DefaultQueryProbeInterval               = time.Minute
+	DefaultQueryProbeTimeout                = 5 * time.Second
+	DefaultQueryProbeFailureThreshold int32 = 3
+)
+
+// queryProbeSettings is the QueryProbe in spec with the defaults filled.
+type queryProbeSettings struct {
+	query            string
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int32
+}
+
+// newQueryProbeSettings return nil when probing not enabled.
+func newQueryProbeSettings(qp *dorisv1.QueryProbe) *queryProbeSettings {
+	if qp == nil || !qp.Enabled {
+		return nil
+	}
+	s := &queryProbeSettings{
+		query:            DefaultQueryProbeQuery,
+		interval:         DefaultQueryProbeInterval,
+		timeout:          DefaultQueryProbeTimeout,
+		failureThreshold: DefaultQueryProbeFailureThreshold,
+	}
+	if qp.Query != "" {
+		s.query = qp.Query
+	}
+	if qp.Interval != nil && qp.Interval.Duration > 0 {
+		s.interval = qp.Interval.Duration
+	}
+	if qp.Timeout != nil && qp.Timeout.Duration > 0 {
+		s.timeout = qp.Timeout.Duration
+	}
+	if qp.FailureThreshold > 0 {
+		s.failureThreshold = qp.FailureThreshold
+	}
+	return s
+}
+
+// probeRequired return true when never probed or the interval elapsed since the last probe.
+func (s *queryProbeSettings) probeRequired(lastProbeTime *metav1.Time) bool {
+	return lastProbeTime == nil || lastProbeTime.IsZero() || time.Since(lastProbeTime.Time) >= s.interval
+}
+
+// QueryProbeRequeueAfter return the interval of probing for requeueing the cluster, 0 when probing disabled.
+func QueryProbeRequeueAfter(qp *dorisv1.QueryProbe) time.Duration {
+	if s := newQueryProbeSettings(qp); s != nil {
+		return s.interval
+	}
+	return 0
+}
+
+// DisaggregatedQueryProbeRequeueAfter return the interval of probing of DorisDisaggregatedCluster, 0 when probing disabled.
+func DisaggregatedQueryProbeRequeueAfter(qp *dv1.QueryProbe) time.Duration {
+	return QueryProbeRequeueAfter((*dorisv1.QueryProbe)(qp))
+}
+
+// QueryProbeFailing return true when the probes of compute group failed continuously reaching the failure threshold, the compute group can not serve queries.
+func QueryProbeFailing(qp *dv1.QueryProbe, result *dv1.QueryProbeResult) bool {
+	s := newQueryProbeSettings((*dorisv1.QueryProbe)(qp))
+	return s != nil && result != nil && !result.Succeeded && result.ConsecutiveFailures >= s.failureThreshold
+}
+
+// probeTarget is a fe or compute group probed, connect create the sql client dedicated to the probe.
+type probeTarget struct {
+	// kind is `fe` or `compute group` used in the messages.
+	kind         string
+	target       string
+	podName      string
+	computeGroup string
+	connect      func(ctx context.Context) (*mysql.DB, error)
+	last         *dorisv1.QueryProbeResult
+}
+
+// runQueryProbes probe the targets concurrently and wait for all probes bounded by the timeout, the results are in the order of targets.
+func runQueryProbes(ctx context.Context, s *queryProbeSettings, targets []probeTarget) []dorisv1.QueryProbeResult {
+	results := make([]dorisv1.QueryProbeResult, len(targets))
+	wg := sync.WaitGroup{}
+	wg.Add(len(targets))
+	for i := range targets {
+		go func(idx int) {
+			defer wg.Done()
+			t := &targets[idx]
+			latency, err := probeQuery(ctx, s.timeout, t.connect, t.computeGroup, s.query)
+			if err != nil {
+				klog.FromContext(ctx).Error(err, "runQueryProbes probe the query failed", "kind", t.kind, "target", t.target)
+			}
+			results[idx] = nextQueryProbeResult(t, latency, err, time.Now())
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// probeQuery run the query by the sql client from connect, return the latency of query. the connecting and querying are bounded by timeout.
+func probeQuery(ctx context.Context, timeout time.Duration, connect func(ctx context.Context) (*mysql.DB, error), computeGroup, query string) (time.Duration, error) {
+	// the query runs with the credentials of management user, so it's limited to read only even though the webhook not enabled.
+	if !doris.IsSelectQuery(query) {
+		return 0, fmt.Errorf("query probe only allows a single SELECT statement, the query %q is not allowed", query)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	db, err := connect(ctx)
+	if err == nil {
+		defer db.Close()
+		start := time.Now()
+		if err = db.ProbeQuery(ctx, computeGroup, query); err == nil {
+			return time.Since(start), nil
+		}
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return timeout, fmt.Errorf("query probe timed out after %s, %s", timeout, err.Error())
+	}
+	return 0, err
+}
+
+// nextQueryProbeResult compute the result of target by the probe and the last result, the failures are counted until a probe succeeded.
+func nextQueryProbeResult(t *probeTarget, latency time.Duration, err error, now time.Time) dorisv1.QueryProbeResult {
+	r := dorisv1.QueryProbeResult{
+		Target:              t.target,
+		PodName:             t.podName,
+		Succeeded:           err == nil,
+		LatencyMilliseconds: latency.Milliseconds(),
+		LastProbeTime:       metav1.NewTime(now),
+	}
+	if err != nil {
+		r.Message = err.Error()
+		r.ConsecutiveFailures = 1
+		if t.last != nil {
+			r.ConsecutiveFailures = t.last.ConsecutiveFailures + 1
+		}
+	}
+	return r
+}
+
+// recordQueryProbeEvent record a warning event when the failures of target reached the threshold, and a normal event when the target recovered.
+func recordQueryProbeEvent(recorder record.EventRecorder, obj runtime.Object, t *probeTarget, r *dorisv1.QueryProbeResult, threshold int32) {
+	if !r.Succeeded && r.ConsecutiveFailures == threshold {
+		recorder.Event(obj, string(EventWarning), string(QueryProbeFailed), fmt.Sprintf("query probe on %s %s failed %d times continuously, %s", t.kind, t.target, r.ConsecutiveFailures, r.Message))
+	} else if r.Succeeded && t.last != nil && t.last.ConsecutiveFailures >= threshold {
+		recorder.Event(obj, string(EventNormal), string(QueryProbeRecovered), fmt.Sprintf("query probe on %s %s succeeded after %d failures.", t.kind, t.target, t.last.ConsecutiveFailures))
+	}
+}
+
+// sqlConnector return the function that connects the host by the config of sql client, the connections are bounded by the deadline of ctx.
+func sqlConnector(dbConf mysql.DBConfig, host string, tlsConfig *mysql.TLSConfig, tlsSecret *corev1.Secret) func(ctx context.Context) (*mysql.DB, error) {
+	dbConf.Host = host
+	return func(ctx context.Context) (*mysql.DB, error) {
+		conf := dbConf
+		var err error
+		if conf.Timeout, err = sqlTimeoutOfContext(ctx); err != nil {
+			return nil, err
+		}
+		db, err := mysql.NewDorisSqlDB(conf, tlsConfig, tlsSecret)
+		if err != nil {
+			return nil, err
+		}
+		return db.WithContext(ctx), nil
+	}
+}
+
+// ProbeQueries run the synthetic query on every fe at the interval of QueryProbe, the results are displayed in status. the results are cleared when probing disabled.
+func (d *SubDefaultController) ProbeQueries(ctx context.Context, dcr *dorisv1.DorisCluster) {
+	s := newQueryProbeSettings(dcr.Spec.QueryProbe)
+	if s == nil {
+		dcr.Status.QueryProbe = nil
+		return
+	}
+	if dcr.Status.Nodes == nil || (dcr.Status.QueryProbe != nil && !s.probeRequired(&dcr.Status.QueryProbe.LastProbeTime)) {
+		return
+	}
+
+	lasts := map[string]*dorisv1.QueryProbeResult{}
+	if dcr.Status.QueryProbe != nil {
+		for i := range dcr.Status.QueryProbe.Frontends {
+			lasts[dcr.Status.QueryProbe.Frontends[i].Target] = &dcr.Status.QueryProbe.Frontends[i]
+		}
+	}
+	adminUserName, password := d.GetManagementAdminUserAndPWD(ctx, dcr)
+	dbConf, tlsConfig, tlsSecret := d.getSqlClientConfig(ctx, dcr, adminUserName, password)
+	var targets []probeTarget
+	for _, fe := range dcr.Status.Nodes.Frontends {
+		targets = append(targets, probeTarget{kind: "fe", target: fe.Host, podName: fe.PodName, connect: sqlConnector(dbConf, fe.Host, tlsConfig, tlsSecret), last: lasts[fe.Host]})
+	}
+
+	results := runQueryProbes(ctx, s, targets)
+	for i := range results {
+		recordQueryProbeEvent(d.K8srecorder, dcr, &targets[i], &results[i], s.failureThreshold)
+	}
+	dcr.Status.QueryProbe = &dorisv1.QueryProbeStatus{LastProbeTime: metav1.Now(), Frontends: results}
+}
+
+// ProbeQueries run the synthetic query on every fe and in every compute group by `USE @<compute group>` at the interval of QueryProbe.
+// the compute groups without available pods are not probed. the results are cleared when probing disabled.
+func (d *DisaggregatedSubDefaultController) ProbeQueries(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) {
+	s := newQueryProbeSettings((*dorisv1.QueryProbe)(ddc.Spec.QueryProbe))
+	if s == nil {
+		ddc.Status.QueryProbe = nil
+		for i := range ddc.Status.ComputeGroupStatuses {
+			ddc.Status.ComputeGroupStatuses[i].QueryProbe = nil
+		}
+		return
+	}
+	if ddc.Status.FEStatus.AvailableStatus != dv1.Available || ddc.Status.Nodes == nil ||
+		(ddc.Status.QueryProbe != nil && !s.probeRequired(&ddc.Status.QueryProbe.LastProbeTime)) {
+		return
+	}
+
+	lasts := map[string]*dorisv1.QueryProbeResult{}
+	if ddc.Status.QueryProbe != nil {
+		for i := range ddc.Status.QueryProbe.Frontends {
+			lasts[ddc.Status.QueryProbe.Frontends[i].Target] = (*dorisv1.QueryProbeResult)(&ddc.Status.QueryProbe.Frontends[i])
+		}
+	}
+	dbConf, tlsConfig, tlsSecret := d.getSqlClientConfig(ctx, ddc)
+	var targets []probeTarget
+	for _, fe := range ddc.Status.Nodes.Frontends {
+		targets = append(targets, probeTarget{kind: "fe", target: fe.Host, podName: fe.PodName, connect: sqlConnector(dbConf, fe.Host, tlsConfig, tlsSecret), last: lasts[fe.Host]})
+	}
+	feNum := len(targets)
+
+	// the compute groups are probed through the fe service, the index of compute group status is recorded for displaying the result.
+	var cgIndexes []int
+	for i := range ddc.Status.ComputeGroupStatuses {
+		cgs := &ddc.Status.ComputeGroupStatuses[i]
+		cg := getComputeGroup(ddc, cgs.UniqueId)
+		if cg == nil || cgs.AvailableReplicas == 0 {
+			cgs.QueryProbe = nil
+			continue
+		}
+		cgName := ddc.GetCGName(cg)
+		targets = append(targets, probeTarget{kind: "compute group", target: cgName, computeGroup: cgName,
+			connect: sqlConnector(dbConf, dbConf.Host, tlsConfig, tlsSecret), last: (*dorisv1.QueryProbeResult)(cgs.QueryProbe)})
+		cgIndexes = append(cgIndexes, i)
+	}
+
+	results := runQueryProbes(ctx, s, targets)
+	ddc.Status.QueryProbe = &dv1.QueryProbeStatus{LastProbeTime: metav1.Now()}
+	for i := range results {
+		recordQueryProbeEvent(d.K8srecorder, ddc, &targets[i], &results[i], s.failureThreshold)
+		r := dv1.QueryProbeResult(results[i])
+		if i < feNum {
+			ddc.Status.QueryProbe.Frontends = append(ddc.Status.QueryProbe.Frontends, r)
+		} else {
+			ddc.Status.ComputeGroupStatuses[cgIndexes[i-feNum]].QueryProbe = &r
+		}
+	}
+}
+
+func getComputeGroup(ddc *dv1.DorisDisaggregatedCluster, uniqueId string) *dv1.ComputeGroup {
+	for i := range ddc.Spec.ComputeGroups {
+		if ddc.Spec.ComputeGroups[i].UniqueId == uniqueId {
+			return &ddc.Spec.ComputeGroups[i]
+		}
+	}
+	return nil
+}
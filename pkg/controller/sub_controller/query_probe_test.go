@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_newQueryProbeSettings(t *testing.T) {
+	if newQueryProbeSettings(nil) != nil || newQueryProbeSettings(&dorisv1.QueryProbe{}) != nil {
+		t.Errorf("the probing should be disabled when not enabled.")
+	}
+	s := newQueryProbeSettings(&dorisv1.QueryProbe{Enabled: true})
+	if s.query != DefaultQueryProbeQuery || s.interval != DefaultQueryProbeInterval || s.timeout != DefaultQueryProbeTimeout || s.failureThreshold != DefaultQueryProbeFailureThreshold {
+		t.Errorf("the defaults of probing not filled, %+v", s)
+	}
+	s = newQueryProbeSettings(&dorisv1.QueryProbe{Enabled: true, Query: "SELECT 2", Interval: &metav1.Duration{Duration: 10 * time.Second}, FailureThreshold: 1})
+	if s.query != "SELECT 2" || s.interval != 10*time.Second || s.failureThreshold != 1 {
+		t.Errorf("the configured probing not used, %+v", s)
+	}
+	if !s.probeRequired(nil) || s.probeRequired(&metav1.Time{Time: time.Now()}) || !s.probeRequired(&metav1.Time{Time: time.Now().Add(-10 * time.Second)}) {
+		t.Errorf("the probe should be required when the interval elapsed.")
+	}
+}
+
+func Test_nextQueryProbeResult(t *testing.T) {
+	target := &probeTarget{kind: "compute group", target: "cg1"}
+	r := nextQueryProbeResult(target, time.Second, errors.New("no backend"), time.Now())
+	if r.Succeeded || r.ConsecutiveFailures != 1 || r.Message != "no backend" {
+		t.Errorf("the first failed probe not recorded, %+v", r)
+	}
+
+	first := r
+	target.last = &first
+	r = nextQueryProbeResult(target, time.Second, errors.New("no backend"), time.Now())
+	if r.ConsecutiveFailures != 2 {
+		t.Errorf("the failures should be counted continuously, got %d", r.ConsecutiveFailures)
+	}
+
+	dr := dv1.QueryProbeResult(r)
+	qp := &dv1.QueryProbe{Enabled: true, FailureThreshold: 2}
+	if !QueryProbeFailing(qp, &dr) || QueryProbeFailing(&dv1.QueryProbe{Enabled: true}, &dr) || QueryProbeFailing(nil, &dr) {
+		t.Errorf("the compute group should be failing only when the failures reached the threshold.")
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	recordQueryProbeEvent(recorder, &dv1.DorisDisaggregatedCluster{}, target, &r, 2)
+	if e := <-recorder.Events; e != "Warning QueryProbeFailed query probe on compute group cg1 failed 2 times continuously, no backend" {
+		t.Errorf("the failed event not match, got %q", e)
+	}
+
+	failed := r
+	target.last = &failed
+	r = nextQueryProbeResult(target, 20*time.Millisecond, nil, time.Now())
+	if !r.Succeeded || r.ConsecutiveFailures != 0 || r.LatencyMilliseconds != 20 {
+		t.Errorf("the succeeded probe not recorded, %+v", r)
+	}
+	recordQueryProbeEvent(recorder, &dv1.DorisDisaggregatedCluster{}, target, &r, 2)
+	if e := <-recorder.Events; e != "Normal QueryProbeRecovered query probe on compute group cg1 succeeded after 2 failures." {
+		t.Errorf("the recovered event not match, got %q", e)
+	}
+}
+
+func Test_probeQueryTimeout(t *testing.T) {
+	connect := func(ctx context.Context) (*mysql.DB, error) {
+		<-ctx.Done()
+		return nil, errors.New("fe not reachable")
+	}
+
+	latency, err := probeQuery(context.Background(), 10*time.Millisecond, connect, "", DefaultQueryProbeQuery)
+	if err == nil || latency != 10*time.Millisecond {
+		t.Errorf("the probe should time out, latency=%s err=%v", latency, err)
+	}
+}
+
+func Test_probeQueryNotSelect(t *testing.T) {
+	connect := func(ctx context.Context) (*mysql.DB, error) {
+		t.Fatal("the query not a single SELECT statement should not be run")
+		return nil, nil
+	}
+	if _, err := probeQuery(context.Background(), time.Second, connect, "", "DELETE FROM db.t"); err == nil {
+		t.Errorf("the query not a single SELECT statement should be rejected")
+	}
+}
@@ -29,6 +29,7 @@ import (
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kr "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -60,7 +61,7 @@ type SubDefaultController struct {
 	K8srecorder record.EventRecorder
 }
 
-func (d *SubDefaultController) CheckRestartTimeAndInject(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) bool {
+func (d *SubDefaultController) CheckRestartTimeAndInject(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) bool {
 	var baseSpec *dorisv1.BaseSpec
 	var restartedAt string
 	var restartAnnotationsKey string
@@ -74,7 +75,7 @@ func (d *SubDefaultController) CheckRestartTimeAndInject(dcr *dorisv1.DorisClust
 		restartedAt = dcr.Annotations[dorisv1.BERestartAt]
 		restartAnnotationsKey = dorisv1.BERestartAt
 	default:
-		klog.Errorf("CheckRestartTimeAndInject dorisClusterName %s, namespace %s componentType %s not supported.", dcr.Name, dcr.Namespace, componentType)
+		klog.FromContext(ctx).Error(nil, "CheckRestartTimeAndInject the componentType is not supported", "dorisClusterName", dcr.Name, "namespace", dcr.Namespace, "componentType", componentType)
 	}
 
 	if restartedAt == "" {
@@ -86,7 +87,7 @@ func (d *SubDefaultController) CheckRestartTimeAndInject(dcr *dorisv1.DorisClust
 	parseTime, err := time.Parse(time.RFC3339, restartedAt)
 	if err != nil {
 		checkErr := fmt.Errorf("CheckRestartTimeAndInject error: time format is incorrect. dorisClusterName: %s, namespace: %s, componentType %s, wrong parse 'restartedAt': %s , error: %s", dcr.Name, dcr.Namespace, componentType, restartedAt, err.Error())
-		klog.Error(checkErr.Error())
+		klog.FromContext(ctx).Error(err, "CheckRestartTimeAndInject time format is incorrect", "dorisClusterName", dcr.Name, "namespace", dcr.Namespace, "componentType", componentType, "restartedAt", restartedAt)
 		d.K8srecorder.Event(dcr, string(EventWarning), string(RestartTimeInvalid), checkErr.Error())
 		return false
 	}
@@ -94,7 +95,7 @@ func (d *SubDefaultController) CheckRestartTimeAndInject(dcr *dorisv1.DorisClust
 	effectiveStartTime := time.Now().Add(-10 * time.Minute)
 
 	if effectiveStartTime.After(parseTime) {
-		klog.Errorf("CheckRestartTimeAndInject The time has expired, dorisClusterName: %s, namespace: %s, componentType %s, wrong parse 'restartedAt': %s : The time has expired, if you want to restart doris, please set a future time", dcr.Name, dcr.Namespace, componentType, restartedAt)
+		klog.FromContext(ctx).Error(nil, "CheckRestartTimeAndInject the restartedAt time has expired, if you want to restart doris, please set a future time", "dorisClusterName", dcr.Name, "namespace", dcr.Namespace, "componentType", componentType, "restartedAt", restartedAt)
 		d.K8srecorder.Event(dcr, string(EventWarning), string(RestartTimeInvalid), fmt.Sprintf("the %s restart time is not effective. the 'restartedAt' %s can't be earlier than 10 minutes before the current time", componentType, restartedAt))
 		return false
 	}
@@ -164,7 +165,7 @@ func (d *SubDefaultController) ClassifyPodsByStatus(namespace string, status *do
 func (d *SubDefaultController) GetConfig(ctx context.Context, configMapInfo *dorisv1.ConfigMapInfo, namespace string, componentType dorisv1.ComponentType) (map[string]interface{}, error) {
 	config, err := k8s.GetConfig(ctx, d.K8sclient, configMapInfo, namespace, componentType)
 	if err != nil {
-		klog.Errorf("SubDefaultController GetConfig get configmap failed, namespace: %s,err: %s \n", namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "SubDefaultController GetConfig get configmap failed", "namespace", namespace)
 	}
 	return config, nil
 }
@@ -183,19 +184,19 @@ func (d *SubDefaultController) GetFinalPersistentVolumes(ctx context.Context, dc
 	case dorisv1.Component_Broker:
 		baseSpec = dcr.Spec.BrokerSpec.BaseSpec
 	default:
-		klog.Infof("GetFinalPersistentVolumes the componentType %s is not supported.", componentType)
+		klog.FromContext(ctx).Info("GetFinalPersistentVolumes the componentType is not supported", "componentType", componentType)
 	}
 
 	config, err := d.GetConfig(ctx, &baseSpec.ConfigMapInfo, dcr.Namespace, componentType)
 	if err != nil {
-		klog.Errorf("GetFinalPersistentVolumes GetConfig failed, namespace: %s,err: %s \n", dcr.Namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "GetFinalPersistentVolumes GetConfig failed", "namespace", dcr.Namespace)
 		return nil, err
 	}
 
 	_, _, sharedPaths := resource.BuildSharedVolumesAndVolumeMounts(dcr.Spec.SharedPersistentVolumeClaims, componentType)
 	dorisPersistentVolumes, err := resource.GenerateEveryoneMountPathDorisPersistentVolume(&baseSpec, sharedPaths, config, componentType)
 	if err != nil {
-		klog.Errorf("GetFinalPersistentVolumes GenerateEveryoneMountPathDorisPersistentVolume failed, namespace: %s,err: %s \n", dcr.Namespace, err.Error())
+		klog.FromContext(ctx).Error(err, "GetFinalPersistentVolumes GenerateEveryoneMountPathDorisPersistentVolume failed", "namespace", dcr.Namespace)
 		return nil, err
 	}
 
@@ -203,7 +204,7 @@ func (d *SubDefaultController) GetFinalPersistentVolumes(ctx context.Context, dc
 }
 
 // generate map for mountpath:configmap
-func (d *SubDefaultController) CheckConfigMountPath(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
+func (d *SubDefaultController) CheckConfigMountPath(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
 	var configMapInfo dorisv1.ConfigMapInfo
 	switch componentType {
 	case dorisv1.Component_FE:
@@ -215,14 +216,14 @@ func (d *SubDefaultController) CheckConfigMountPath(dcr *dorisv1.DorisCluster, c
 	case dorisv1.Component_Broker:
 		configMapInfo = dcr.Spec.BrokerSpec.ConfigMapInfo
 	default:
-		klog.Infof("the componentType %s is not supported.", componentType)
+		klog.FromContext(ctx).Info("the componentType is not supported", "componentType", componentType)
 	}
 	cms := resource.GetMountConfigMapInfo(configMapInfo)
 	var mountsMap = make(map[string]dorisv1.MountConfigMapInfo)
 	for _, cm := range cms {
 		path := cm.MountPath
 		if m, exist := mountsMap[path]; exist {
-			klog.Errorf("CheckConfigMountPath error: the mountPath %s is repeated between configmap: %s and configmap: %s.", path, cm.ConfigMapName, m.ConfigMapName)
+			klog.FromContext(ctx).Error(nil, "CheckConfigMountPath error: the mountPath is repeated between configmaps", "mountPath", path, "configmap", cm.ConfigMapName, "otherConfigmap", m.ConfigMapName)
 			d.K8srecorder.Event(dcr, string(EventWarning), string(ConfigMapPathRepeated), fmt.Sprintf("the mountPath %s is repeated between configmap: %s and configmap: %s.", path, cm.ConfigMapName, m.ConfigMapName))
 		}
 		mountsMap[path] = cm
@@ -230,7 +231,7 @@ func (d *SubDefaultController) CheckConfigMountPath(dcr *dorisv1.DorisCluster, c
 }
 
 // generate map for mountpath:secret
-func (d *SubDefaultController) CheckSecretMountPath(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
+func (d *SubDefaultController) CheckSecretMountPath(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
 	var secrets []dorisv1.Secret
 	switch componentType {
 	case dorisv1.Component_FE:
@@ -242,13 +243,13 @@ func (d *SubDefaultController) CheckSecretMountPath(dcr *dorisv1.DorisCluster, c
 	case dorisv1.Component_Broker:
 		secrets = dcr.Spec.BrokerSpec.Secrets
 	default:
-		klog.Infof("the componentType %s is not supported.", componentType)
+		klog.FromContext(ctx).Info("the componentType is not supported", "componentType", componentType)
 	}
 	var mountsMap = make(map[string]dorisv1.Secret)
 	for _, secret := range secrets {
 		path := secret.MountPath
 		if s, exist := mountsMap[path]; exist {
-			klog.Errorf("CheckSecretMountPath error: the mountPath %s is repeated between secret: %s and secret: %s.", path, secret.SecretName, s.SecretName)
+			klog.FromContext(ctx).Error(nil, "CheckSecretMountPath error: the mountPath is repeated between secrets", "mountPath", path, "secret", secret.SecretName, "otherSecret", s.SecretName)
 			d.K8srecorder.Event(dcr, string(EventWarning), string(SecretPathRepeated), fmt.Sprintf("the mountPath %s is repeated between secret: %s and secret: %s.", path, secret.SecretName, s.SecretName))
 		}
 		mountsMap[path] = secret
@@ -268,7 +269,7 @@ func (d *SubDefaultController) CheckSecretExist(ctx context.Context, dcr *dorisv
 	case dorisv1.Component_Broker:
 		secrets = dcr.Spec.BrokerSpec.Secrets
 	default:
-		klog.Infof("the componentType %s is not supported.", componentType)
+		klog.FromContext(ctx).Info("the componentType is not supported", "componentType", componentType)
 	}
 	errMessage := ""
 	for _, secret := range secrets {
@@ -278,14 +279,139 @@ func (d *SubDefaultController) CheckSecretExist(ctx context.Context, dcr *dorisv
 		}
 	}
 	if errMessage != "" {
-		klog.Errorf("CheckSecretExist error: %s.", errMessage)
+		klog.FromContext(ctx).Error(nil, "CheckSecretExist get secrets failed", "errors", errMessage)
 		d.K8srecorder.Event(dcr, string(EventWarning), string(SecretNotExist), fmt.Sprintf("CheckSecretExist error: %s.", errMessage))
 	}
 }
 
+// GetMasterSqlClient build the sql client that connect to the fe master.
+func (d *SubDefaultController) GetMasterSqlClient(ctx context.Context, dcr *dorisv1.DorisCluster) (*mysql.DB, error) {
+	// get adminuserName and pwd
+	adminUserName, password := d.GetManagementAdminUserAndPWD(ctx, dcr)
+	return d.newMasterSqlClient(ctx, dcr, adminUserName, password)
+}
+
+// GetMaxReplicationNum return the max number of replicas of tables queried from fe master.
+// the connecting and querying are bounded by the deadline of ctx, so the caller is not blocked when fe not reachable.
+func (d *SubDefaultController) GetMaxReplicationNum(ctx context.Context, dcr *dorisv1.DorisCluster) (int32, error) {
+	db, err := d.GetMasterSqlClient(ctx, dcr)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	return db.GetMaxReplicationNum(ctx)
+}
+
+// GetManagementAdminUserAndPWD return the credentials of management user, when password rotation enabled the confirmed credentials in the state of rotation are used.
+func (d *SubDefaultController) GetManagementAdminUserAndPWD(ctx context.Context, dcr *dorisv1.DorisCluster) (string, string) {
+	if dcr.Spec.AuthSecret != "" && dcr.Spec.PasswordRotation != nil {
+		if user, password, ok := getRotatedCredentials(ctx, d.K8sclient, dcr.Namespace, resource.GetPasswordRotationStateSecretName(dcr.Name)); ok {
+			return user, password
+		}
+	}
+
+	secret, _ := k8s.GetSecret(ctx, d.K8sclient, dcr.Namespace, dcr.Spec.AuthSecret)
+	return dorisv1.GetClusterSecret(dcr, secret)
+}
+
+// newMasterSqlClient return the client of fe master bound to ctx, the connections are bounded by the deadline of ctx when it has.
+func (d *SubDefaultController) newMasterSqlClient(ctx context.Context, dcr *dorisv1.DorisCluster, adminUserName, password string) (*mysql.DB, error) {
+	dbConf, tlsConfig, tlsSecret := d.getSqlClientConfig(ctx, dcr, adminUserName, password)
+	var err error
+	if dbConf.Timeout, err = sqlTimeoutOfContext(ctx); err != nil {
+		return nil, err
+	}
+	db, err := mysql.NewDorisMasterSqlDB(dbConf, tlsConfig, tlsSecret)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx), nil
+}
+
+// sqlTimeoutOfContext return the timeout of sql connections by the deadline of ctx, 0 means not limited when ctx has no deadline.
+func sqlTimeoutOfContext(ctx context.Context) (time.Duration, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, nil
+	}
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return 0, context.DeadlineExceeded
+	}
+	return timeout, nil
+}
+
+// getSqlClientConfig return the config of sql client that connects to the fe service, and the tls config with the secret of client certificate when tls enabled.
+func (d *SubDefaultController) getSqlClientConfig(ctx context.Context, dcr *dorisv1.DorisCluster, adminUserName, password string) (mysql.DBConfig, *mysql.TLSConfig, *corev1.Secret) {
+	// When the operator and dcr are deployed in different namespace, it will be inaccessible, so need to add the dcr svc namespace
+	host := dorisv1.GenerateExternalServiceName(dcr, dorisv1.Component_FE) + "." + dcr.Namespace
+	maps, _ := k8s.GetConfig(ctx, d.K8sclient, &dcr.Spec.FeSpec.ConfigMapInfo, dcr.Namespace, dorisv1.Component_FE)
+	queryPort := resource.GetPort(maps, resource.QUERY_PORT)
+
+	dbConf := mysql.DBConfig{
+		User:     adminUserName,
+		Password: password,
+		Host:     host,
+		Port:     strconv.FormatInt(int64(queryPort), 10),
+		Database: "mysql",
+	}
+
+	// check if TLS is enabled in FE config and find the corresponding secret
+	tlsConfig, secretName := d.FindSecretTLSConfig(maps, dcr)
+	var tlsSecret *corev1.Secret
+	if tlsConfig != nil && secretName != "" {
+		tlsSecret, _ = k8s.GetSecret(ctx, d.K8sclient, dcr.Namespace, secretName)
+	}
+
+	return dbConf, tlsConfig, tlsSecret
+}
+
+// RotateManagementPassword rotate the password of management user when fe is available, the state of rotation is cleared when rotation disabled.
+func (d *SubDefaultController) RotateManagementPassword(ctx context.Context, dcr *dorisv1.DorisCluster) {
+	stateSecretName := resource.GetPasswordRotationStateSecretName(dcr.Name)
+	if dcr.Spec.AuthSecret == "" || dcr.Spec.PasswordRotation == nil {
+		clearPasswordRotationState(ctx, d.K8sclient, dcr.Namespace, stateSecretName)
+		return
+	}
+	if dcr.Status.FEStatus == nil || dcr.Status.FEStatus.ComponentCondition.Phase != dorisv1.Available {
+		return
+	}
+
+	pr := &passwordRotator{
+		k8sclient:       d.K8sclient,
+		namespace:       dcr.Namespace,
+		authSecretName:  dcr.Spec.AuthSecret,
+		stateSecretName: stateSecretName,
+		ownerRef:        resource.GetOwnerReference(dcr),
+		labels:          map[string]string{dorisv1.DorisClusterLabelKey: dcr.Name},
+		connect: func(user, password string) (*mysql.DB, error) {
+			return d.newMasterSqlClient(ctx, dcr, user, password)
+		},
+	}
+	if dcr.Spec.PasswordRotation.Interval != nil {
+		pr.interval = dcr.Spec.PasswordRotation.Interval.Duration
+	}
+
+	rotated, err := pr.rotate(ctx)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController RotateManagementPassword failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(PasswordRotationFailed), "rotate the password of management user failed, "+err.Error())
+		return
+	}
+	if rotated {
+		klog.FromContext(ctx).Info("SubDefaultController RotateManagementPassword rotated the password of management user", "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventNormal), string(PasswordRotated), "the password of management user rotated.")
+	}
+}
+
 // FindSecretTLSConfig reads TLS configuration from FE config map and returns
 // the TLS config and secret name for establishing TLS-enabled MySQL connections.
 func (d *SubDefaultController) FindSecretTLSConfig(feConfMap map[string]interface{}, dcr *dorisv1.DorisCluster) (*mysql.TLSConfig, string) {
+	// use the client certificate issued by operator.
+	if dcr.IsTLSEnabled() {
+		return getOperatorTLSConfig(), resource.GetTLSClientSecretName(dcr.Name)
+	}
+
 	enableTLS := resource.GetString(feConfMap, resource.ENABLE_TLS_KEY)
 	if enableTLS == "" {
 		return nil, ""
@@ -329,13 +455,13 @@ func (d *SubDefaultController) CheckSharedPVC(ctx context.Context, dcr *dorisv1.
 		pvc, err := k8s.GetPVC(ctx, d.K8sclient, claim.PersistentVolumeClaimName, dcr.Namespace)
 		if err != nil || pvc == nil {
 			errMessage := fmt.Sprintf("(PersistentVolumeClaim get failed name: %s, namespace: %s, err: %#v), ", claim.PersistentVolumeClaimName, dcr.Namespace, err)
-			klog.Errorf(errMessage)
+			klog.FromContext(ctx).Error(err, "CheckSharedPVC get PersistentVolumeClaim failed", "pvc", claim.PersistentVolumeClaimName, "namespace", dcr.Namespace)
 			d.K8srecorder.Event(dcr, string(EventWarning), string(CheckSharePVC), errMessage)
 			return
 		}
 		if !set.ArrayContains(pvc.Spec.AccessModes, corev1.ReadWriteMany) {
 			errMessage := fmt.Sprintf("(PersistentVolumeClaim name: %s, namespace: %s AccessMode cannot be shared: %+v), ", claim.PersistentVolumeClaimName, dcr.Namespace, pvc.Spec.AccessModes)
-			klog.Errorf(errMessage)
+			klog.FromContext(ctx).Error(nil, "CheckSharedPVC the AccessMode of PersistentVolumeClaim cannot be shared", "pvc", claim.PersistentVolumeClaimName, "namespace", dcr.Namespace, "accessModes", pvc.Spec.AccessModes)
 			d.K8srecorder.Event(dcr, string(EventWarning), string(CheckSharePVC), errMessage)
 			return
 		}
@@ -350,23 +476,23 @@ func (d *SubDefaultController) ClearCommonResources(ctx context.Context, dcr *do
 	externalServiceName := dorisv1.GenerateExternalServiceName(dcr, componentType)
 	internalServiceName := dorisv1.GenerateInternalCommunicateServiceName(dcr, componentType)
 	if err := k8s.DeleteStatefulset(ctx, d.K8sclient, dcr.Namespace, stName); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("SubDefaultController ClearResources delete statefulset failed, namespace=%s,name=%s, error=%s.", dcr.Namespace, stName, err.Error())
+		klog.FromContext(ctx).Error(err, "SubDefaultController ClearResources delete statefulset failed", "namespace", dcr.Namespace, "name", stName)
 		return false, err
 	}
 
 	if err := k8s.DeleteService(ctx, d.K8sclient, dcr.Namespace, internalServiceName); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("SubDefaultController ClearResources delete search service, namespace=%s,name=%s,error=%s.", dcr.Namespace, internalServiceName, err.Error())
+		klog.FromContext(ctx).Error(err, "SubDefaultController ClearResources delete search service failed", "namespace", dcr.Namespace, "name", internalServiceName)
 		return false, err
 	}
 	if err := k8s.DeleteService(ctx, d.K8sclient, dcr.Namespace, externalServiceName); err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("SubDefaultController ClearResources delete external service, namespace=%s, name=%s,error=%s.", dcr.Namespace, externalServiceName, err.Error())
+		klog.FromContext(ctx).Error(err, "SubDefaultController ClearResources delete external service failed", "namespace", dcr.Namespace, "name", externalServiceName)
 		return false, err
 	}
 
 	return true, nil
 }
 
-func (d *SubDefaultController) FeAvailable(dcr *dorisv1.DorisCluster) bool {
+func (d *SubDefaultController) FeAvailable(ctx context.Context, dcr *dorisv1.DorisCluster) bool {
 	addr, _ := dorisv1.GetConfigFEAddrForAccess(dcr, dorisv1.Component_BE)
 	if addr != "" {
 		return true
@@ -376,7 +502,7 @@ func (d *SubDefaultController) FeAvailable(dcr *dorisv1.DorisCluster) bool {
 	//1. wait for fe ok.
 	endpoints := corev1.Endpoints{}
 	if err := d.K8sclient.Get(context.Background(), types.NamespacedName{Namespace: dcr.Namespace, Name: dorisv1.GenerateExternalServiceName(dcr, dorisv1.Component_FE)}, &endpoints); err != nil {
-		klog.Infof("SubDefaultController Sync wait fe service name %s available occur failed %s\n", dorisv1.GenerateExternalServiceName(dcr, dorisv1.Component_FE), err.Error())
+		klog.FromContext(ctx).Info("SubDefaultController Sync wait fe service available occur failed", "err", err, "name", dorisv1.GenerateExternalServiceName(dcr, dorisv1.Component_FE))
 		return false
 	}
 
@@ -413,7 +539,7 @@ func (d *SubDefaultController) PrepareReconcileResources(ctx context.Context, dc
 	case dorisv1.Component_CN:
 		return d.prepareCNReconcileResources(ctx, dcr)
 	default:
-		klog.Infof("prepareReconcileResource not support type= %s", componentType)
+		klog.FromContext(ctx).Info("prepareReconcileResource not support", "type", componentType)
 		return true
 	}
 }
@@ -496,6 +622,12 @@ func (d *SubDefaultController) preparePersistentVolumeClaim(ctx context.Context,
 		pvcMap[key] = append(pvcMap[key], pvc)
 	}
 
+	// the disk usage of backends is only used for auto expansion of be pvcs.
+	var backends map[string]*mysql.Backend
+	if componentType == dorisv1.Component_BE && autoExpansionConfigured(dorisPersistentVolumes) {
+		backends = d.getBackendsOfPods(ctx, dcr)
+	}
+
 	//presents the pvc have all created or updated to new version.
 	prepared := true
 	for _, dorisPersistentVolume := range dorisPersistentVolumes {
@@ -504,7 +636,7 @@ func (d *SubDefaultController) preparePersistentVolumeClaim(ctx context.Context,
 			continue
 		}
 
-		if !d.patchPVCs(ctx, dcr, selector, pvcMap["-^"+dorisPersistentVolume.Name], stsName, dorisPersistentVolume, replicas) {
+		if !d.patchPVCs(ctx, dcr, selector, pvcMap["-^"+dorisPersistentVolume.Name], stsName, dorisPersistentVolume, replicas, backends) {
 			prepared = false
 		}
 	}
@@ -513,21 +645,24 @@ func (d *SubDefaultController) preparePersistentVolumeClaim(ctx context.Context,
 }
 
 func (d *SubDefaultController) patchPVCs(ctx context.Context, dcr *dorisv1.DorisCluster, selector map[string]string,
-	pvcs []corev1.PersistentVolumeClaim, stsName string, volume dorisv1.PersistentVolume, replicas int32) bool {
+	pvcs []corev1.PersistentVolumeClaim, stsName string, volume dorisv1.PersistentVolume, replicas int32, backends map[string]*mysql.Backend) bool {
 	//patch already exist in k8s .
 	prepared := true
 	for _, pvc := range pvcs {
 		oldCapacity := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
 		newCapacity := volume.PersistentVolumeClaimSpec.Resources.Requests[corev1.ResourceStorage]
+		if volume.AutoExpansion != nil {
+			newCapacity = d.autoExpandedCapacity(ctx, dcr, &pvc, volume, backends)
+		}
 		if !oldCapacity.Equal(newCapacity) {
 			// if pvc need update, the resource have not prepared, return false.
 			prepared = false
 			eventType := EventNormal
 			reason := PVCUpdate
-			message := pvc.Name + " update successfully!"
+			message := fmt.Sprintf("pvc %s resized from %s to %s.", pvc.Name, oldCapacity.String(), newCapacity.String())
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = newCapacity
 			if err := d.K8sclient.Patch(ctx, &pvc, client.Merge); err != nil {
-				klog.Errorf("SubDefaultController namespace %s name %s patch pvc %s failed, %s", dcr.Namespace, dcr.Name, pvc.Name, err.Error())
+				klog.FromContext(ctx).Error(err, "SubDefaultController patch pvc failed", "namespace", dcr.Namespace, "name", dcr.Name, "pvc", pvc.Name)
 				eventType = EventWarning
 				reason = PVCUpdateFailed
 				message = pvc.Name + " update failed, " + err.Error()
@@ -548,30 +683,179 @@ func (d *SubDefaultController) patchPVCs(ctx context.Context, dcr *dorisv1.Doris
 		pvc := resource.BuildPVC(volume, selector, dcr.Namespace, stsName, strconv.Itoa(baseOrdinal))
 		if err := d.K8sclient.Create(ctx, &pvc); err != nil && !apierrors.IsAlreadyExists(err) {
 			d.K8srecorder.Event(dcr, string(EventWarning), PVCCreateFailed, err.Error())
-			klog.Errorf("SubDefaultController namespace %s name %s create pvc %s failed, %s.", dcr.Namespace, dcr.Name, pvc.Name, err.Error())
+			klog.FromContext(ctx).Error(err, "SubDefaultController create pvc failed", "namespace", dcr.Namespace, "name", dcr.Name, "pvc", pvc.Name)
 		}
 	}
 
 	return prepared
 }
 
+func autoExpansionConfigured(volumes []dorisv1.PersistentVolume) bool {
+	for _, volume := range volumes {
+		if volume.PVCProvisioner == dorisv1.PVCProvisionerOperator && volume.AutoExpansion != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// autoExpandedCapacity returns the capacity that the pvc should be resized to when auto expansion is configured.
+// the capacity expanded before is kept, and grows one step when the max disk used percent of the backend crosses the threshold.
+func (d *SubDefaultController) autoExpandedCapacity(ctx context.Context, dcr *dorisv1.DorisCluster, pvc *corev1.PersistentVolumeClaim,
+	volume dorisv1.PersistentVolume, backends map[string]*mysql.Backend) kr.Quantity {
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := volume.PersistentVolumeClaimSpec.Resources.Requests[corev1.ResourceStorage]
+	// the pvc can't be shrunk, so the capacity expanded automatically should not be reverted to the spec.
+	if current.Cmp(capacity) > 0 {
+		capacity = current
+	}
+
+	podName := strings.TrimPrefix(pvc.Name, volume.Name+"-")
+	backend, ok := backends[podName]
+	if !ok {
+		return capacity
+	}
+	usedPercent, err := resource.ParseDorisUsedPercent(backend.MaxDiskUsedPct)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController autoExpandedCapacity parse MaxDiskUsedPct of backend failed", "maxDiskUsedPct", backend.MaxDiskUsedPct, "backend", backend.Host)
+		return capacity
+	}
+
+	ae := volume.AutoExpansion
+	expanded, expand := resource.CalculateAutoExpandedCapacity(capacity, usedPercent, ae.UsedPercentThreshold, ae.Step, ae.MaxCapacity)
+	if !expand {
+		return capacity
+	}
+	// the last expansion not finished, the disk usage is not refreshed.
+	statusCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !statusCapacity.IsZero() && statusCapacity.Cmp(current) < 0 {
+		return capacity
+	}
+
+	allow, err := k8s.StorageClassAllowVolumeExpansion(ctx, d.K8sclient, pvc)
+	if err != nil || !allow {
+		message := fmt.Sprintf("pvc %s disk used %.2f%%, available %s, but the storageclass not allow volume expansion.", pvc.Name, usedPercent, backend.AvailCapacity)
+		if err != nil {
+			message = fmt.Sprintf("pvc %s disk used %.2f%%, available %s, but get storageclass failed, %s", pvc.Name, usedPercent, backend.AvailCapacity, err.Error())
+		}
+		klog.FromContext(ctx).Error(nil, "SubDefaultController auto expand failed", "namespace", dcr.Namespace, "name", dcr.Name, "message", message)
+		d.K8srecorder.Event(dcr, string(EventWarning), PVCAutoExpandFailed, message)
+		return capacity
+	}
+
+	klog.FromContext(ctx).Info("SubDefaultController disk used percent reached the threshold, auto expand pvc", "namespace", dcr.Namespace, "name", dcr.Name, "pvc", pvc.Name, "usedPercent", usedPercent, "availCapacity", backend.AvailCapacity, "from", current.String(), "to", expanded.String())
+	d.K8srecorder.Event(dcr, string(EventNormal), PVCAutoExpand, fmt.Sprintf("pvc %s disk used %.2f%%, available %s, expand from %s to %s.", pvc.Name, usedPercent, backend.AvailCapacity, current.String(), expanded.String()))
+	return expanded
+}
+
+// getBackendsOfPods returns the backends displayed by `show backends`, the key is the pod name of backend.
+// the MaxDiskUsedPct and AvailCapacity of backend are used by auto expansion.
+func (d *SubDefaultController) getBackendsOfPods(ctx context.Context, dcr *dorisv1.DorisCluster) map[string]*mysql.Backend {
+	masterDBClient, err := d.GetMasterSqlClient(ctx, dcr)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController getBackendsOfPods GetMasterSqlClient failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return nil
+	}
+	defer masterDBClient.Close()
+
+	backends, err := masterDBClient.ShowBackends()
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController getBackendsOfPods show backends failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return nil
+	}
+	pods, err := k8s.GetPods(ctx, d.K8sclient, dcr.Namespace, dorisv1.GetPodLabels(dcr, dorisv1.Component_BE))
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController getBackendsOfPods list pods failed", "namespace", dcr.Namespace, "name", dcr.Name)
+		return nil
+	}
+	podMap := make(map[string]string) // key is pod ip, value is pod name
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" {
+			podMap[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	podBackends := make(map[string]*mysql.Backend)
+	for _, backend := range backends {
+		podName, ok := podMap[backend.Host]
+		if !ok {
+			// use fqdn, like: doriscluster-sample-be-0.doriscluster-sample-be-internal.doris.svc.cluster.local
+			podName = strings.Split(backend.Host, ".")[0]
+		}
+		podBackends[podName] = backend
+	}
+	return podBackends
+}
+
 // RecycleResources pvc resource for recycle
 func (d *SubDefaultController) RecycleResources(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) error {
+	var baseSpec *dorisv1.BaseSpec
 	switch componentType {
 	case dorisv1.Component_FE:
-		return d.recycleFEResources(ctx, dcr)
+		if dcr.Spec.FeSpec != nil {
+			baseSpec = &dcr.Spec.FeSpec.BaseSpec
+		}
+	case dorisv1.Component_BE:
+		if dcr.Spec.BeSpec != nil {
+			baseSpec = &dcr.Spec.BeSpec.BaseSpec
+		}
+	case dorisv1.Component_CN:
+		if dcr.Spec.CnSpec != nil {
+			baseSpec = &dcr.Spec.CnSpec.BaseSpec
+		}
 	default:
-		klog.Infof("RecycleResources not support type=%s", componentType)
+		klog.FromContext(ctx).Info("RecycleResources not support", "type", componentType)
+		return nil
+	}
+
+	if baseSpec == nil || len(baseSpec.PersistentVolumes) == 0 {
 		return nil
 	}
+	return d.recyclePersistentVolumeClaims(ctx, dcr, componentType, baseSpec.PersistentVolumeClaimRetentionPolicy)
 }
 
-// recycleFEResources pvc resource for fe recycle
-func (d *SubDefaultController) recycleFEResources(ctx context.Context, dcr *dorisv1.DorisCluster) error {
-	if len(dcr.Spec.FeSpec.PersistentVolumes) != 0 {
-		return d.listAndDeletePersistentVolumeClaim(ctx, dcr, dorisv1.Component_FE)
+// recyclePersistentVolumeClaims apply the pvc retention policy of component.
+// when `whenDeleted` is `Delete` the pvcs are owned by DorisCluster for deleting with it, when `whenScaled` is `Delete` the pvcs of pods removed by scaling down are deleted.
+func (d *SubDefaultController) recyclePersistentVolumeClaims(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType, policy *dorisv1.PersistentVolumeClaimRetentionPolicy) error {
+	if err := d.applyPVCRetentionPolicy(ctx, dcr, componentType, policy); err != nil {
+		return err
 	}
-	return nil
+
+	if !resource.DeletePVCWhenScaled(policy, componentType) {
+		return nil
+	}
+	return d.listAndDeletePersistentVolumeClaim(ctx, dcr, componentType)
+}
+
+// applyPVCRetentionPolicy keeps the owner reference and finalizer of pvcs consistent with `whenDeleted` policy.
+// the pvcs retained by the deleted DorisCluster that have the same name are re-attached.
+func (d *SubDefaultController) applyPVCRetentionPolicy(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType, policy *dorisv1.PersistentVolumeClaimRetentionPolicy) error {
+	pvcList := corev1.PersistentVolumeClaimList{}
+	selector := dorisv1.GenerateStatefulSetSelector(dcr, componentType)
+	if err := d.K8sclient.List(ctx, &pvcList, client.InNamespace(dcr.Namespace), client.MatchingLabels(selector)); err != nil {
+		d.K8srecorder.Event(dcr, string(EventWarning), PVCListFailed, string("list component "+componentType+" failed!"))
+		return err
+	}
+
+	owner := resource.GetOwnerReference(dcr)
+	deleteWithCluster := resource.DeletePVCWhenDeleted(policy)
+	var mergeError error
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		changed, reattached := resource.ApplyPVCRetentionPolicy(pvc, owner, deleteWithCluster)
+		if !changed {
+			continue
+		}
+		if err := d.K8sclient.Update(ctx, pvc); err != nil {
+			klog.FromContext(ctx).Error(err, "SubDefaultController apply retention policy to pvc failed", "namespace", dcr.Namespace, "name", dcr.Name, "pvc", pvc.Name)
+			mergeError = utils.MergeError(mergeError, err)
+			continue
+		}
+		if reattached {
+			d.K8srecorder.Event(dcr, string(EventNormal), PVCReattached, fmt.Sprintf("pvc %s retained by the deleted cluster is re-attached.", pvc.Name))
+		}
+	}
+	return mergeError
 }
 
 // listAndDeletePersistentVolumeClaim:
@@ -604,6 +888,10 @@ func (d *SubDefaultController) listAndDeletePersistentVolumeClaim(ctx context.Co
 		d.K8srecorder.Event(dcr, string(EventWarning), PVCListFailed, string("list component "+componentType+" failed!"))
 		return err
 	}
+	// the statefulset not scaled down when be decommissioning, the pvcs used by the pods of statefulset should not be deleted.
+	if est, err := k8s.GetStatefulSet(ctx, d.K8sclient, dcr.Namespace, stsName); err == nil && est.Spec.Replicas != nil && *est.Spec.Replicas > replicas {
+		replicas = *est.Spec.Replicas
+	}
 	//classify pvc by volume.Name, pvc.name generate by volume.Name + statefulset.Name + ordinal
 	pvcMap := make(map[string][]corev1.PersistentVolumeClaim)
 
@@ -647,21 +935,21 @@ func (d *SubDefaultController) deletePVCs(ctx context.Context, dcr *dorisv1.Dori
 		pvcName := resource.BuildPVCName(stsName, strconv.Itoa(maxOrdinal-1), volumeName)
 		if err := k8s.DeletePVC(ctx, d.K8sclient, dcr.Namespace, pvcName, selector); err != nil {
 			d.K8srecorder.Event(dcr, string(EventWarning), PVCDeleteFailed, err.Error())
-			klog.Errorf("SubController namespace %s name %s delete pvc %s failed, %s.", dcr.Namespace, dcr.Name, pvcName, err.Error())
+			klog.FromContext(ctx).Error(err, "SubController delete pvc failed", "namespace", dcr.Namespace, "name", dcr.Name, "pvc", pvcName)
 			mergeError = utils.MergeError(mergeError, err)
 		}
 	}
 	return mergeError
 }
 
-func (d *SubDefaultController) InitStatus(dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
+func (d *SubDefaultController) InitStatus(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) {
 	switch componentType {
 	case dorisv1.Component_FE:
 		d.initFEStatus(dcr)
 	case dorisv1.Component_BE:
 		d.initBEStatus(dcr)
 	default:
-		klog.Infof("InitStatus not support type= %s", componentType)
+		klog.FromContext(ctx).Info("InitStatus not support", "type", componentType)
 	}
 }
 
@@ -730,31 +1018,43 @@ func (d *SubDefaultController) BuildCoreConfigmapStatusHash(ctx context.Context,
 
 // CompareConfigmapAndTriggerRestart
 // 1. Compared by configmap Resolve file to map`s hash
-// 2. Add restart trigger DCR
-func (d *SubDefaultController) CompareConfigmapAndTriggerRestart(dcr *dorisv1.DorisCluster, oldStatus dorisv1.ComponentStatus, componentType dorisv1.ComponentType) {
+// 2. Apply the changed configs at runtime when all of them are mutable, otherwise add restart trigger DCR
+// 3. Snapshot the core configmap as the applied configs for the next comparison, only when the configs applied by hot reload or the restart finished
+func (d *SubDefaultController) CompareConfigmapAndTriggerRestart(ctx context.Context, dcr *dorisv1.DorisCluster, oldStatus dorisv1.ComponentStatus, componentType dorisv1.ComponentType) {
 	oldCmHash := oldStatus.CoreConfigMapHashValue
 	if oldCmHash == "" {
 		// oldCmHash is "" means the following situations:
 		// * First deployment: no restart is required, just skip it.
 		// * Not the first deployment, configmap was not configured: add configmap for doris, then statusfulset schedules automatic rolling restart, and this method does not need to be triggered
 		// * Not the first deployment, configmap is also configured: the operator upgrade operation is done, and 'CoreConfigMapHashValue' was not available before. It also needs to be skipped, no restart is required, CoreConfigMapHashValue will be modified in the subsequent 'UpdateComponentStatus' method.
+		d.SaveAppliedConfig(ctx, dcr, componentType)
 		return
 	}
 
-	newCmHash := d.BuildCoreConfigmapStatusHash(context.Background(), dcr, componentType)
+	newCmHash := d.BuildCoreConfigmapStatusHash(ctx, dcr, componentType)
 	if newCmHash == "" {
 		// dcr has no configmap for doris core config
 		return
 	}
 
 	if oldCmHash == newCmHash {
-		// not change configmap
+		// not change configmap, the snapshot is created when operator upgraded from the version without snapshot,
+		// or updated when the restart for the changed configs finished and the component is available again.
+		if oldStatus.ComponentCondition.Phase == dorisv1.Available {
+			d.SaveAppliedConfig(ctx, dcr, componentType)
+		}
 		return
 	}
 
-	// configmap changed , restart sts
+	// configmap changed, reload the mutable configs or restart sts
 	if oldStatus.ComponentCondition.Phase == dorisv1.Available {
-		klog.Infof("CompareConfigmapAndTriggerRestart TriggerRestart %s for CRD %s , namespace: %s", componentType, dcr.Name, dcr.Namespace)
+		if d.ReloadConfig(ctx, dcr, componentType) {
+			klog.FromContext(ctx).Info("CompareConfigmapAndTriggerRestart reload configs", "componentType", componentType, "name", dcr.Name, "namespace", dcr.Namespace)
+			d.SaveAppliedConfig(ctx, dcr, componentType)
+			return
+		}
+
+		klog.FromContext(ctx).Info("CompareConfigmapAndTriggerRestart trigger restart", "componentType", componentType, "name", dcr.Name, "namespace", dcr.Namespace)
 		if dcr.Annotations == nil {
 			dcr.Annotations = make(map[string]string)
 		}
@@ -0,0 +1,41 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_GetMaxReplicationNumDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	d := &SubDefaultController{K8sclient: fake.NewClientBuilder().Build()}
+	dcr := &dorisv1.DorisCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}, Spec: dorisv1.DorisClusterSpec{FeSpec: &dorisv1.FeSpec{}}}
+
+	// the deadline passed, fe should not be connected.
+	if _, err := d.GetMaxReplicationNum(ctx, dcr); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline exceeded error, got %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"github.com/apache/doris-operator/pkg/common/utils/certificate"
+	"github.com/apache/doris-operator/pkg/common/utils/hash"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/mysql"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the common name of client certificate used by operator.
+const tls_client_common_name = "doris-operator"
+
+// ErrTLSCertificateNotReady means the certificate requested from cert-manager is not issued, the statefulset should not be applied until the secret is ready.
+var ErrTLSCertificateNotReady = errors.New("the certificate requested from cert-manager is not ready")
+
+// tlsIssuer issue the ca of cluster and the certificates signed by the ca, the certificates are stored in secrets owned by the cluster.
+// when the cert-manager issuer is configured, the certificates are requested from cert-manager in place of the ca of cluster.
+type tlsIssuer struct {
+	k8sclient   client.Client
+	namespace   string
+	clusterName string
+	ownerRef    metav1.OwnerReference
+	labels      map[string]string
+	validity    time.Duration
+	renewBefore time.Duration
+	issuer      *resource.CertManagerIssuer
+}
+
+// applyCertificates apply the client certificate used by operator and the certificate of the pods of statefulset, return the hash of the certificate of pods.
+func (ti *tlsIssuer) applyCertificates(ctx context.Context, stsName string, dnsNames []string) (string, error) {
+	clientSecretName := resource.GetTLSClientSecretName(ti.clusterName)
+	serverSecretName := resource.GetTLSServerSecretName(stsName)
+	if ti.issuer != nil {
+		if _, err := ti.requestCertificate(ctx, clientSecretName, tls_client_common_name, nil); err != nil {
+			return "", err
+		}
+		return ti.requestCertificate(ctx, serverSecretName, stsName, dnsNames)
+	}
+
+	ca, err := ti.applyCA(ctx)
+	if err != nil {
+		return "", err
+	}
+	if _, err = ti.applyCertificate(ctx, ca, clientSecretName, tls_client_common_name, nil); err != nil {
+		return "", err
+	}
+	return ti.applyCertificate(ctx, ca, serverSecretName, stsName, dnsNames)
+}
+
+// requestCertificate apply the cert-manager `Certificate` and return the hash of the secret issued by cert-manager.
+// return ErrTLSCertificateNotReady when the secret is not issued.
+func (ti *tlsIssuer) requestCertificate(ctx context.Context, secretName, commonName string, dnsNames []string) (string, error) {
+	cert := resource.BuildCertManagerCertificate(resource.CertManagerCertificateOptions{
+		Name:            secretName,
+		Namespace:       ti.namespace,
+		Labels:          ti.labels,
+		OwnerReferences: []metav1.OwnerReference{ti.ownerRef},
+		CommonName:      commonName,
+		DnsNames:        dnsNames,
+		Duration:        ti.validity,
+		RenewBefore:     ti.renewBefore,
+		Issuer:          *ti.issuer,
+	})
+	if err := k8s.ServerSideApply(ctx, ti.k8sclient, cert); err != nil {
+		return "", err
+	}
+
+	secret, err := k8s.GetSecret(ctx, ti.k8sclient, ti.namespace, secretName)
+	if apierrors.IsNotFound(err) {
+		return "", ErrTLSCertificateNotReady
+	} else if err != nil {
+		return "", err
+	}
+	if len(secret.Data[certificate.TLsCertName]) == 0 || len(secret.Data[certificate.TlsKeyName]) == 0 {
+		return "", ErrTLSCertificateNotReady
+	}
+	return hash.HashObject(secret.Data), nil
+}
+
+// newCertManagerIssuer convert the issuer reference of spec, return nil when not configured.
+func newCertManagerIssuer(name, kind, group string) *resource.CertManagerIssuer {
+	if name == "" {
+		return nil
+	}
+	return &resource.CertManagerIssuer{Name: name, Kind: kind, Group: group}
+}
+
+// applyCA return the ca of cluster, the ca is created when not exist or rotated when it will expire in renewBefore.
+func (ti *tlsIssuer) applyCA(ctx context.Context) (*certificate.CA, error) {
+	name := resource.GetTLSCASecretName(ti.clusterName)
+	secret, err := k8s.GetSecret(ctx, ti.k8sclient, ti.namespace, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if secret != nil {
+		if ca := certificate.BuildCAFromSecret(secret); ca != nil && time.Now().Add(ti.renewBefore).Before(ca.Certificate.NotAfter) {
+			return ca, nil
+		}
+		klog.FromContext(ctx).Info("tlsIssuer the ca of cluster is invalid or will expire, rotate it", "namespace", ti.namespace, "cluster", ti.clusterName)
+	}
+
+	ca, err := certificate.NewRootCA(pkix.Name{CommonName: ti.clusterName + "-ca", Organization: []string{"doris-operator"}}, certificate.DefaultRootCAExpireTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       ti.namespace,
+			Labels:          ti.labels,
+			OwnerReferences: []metav1.OwnerReference{ti.ownerRef},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			certificate.TLsCertName: ca.GetEncodeCert(),
+			certificate.TlsKeyName:  ca.GetEncodePrivateKey(),
+		},
+	}
+	return ca, ti.applySecret(ctx, secret, ns)
+}
+
+// applyCertificate issue the certificate when not exist, not signed by the ca, the dns names changed or the certificate will expire.
+// return the hash of the secret for restarting pods when the certificate rotated.
+func (ti *tlsIssuer) applyCertificate(ctx context.Context, ca *certificate.CA, secretName, commonName string, dnsNames []string) (string, error) {
+	secret, err := k8s.GetSecret(ctx, ti.k8sclient, ti.namespace, secretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	if secret != nil && bytes.Equal(secret.Data[certificate.TlsCACertName], ca.GetEncodeCert()) {
+		cert, perr := certificate.ParsePemCert(secret.Data[certificate.TLsCertName])
+		if perr == nil && !certificate.CertificateNeedRenew(cert, ca.Certificate, dnsNames, ti.renewBefore) {
+			return hash.HashObject(secret.Data), nil
+		}
+	}
+
+	cert, err := ca.IssueCertificate(certificate.CAOptions{
+		Subject:  pkix.Name{CommonName: commonName, Organization: []string{"doris-operator"}},
+		DnsNames: dnsNames,
+	}, ti.validity)
+	if err != nil {
+		return "", err
+	}
+
+	ns := resource.BuildTLSSecret(secretName, ti.namespace, ti.labels, ti.ownerRef, ca, cert)
+	if err := ti.applySecret(ctx, secret, ns); err != nil {
+		return "", err
+	}
+	klog.FromContext(ctx).Info("tlsIssuer issued certificate secret", "namespace", ti.namespace, "name", secretName, "cluster", ti.clusterName)
+	return hash.HashObject(ns.Data), nil
+}
+
+func (ti *tlsIssuer) applySecret(ctx context.Context, existing, secret *corev1.Secret) error {
+	if existing == nil {
+		return k8s.CreateSecret(ctx, ti.k8sclient, secret)
+	}
+	secret.ResourceVersion = existing.ResourceVersion
+	return k8s.UpdateSecret(ctx, ti.k8sclient, secret)
+}
+
+// getOperatorTLSConfig returns the tls config of sql client that use the client certificate issued by operator.
+func getOperatorTLSConfig() *mysql.TLSConfig {
+	return &mysql.TLSConfig{
+		CAFileName:         certificate.TlsCACertName,
+		ClientCertFileName: certificate.TLsCertName,
+		ClientKeyFileName:  certificate.TlsKeyName,
+	}
+}
+
+// newOperatorHttpsTLSConfig build the tls config of http client from the client certificate secret issued by operator,
+// the webservers are verified by the ca of cluster.
+func newOperatorHttpsTLSConfig(secret *corev1.Secret) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[certificate.TlsCACertName]) {
+		return nil, errors.New("append the ca of secret " + secret.Name + " failed")
+	}
+	cert, err := tls.X509KeyPair(secret.Data[certificate.TLsCertName], secret.Data[certificate.TlsKeyName])
+	if err != nil {
+		return nil, errors.New("load the client certificate of secret " + secret.Name + " failed, " + err.Error())
+	}
+	return &tls.Config{RootCAs: pool, Certificates: []tls.Certificate{cert}}, nil
+}
+
+func newDorisClusterTLSIssuer(k8sclient client.Client, dcr *dorisv1.DorisCluster) *tlsIssuer {
+	validity, renewBefore := resource.GetTLSDurations(dcr.Spec.TLS.CertificateValidity, dcr.Spec.TLS.RenewBefore)
+	var issuer *resource.CertManagerIssuer
+	if ref := dcr.Spec.TLS.IssuerRef; ref != nil {
+		issuer = newCertManagerIssuer(ref.Name, ref.Kind, ref.Group)
+	}
+	return &tlsIssuer{
+		k8sclient:   k8sclient,
+		namespace:   dcr.Namespace,
+		clusterName: dcr.Name,
+		ownerRef:    resource.GetOwnerReference(dcr),
+		labels:      map[string]string{dorisv1.DorisClusterLabelKey: dcr.Name},
+		validity:    validity,
+		renewBefore: renewBefore,
+		issuer:      issuer,
+	}
+}
+
+// ApplyTLSCertificates issue the certificate for the pods of component and the client certificate used by operator.
+// return the hash of the certificate of component, the hash is recorded in pod template for restarting pods when the certificate rotated.
+// return ErrTLSCertificateNotReady when the certificates requested from cert-manager are not issued.
+func (d *SubDefaultController) ApplyTLSCertificates(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) (string, error) {
+	ti := newDorisClusterTLSIssuer(d.K8sclient, dcr)
+	stsName := dorisv1.GenerateComponentStatefulSetName(dcr, componentType)
+	dnsNames := resource.BuildServiceDNSNames(dcr.Namespace, dorisv1.GenerateInternalCommunicateServiceName(dcr, componentType), dorisv1.GenerateExternalServiceName(dcr, componentType))
+	certHash, err := ti.applyCertificates(ctx, stsName, dnsNames)
+	if errors.Is(err, ErrTLSCertificateNotReady) {
+		klog.FromContext(ctx).Info("SubDefaultController ApplyTLSCertificates the certificates not issued by cert-manager, wait for it", "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventNormal), string(TLSCertificateWaiting), "wait for cert-manager issuing the certificates of "+string(componentType)+".")
+		return "", err
+	} else if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ApplyTLSCertificates apply certificates failed", "componentType", componentType, "namespace", dcr.Namespace, "name", dcr.Name)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(TLSCertificateIssueFailed), "apply the certificates of "+string(componentType)+" failed, "+err.Error())
+		return "", err
+	}
+	if certificateRotated(ctx, d.K8sclient, dcr.Namespace, stsName, certHash) {
+		d.K8srecorder.Event(dcr, string(EventNormal), string(TLSCertificateRotated), "the certificates of "+string(componentType)+" rotated, pods will restart to load them.")
+	}
+	return certHash, nil
+}
+
+// certificateRotated check the certificate hash recorded in the pod template of the existing statefulset, the certificates rotated when the hash changed.
+func certificateRotated(ctx context.Context, k8sclient client.Client, namespace, stsName, certHash string) bool {
+	st, err := k8s.GetStatefulSet(ctx, k8sclient, namespace, stsName)
+	if err != nil {
+		return false
+	}
+	old := st.Spec.Template.Annotations[resource.TLSCertificateHashAnnotation]
+	return old != "" && old != certHash
+}
+
+// ApplyRenderedConfigMap render the core configmap of component into a configmap owned by the cluster, the rendered configmap is mounted in place of the core configmap.
+// the rendered configmap is deleted when not need rendering.
+func (d *SubDefaultController) ApplyRenderedConfigMap(ctx context.Context, dcr *dorisv1.DorisCluster, componentType dorisv1.ComponentType) error {
+	renderedName := resource.GetRenderedConfigMapName(dcr, componentType)
+	if !resource.NeedRenderConfig(dcr, componentType) {
+		return k8s.DeleteConfigMap(ctx, d.K8sclient, dcr.Namespace, renderedName)
+	}
+
+	coreCmName := resource.GetDorisCoreConfigMapNames(dcr)[componentType]
+	if coreCmName == "" {
+		msg := "the core configmap of " + string(componentType) + " not mounted, the configs can not be rendered by operator."
+		d.K8srecorder.Event(dcr, string(EventWarning), ConfigRenderFailed, msg)
+		// only the storage paths need rendering, the be runs with the config files in image as before.
+		if !dcr.IsTLSEnabled() {
+			return nil
+		}
+		return errors.New(msg)
+	}
+
+	coreCm, err := k8s.GetConfigMap(ctx, d.K8sclient, dcr.Namespace, coreCmName)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ApplyRenderedConfigMap get core configmap failed", "name", coreCmName, "namespace", dcr.Namespace)
+		d.K8srecorder.Event(dcr, string(EventWarning), ConfigRenderFailed, "get configmap "+coreCmName+" failed, "+err.Error())
+		return err
+	}
+
+	cm := resource.BuildRenderedConfigMap(dcr, componentType, coreCm)
+	if err := k8s.ApplyConfigMap(ctx, d.K8sclient, cm); err != nil {
+		klog.FromContext(ctx).Error(err, "SubDefaultController ApplyRenderedConfigMap apply rendered configmap failed", "name", cm.Name, "namespace", cm.Namespace)
+		return err
+	}
+	return nil
+}
+
+func newDisaggregatedClusterTLSIssuer(k8sclient client.Client, ddc *dv1.DorisDisaggregatedCluster) *tlsIssuer {
+	validity, renewBefore := resource.GetTLSDurations(ddc.Spec.TLS.CertificateValidity, ddc.Spec.TLS.RenewBefore)
+	var issuer *resource.CertManagerIssuer
+	if ref := ddc.Spec.TLS.IssuerRef; ref != nil {
+		issuer = newCertManagerIssuer(ref.Name, ref.Kind, ref.Group)
+	}
+	return &tlsIssuer{
+		k8sclient:   k8sclient,
+		namespace:   ddc.Namespace,
+		clusterName: ddc.Name,
+		ownerRef:    resource.GetOwnerReference(ddc),
+		labels:      map[string]string{dv1.DorisDisaggregatedClusterName: ddc.Name},
+		validity:    validity,
+		renewBefore: renewBefore,
+		issuer:      issuer,
+	}
+}
+
+// ApplyTLS issue the certificate for the pods of statefulset and render the configmap that have the config file of resolveKey,
+// then mount the certificate and the rendered configmap in the pod template of statefulset.
+// return ErrTLSCertificateNotReady when the certificates requested from cert-manager are not issued.
+// the headless service and services are used to build the dns names of certificate.
+func (d *DisaggregatedSubDefaultController) ApplyTLS(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster, st *appv1.StatefulSet, cms []dv1.ConfigMap,
+	resolveKey, containerName, headlessService string, services ...string) error {
+	ti := newDisaggregatedClusterTLSIssuer(d.K8sclient, ddc)
+	certHash, err := ti.applyCertificates(ctx, st.Name, resource.BuildServiceDNSNames(ddc.Namespace, headlessService, services...))
+	if errors.Is(err, ErrTLSCertificateNotReady) {
+		klog.FromContext(ctx).Info("DisaggregatedSubDefaultController ApplyTLS the certificates not issued by cert-manager, wait for it", "name", st.Name, "namespace", ddc.Namespace)
+		d.K8srecorder.Event(ddc, string(EventNormal), string(TLSCertificateWaiting), "wait for cert-manager issuing the certificates of "+st.Name+".")
+		return err
+	} else if err != nil {
+		d.K8srecorder.Event(ddc, string(EventWarning), string(TLSCertificateIssueFailed), "apply the certificates of "+st.Name+" failed, "+err.Error())
+		return err
+	}
+	if certificateRotated(ctx, d.K8sclient, ddc.Namespace, st.Name, certHash) {
+		d.K8srecorder.Event(ddc, string(EventNormal), string(TLSCertificateRotated), "the certificates of "+st.Name+" rotated, pods will restart to load them.")
+	}
+
+	renderedNames, err := d.applyTLSRenderedConfigMaps(ctx, ddc, cms, resolveKey)
+	if err != nil {
+		return err
+	}
+
+	pts := &st.Spec.Template
+	for i := range pts.Spec.Volumes {
+		v := &pts.Spec.Volumes[i]
+		if v.ConfigMap != nil && renderedNames[v.ConfigMap.Name] != "" {
+			v.ConfigMap.Name = renderedNames[v.ConfigMap.Name]
+		}
+	}
+	tlsVolume, tlsVolumeMount := resource.GetTLSVolumeAndVolumeMount(resource.GetTLSServerSecretName(st.Name))
+	pts.Spec.Volumes = append(pts.Spec.Volumes, tlsVolume)
+	for i := range pts.Spec.Containers {
+		if pts.Spec.Containers[i].Name == containerName {
+			pts.Spec.Containers[i].VolumeMounts = append(pts.Spec.Containers[i].VolumeMounts, tlsVolumeMount)
+		}
+	}
+	resource.SetTLSCertificateHash(pts, certHash)
+	return nil
+}
+
+// applyTLSRenderedConfigMaps render the tls configs into the configmap that have the config file of resolveKey, return the map of configmap name to rendered name.
+func (d *DisaggregatedSubDefaultController) applyTLSRenderedConfigMaps(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster, cms []dv1.ConfigMap, resolveKey string) (map[string]string, error) {
+	renderedNames := map[string]string{}
+	for _, cm := range cms {
+		kcm, err := k8s.GetConfigMap(ctx, d.K8sclient, ddc.Namespace, cm.Name)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController applyTLSRenderedConfigMaps get configmap failed", "namespace", ddc.Namespace, "name", cm.Name)
+			d.K8srecorder.Event(ddc, string(EventWarning), ConfigRenderFailed, "get configmap "+cm.Name+" failed, "+err.Error())
+			return nil, err
+		}
+		if _, ok := kcm.Data[resolveKey]; !ok {
+			continue
+		}
+
+		rcm := resource.BuildDisaggregatedTLSRenderedConfigMap(ddc, kcm, resolveKey)
+		if err := k8s.ApplyConfigMap(ctx, d.K8sclient, rcm); err != nil {
+			klog.FromContext(ctx).Error(err, "DisaggregatedSubDefaultController applyTLSRenderedConfigMaps apply configmap failed", "namespace", rcm.Namespace, "name", rcm.Name)
+			return nil, err
+		}
+		renderedNames[cm.Name] = rcm.Name
+	}
+
+	if len(renderedNames) == 0 {
+		msg := "the configmap of " + resolveKey + " not configured, the tls configs can not be rendered by operator."
+		d.K8srecorder.Event(ddc, string(EventWarning), ConfigRenderFailed, msg)
+		return nil, errors.New(msg)
+	}
+	return renderedNames, nil
+}
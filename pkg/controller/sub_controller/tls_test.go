@@ -0,0 +1,75 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/doris-operator/pkg/common/utils/certificate"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_tlsIssuer(t *testing.T) {
+	ctx := context.Background()
+	ti := &tlsIssuer{
+		k8sclient:   fake.NewClientBuilder().Build(),
+		namespace:   "default",
+		clusterName: "test",
+		ownerRef:    metav1.OwnerReference{APIVersion: "doris.apache.com/v1", Kind: "DorisCluster", Name: "test", UID: "uid"},
+		validity:    24 * time.Hour,
+		renewBefore: time.Hour,
+	}
+
+	ca, err := ti.applyCA(ctx)
+	if err != nil {
+		t.Fatalf("tlsIssuer applyCA failed, err=%s", err.Error())
+	}
+	// the existing ca should be reused.
+	if eca, _ := ti.applyCA(ctx); eca == nil || !eca.Certificate.Equal(ca.Certificate) {
+		t.Errorf("tlsIssuer applyCA not reuse the existing ca.")
+	}
+
+	dnsNames := resource.BuildServiceDNSNames("default", "test-fe-internal", "test-fe-service")
+	h1, err := ti.applyCertificate(ctx, ca, "test-fe-tls", "test-fe", dnsNames)
+	if err != nil {
+		t.Fatalf("tlsIssuer applyCertificate failed, err=%s", err.Error())
+	}
+	if h2, _ := ti.applyCertificate(ctx, ca, "test-fe-tls", "test-fe", dnsNames); h2 != h1 {
+		t.Errorf("tlsIssuer applyCertificate reissued the valid certificate.")
+	}
+
+	secret, err := k8s.GetSecret(ctx, ti.k8sclient, "default", "test-fe-tls")
+	if err != nil {
+		t.Fatalf("get certificate secret failed, err=%s", err.Error())
+	}
+	for _, key := range []string{certificate.TlsCACertName, certificate.TLsCertName, certificate.TlsKeyName} {
+		if len(secret.Data[key]) == 0 {
+			t.Errorf("the certificate secret have not %s.", key)
+		}
+	}
+
+	// the certificate should be reissued when dns names changed.
+	if h3, _ := ti.applyCertificate(ctx, ca, "test-fe-tls", "test-fe", dnsNames[:2]); h3 == h1 {
+		t.Errorf("tlsIssuer applyCertificate not reissue the certificate when dns names changed.")
+	}
+}
@@ -0,0 +1,118 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	dv1 "github.com/apache/doris-operator/api/disaggregated/v1"
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// VersionSkewWindow is the time that the nodes running different versions are tolerated, e.g. in rolling upgrade.
+// the `VersionSkew` condition is raised when the skew persists beyond it and the cluster not in reconciling.
+const VersionSkewWindow = 30 * time.Minute
+
+// UpdateVersionSkew display the versions of nodes in status and set the `VersionSkew` condition of DorisCluster, an event is recorded when the skew raised.
+func (d *SubDefaultController) UpdateVersionSkew(ctx context.Context, dcr *dorisv1.DorisCluster) {
+	if dcr.Status.Nodes == nil {
+		return
+	}
+
+	var versions []string
+	for _, nodes := range [][]dorisv1.NodeStatus{dcr.Status.Nodes.Frontends, dcr.Status.Nodes.Backends} {
+		for _, n := range nodes {
+			versions = append(versions, n.Version)
+		}
+	}
+	dcr.Status.Versions = distinctVersions(versions)
+	var raised bool
+	dcr.Status.VersionSkewSince, raised = setVersionSkewCondition(&dcr.Status.Conditions, dcr.Generation, dcr.Status.Versions, dcr.Status.VersionSkewSince, classicProgressing(dcr), time.Now())
+	if raised {
+		klog.FromContext(ctx).Info("SubDefaultController UpdateVersionSkew the nodes run different versions", "namespace", dcr.Namespace, "name", dcr.Name, "versions", dcr.Status.Versions)
+		d.K8srecorder.Event(dcr, string(EventWarning), string(VersionSkew), versionSkewMessage(dcr.Status.Versions))
+	}
+}
+
+// UpdateVersionSkew display the versions of nodes in status and set the `VersionSkew` condition of DorisDisaggregatedCluster, an event is recorded when the skew raised.
+func (d *DisaggregatedSubDefaultController) UpdateVersionSkew(ctx context.Context, ddc *dv1.DorisDisaggregatedCluster) {
+	if ddc.Status.Nodes == nil {
+		return
+	}
+
+	var versions []string
+	for _, nodes := range [][]dv1.NodeStatus{ddc.Status.Nodes.Frontends, ddc.Status.Nodes.Backends} {
+		for _, n := range nodes {
+			versions = append(versions, n.Version)
+		}
+	}
+	ddc.Status.Versions = distinctVersions(versions)
+	var raised bool
+	ddc.Status.VersionSkewSince, raised = setVersionSkewCondition(&ddc.Status.Conditions, ddc.Generation, ddc.Status.Versions, ddc.Status.VersionSkewSince, disaggregatedClusterProgressing(&ddc.Status), time.Now())
+	if raised {
+		klog.FromContext(ctx).Info("DisaggregatedSubDefaultController UpdateVersionSkew the nodes run different versions", "namespace", ddc.Namespace, "name", ddc.Name, "versions", ddc.Status.Versions)
+		d.K8srecorder.Event(ddc, string(EventWarning), string(VersionSkew), versionSkewMessage(ddc.Status.Versions))
+	}
+}
+
+// distinctVersions return the sorted distinct versions, the nodes not reporting version are ignored.
+func distinctVersions(versions []string) []string {
+	set := make(map[string]bool)
+	var res []string
+	for _, v := range versions {
+		if v == "" || set[v] {
+			continue
+		}
+		set[v] = true
+		res = append(res, v)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// setVersionSkewCondition set the `VersionSkew` condition by the versions of nodes, return the time the skew first observed and whether the condition newly turned true.
+// the skew is tolerated in VersionSkewWindow or when cluster is progressing.
+func setVersionSkewCondition(conditions *[]metav1.Condition, generation int64, versions []string, since *metav1.Time, progressing bool, now time.Time) (*metav1.Time, bool) {
+	c := metav1.Condition{Type: dorisv1.ConditionVersionSkew, Status: metav1.ConditionFalse, ObservedGeneration: generation, Reason: "VersionConsistent", Message: "all nodes run the same version."}
+	if len(versions) <= 1 {
+		since = nil
+	} else {
+		if since == nil {
+			since = &metav1.Time{Time: now}
+		}
+		c.Reason, c.Message = "VersionSkewTolerated", versionSkewMessage(versions)
+		if !progressing && now.Sub(since.Time) >= VersionSkewWindow {
+			c.Status, c.Reason = metav1.ConditionTrue, "VersionSkew"
+		}
+	}
+
+	raised := c.Status == metav1.ConditionTrue && !meta.IsStatusConditionTrue(*conditions, dorisv1.ConditionVersionSkew)
+	meta.SetStatusCondition(conditions, c)
+	return since, raised
+}
+
+func versionSkewMessage(versions []string) string {
+	return fmt.Sprintf("the nodes run different versions: %s.", strings.Join(versions, ", "))
+}
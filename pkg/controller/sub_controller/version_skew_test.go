@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sub_controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	dorisv1 "github.com/apache/doris-operator/api/doris/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_distinctVersions(t *testing.T) {
+	vs := distinctVersions([]string{"doris-2.1.8", "", "doris-2.1.7", "doris-2.1.8"})
+	if !reflect.DeepEqual(vs, []string{"doris-2.1.7", "doris-2.1.8"}) {
+		t.Errorf("the distinct versions not match, %v", vs)
+	}
+}
+
+func Test_setVersionSkewCondition(t *testing.T) {
+	var conditions []metav1.Condition
+	now := time.Now()
+	skew := []string{"doris-2.1.7", "doris-2.1.8"}
+
+	since, raised := setVersionSkewCondition(&conditions, 1, skew, nil, false, now)
+	c := meta.FindStatusCondition(conditions, dorisv1.ConditionVersionSkew)
+	if since == nil || raised || c.Status != metav1.ConditionFalse || c.Reason != "VersionSkewTolerated" {
+		t.Errorf("the skew first observed should be tolerated, since=%v raised=%v condition=%+v", since, raised, c)
+	}
+
+	if _, raised = setVersionSkewCondition(&conditions, 1, skew, since, true, now.Add(VersionSkewWindow)); raised {
+		t.Errorf("the skew should be tolerated when cluster is progressing.")
+	}
+
+	since, raised = setVersionSkewCondition(&conditions, 1, skew, since, false, now.Add(VersionSkewWindow))
+	if !raised || !meta.IsStatusConditionTrue(conditions, dorisv1.ConditionVersionSkew) {
+		t.Errorf("the skew persists beyond window should raise the condition.")
+	}
+	if _, raised = setVersionSkewCondition(&conditions, 1, skew, since, false, now.Add(2*VersionSkewWindow)); raised {
+		t.Errorf("the skew already raised should not be raised again.")
+	}
+
+	since, _ = setVersionSkewCondition(&conditions, 1, []string{"doris-2.1.8"}, since, false, now.Add(2*VersionSkewWindow))
+	if since != nil || meta.IsStatusConditionTrue(conditions, dorisv1.ConditionVersionSkew) {
+		t.Errorf("the skew should be cleared when all nodes run the same version.")
+	}
+}
+
+func Test_UpdateVersionSkew(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	d := &SubDefaultController{K8srecorder: recorder}
+	dcr := newHealthTestCluster()
+	dcr.Status.VersionSkewSince = &metav1.Time{Time: time.Now().Add(-VersionSkewWindow)}
+	dcr.Status.Nodes = &dorisv1.NodesStatus{
+		Frontends: []dorisv1.NodeStatus{{Version: "doris-2.1.8"}},
+		Backends:  []dorisv1.NodeStatus{{Version: "doris-2.1.7"}, {Version: "doris-2.1.8"}},
+	}
+
+	d.UpdateVersionSkew(context.Background(), dcr)
+	if !reflect.DeepEqual(dcr.Status.Versions, []string{"doris-2.1.7", "doris-2.1.8"}) {
+		t.Errorf("the versions in status not match, %v", dcr.Status.Versions)
+	}
+	select {
+	case e := <-recorder.Events:
+		if e != "Warning VersionSkew the nodes run different versions: doris-2.1.7, doris-2.1.8." {
+			t.Errorf("the event of version skew not match, %s", e)
+		}
+	default:
+		t.Errorf("the event of version skew not recorded.")
+	}
+}
@@ -19,10 +19,14 @@ package unnamedwatches
 
 import (
 	"context"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
 	pc "github.com/apache/doris-operator/pkg/controller"
 	v1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"os"
@@ -54,9 +58,19 @@ var (
 	WatchSecretWebhookName                    = "doris-operator-webhook-secret-watch"
 	WatchValidatingWebhookConfigurationName   = "doris-operator-validate-webhook-watch"
 	WatchMutatingWebhookConfigurationName     = "doris-operator-mutate-webhook-watch"
+	WatchCustomResourceDefinitionName         = "doris-operator-crd-watch"
 	DefaultMutatingWebhookConfigurationName   = "doris-operator-mutate-webhook"
 	DefaultValidatingWebhookConfigurationName = "doris-operator-validate-webhook"
 )
+
+// the crds served by v1 and v2, the objects are converted by the conversion webhook of operator.
+// the crd of doris cluster may be installed with the name of apache group, the crd not exist is skipped.
+var ConversionCustomResourceDefinitionNames = []string{
+	"dorisclusters.doris.selectdb.com",
+	"dorisclusters.doris.apache.com",
+	"dorisdisaggregatedclusters.disaggregated.cluster.doris.com",
+}
+
 var (
 	OperatorPodSelector       = "control-plane=doris-operator"
 	OperatorPodTimeAnnotation = "doris-operator/update-time"
@@ -100,10 +114,21 @@ func (wr *WResource) Init(mgr ctrl.Manager, options *pc.Options) {
 		klog.Errorf("wresource init build clientset from mgr failed, err=%s", err.Error())
 		os.Exit(1)
 	}
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		klog.Errorf("wresource init build dynamic client from mgr failed, err=%s", err.Error())
+		os.Exit(1)
+	}
+	crdClient, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		klog.Errorf("wresource init build apiextensions clientset from mgr failed, err=%s", err.Error())
+		os.Exit(1)
+	}
 
 	ws := &WatchSecret{
-		client: clientset,
-		Name:   WatchSecretWebhookName,
+		client:        clientset,
+		dynamicClient: dynamicClient,
+		Name:          WatchSecretWebhookName,
 		NamespaceName: types.NamespacedName{
 			Name:      options.SecretName,
 			Namespace: options.Namespace,
@@ -113,6 +138,9 @@ func (wr *WResource) Init(mgr ctrl.Manager, options *pc.Options) {
 		MutatingWebhookConfigurationName:   DefaultMutatingWebhookConfigurationName,
 		ValidatingWebhookConfigurationName: DefaultValidatingWebhookConfigurationName,
 	}
+	if options.WebhookCertManagerIssuer != "" {
+		ws.Issuer = &resource.CertManagerIssuer{Name: options.WebhookCertManagerIssuer, Kind: options.WebhookCertManagerIssuerKind}
+	}
 
 	wv := &WatchValidatingWebhookConfiguration{
 		client: clientset,
@@ -136,7 +164,20 @@ func (wr *WResource) Init(mgr ctrl.Manager, options *pc.Options) {
 		Type:                             &v1.MutatingWebhookConfiguration{},
 	}
 
-	wr.watches = append(wr.watches, ws, wv, wm)
+	wc := &WatchCustomResourceDefinition{
+		client:                        clientset,
+		crdClient:                     crdClient,
+		Name:                          WatchCustomResourceDefinitionName,
+		CustomResourceDefinitionNames: ConversionCustomResourceDefinitionNames,
+		SecretNamespaceName: types.NamespacedName{
+			Name:      options.SecretName,
+			Namespace: options.Namespace,
+		},
+		WebhookService: options.WebhookService,
+		Type:           &apiextensionsv1.CustomResourceDefinition{},
+	}
+
+	wr.watches = append(wr.watches, ws, wv, wm, wc)
 
 	//force a first reconciliation to create the resources
 	if err := wr.ReconcileResource(context.Background()); err != nil {
@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package unnamedwatches
+
+import (
+	"context"
+	"github.com/apache/doris-operator/pkg/common/utils/certificate"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ watch = &WatchCustomResourceDefinition{}
+
+const (
+	// the path that controller-runtime registers the conversion webhook on.
+	conversionWebhookPath = "/convert"
+	webhookServicePort    = int32(443)
+)
+
+// WatchCustomResourceDefinition configures the conversion webhook of crds that have v1 and v2, the v1 is the storage version.
+// the crds are shipped with v2 not served and without conversion, v2 is served only when the conversion webhook is configured by operator.
+type WatchCustomResourceDefinition struct {
+	client    kubernetes.Interface
+	crdClient apiextensionsclientset.Interface
+	//the watch controller name.
+	Name string
+	//the names of crds that convert by operator.
+	CustomResourceDefinitionNames []string
+	SecretNamespaceName           types.NamespacedName
+	WebhookService                string
+	Type                          client.Object
+}
+
+// return the watch resource name.
+func (w *WatchCustomResourceDefinition) GetName() string {
+	return w.Name
+}
+func (w *WatchCustomResourceDefinition) GetType() client.Object {
+	return w.Type
+}
+
+func (w *WatchCustomResourceDefinition) Create(ctx context.Context, e event.TypedCreateEvent[client.Object], limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for _, req := range w.toReconcileRequest(e.Object) {
+		limitingInterface.Add(req)
+	}
+}
+
+func (w *WatchCustomResourceDefinition) Update(ctx context.Context, e event.TypedUpdateEvent[client.Object], limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for _, req := range w.toReconcileRequest(e.ObjectNew) {
+		limitingInterface.Add(req)
+	}
+}
+
+func (w *WatchCustomResourceDefinition) Delete(ctx context.Context, e event.TypedDeleteEvent[client.Object], limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (w *WatchCustomResourceDefinition) Generic(ctx context.Context, e event.TypedGenericEvent[client.Object], limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for _, req := range w.toReconcileRequest(e.Object) {
+		limitingInterface.Add(req)
+	}
+}
+
+func (w *WatchCustomResourceDefinition) toReconcileRequest(e metav1.Object) []reconcile.Request {
+	for _, name := range w.CustomResourceDefinitionNames {
+		if e.GetName() == name {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: e.GetName()}}}
+		}
+	}
+
+	return nil
+}
+
+func (w *WatchCustomResourceDefinition) Reconcile(ctx context.Context) error {
+	secret, err := w.client.CoreV1().Secrets(w.SecretNamespaceName.Namespace).Get(ctx, w.SecretNamespaceName.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("watchCustomResourceDefinition reconcile failed to get secret, error=%s", err.Error())
+		return err
+	}
+
+	cert := certificate.GetCABundle(secret)
+	for _, name := range w.CustomResourceDefinitionNames {
+		crd, err := w.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			//the crd of disaggregated cluster is not required.
+			klog.Infof("watchCustomResourceDefinition crd name=%s not exist, skip configuring the conversion webhook.", name)
+			continue
+		}
+		if err != nil {
+			klog.Errorf("watchCustomResourceDefinition get crd name=%s failed, err=%s.", name, err.Error())
+			return err
+		}
+
+		conversion := w.newConversion(cert)
+		served := serveAllVersions(crd)
+		if !served && equality.Semantic.DeepEqual(crd.Spec.Conversion, conversion) {
+			continue
+		}
+		crd.Spec.Conversion = conversion
+		if _, err := w.crdClient.ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("watchCustomResourceDefinition update conversion of crd name=%s failed, err=%s.", name, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *WatchCustomResourceDefinition) newConversion(cert []byte) *apiextensionsv1.CustomResourceConversion {
+	path := conversionWebhookPath
+	port := webhookServicePort
+	return &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: w.SecretNamespaceName.Namespace,
+					Name:      w.WebhookService,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: cert,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
+
+// serveAllVersions serves the versions not served of crd, returns true when any version changed.
+// the versions that not storage are converted by the conversion webhook, they should be served only after the conversion configured.
+func serveAllVersions(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	changed := false
+	for i := range crd.Spec.Versions {
+		if !crd.Spec.Versions[i].Served {
+			crd.Spec.Versions[i].Served = true
+			changed = true
+		}
+	}
+
+	return changed
+}
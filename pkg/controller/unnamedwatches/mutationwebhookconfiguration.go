@@ -98,7 +98,7 @@ func (w *WatchMutationWebhookConfiguration) Reconcile(ctx context.Context) error
 		return err
 	}
 
-	cert := secret.Data[certificate.TLsCertName]
+	cert := certificate.GetCABundle(secret)
 	for i, _ := range mutationWebhook.Webhooks {
 		mutationWebhook.Webhooks[i].ClientConfig.CABundle = cert
 	}
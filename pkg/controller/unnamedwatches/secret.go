@@ -18,13 +18,18 @@
 package unnamedwatches
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509/pkix"
 	"fmt"
 	"github.com/apache/doris-operator/pkg/common/utils/certificate"
+	"github.com/apache/doris-operator/pkg/common/utils/k8s"
+	"github.com/apache/doris-operator/pkg/common/utils/resource"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
@@ -45,6 +50,8 @@ var _ watch = &WatchSecret{}
 // define secret should be watched by operator.
 type WatchSecret struct {
 	client kubernetes.Interface
+	// dynamic client for applying the cert-manager `Certificate`.
+	dynamicClient dynamic.Interface
 	//the watch controller name.
 	Name                               string
 	NamespaceName                      types.NamespacedName
@@ -52,6 +59,8 @@ type WatchSecret struct {
 	WebhookService                     string
 	MutatingWebhookConfigurationName   string
 	ValidatingWebhookConfigurationName string
+	// the cert-manager issuer that issue the certificate of webhook, the certificate is self-signed when nil.
+	Issuer *resource.CertManagerIssuer
 }
 
 // return the watch resource name.
@@ -97,23 +106,21 @@ func (w *WatchSecret) toReconcileRequest(object metav1.Object) []reconcile.Reque
 }
 
 func (w *WatchSecret) Reconcile(ctx context.Context) error {
+	if w.Issuer != nil {
+		return w.reconcileCertManagerCertificate(ctx)
+	}
+
 	secret, err := w.client.CoreV1().Secrets(w.NamespaceName.Namespace).Get(ctx, w.NamespaceName.Name, metav1.GetOptions{})
 	if err != nil {
 		klog.Errorf("watchSecret reconcile failed to get secret, error=%s", err.Error())
 		return err
 	}
 
-	ca := certificate.BuildCAFromSecret(secret)
-	if ca != nil && certificate.ValidCA(ca) {
+	if !w.shouldRenewCertificate(secret) {
 		return nil
 	}
 
-	dnsNames := []string{
-		fmt.Sprintf("%s.%s", w.WebhookService, w.NamespaceName.Namespace),
-		fmt.Sprintf("%s.%s.svc", w.WebhookService, w.NamespaceName.Namespace),
-		fmt.Sprintf("%s.%s.svc.cluster.local", w.WebhookService, w.NamespaceName.Namespace),
-		testDNSName,
-	}
+	dnsNames := w.getDnsNames()
 
 	// build new ca.
 	cp := certificate.CAOptions{
@@ -123,7 +130,7 @@ func (w *WatchSecret) Reconcile(ctx context.Context) error {
 		},
 		DnsNames: dnsNames,
 	}
-	ca, err = certificate.NewCAConfigSecret(cp)
+	ca, err := certificate.NewCAConfigSecret(cp)
 	if err != nil {
 		klog.Errorf("watchSecret reconcile failed to newCa, error=%s.", err)
 		return err
@@ -138,13 +145,72 @@ func (w *WatchSecret) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if err := w.updateWebhookCABundle(ctx, ca.GetEncodeCert()); err != nil {
+		return err
+	}
+	w.updateOperatorPods(ctx, w.client, w.NamespaceName.Namespace)
+	return nil
+}
+
+// reconcileCertManagerCertificate request the certificate of webhook from cert-manager, wait for the secret issued and update the ca bundle of webhook configurations.
+func (w *WatchSecret) reconcileCertManagerCertificate(ctx context.Context) error {
+	cert := resource.BuildCertManagerCertificate(resource.CertManagerCertificateOptions{
+		Name:        w.NamespaceName.Name,
+		Namespace:   w.NamespaceName.Namespace,
+		CommonName:  w.GetName() + "-" + certificateType,
+		DnsNames:    w.getDnsNames(),
+		Duration:    resource.DefaultTLSCertificateValidity,
+		RenewBefore: resource.DefaultTLSRenewBefore,
+		Issuer:      *w.Issuer,
+	})
+	if _, err := w.dynamicClient.Resource(resource.CertManagerCertificateGVR).Namespace(w.NamespaceName.Namespace).Apply(ctx, cert.GetName(), cert,
+		metav1.ApplyOptions{FieldManager: k8s.FieldManager, Force: true}); err != nil {
+		klog.Errorf("watchSecret reconcile apply certificate name=%s, namespace=%s failed, err=%s", cert.GetName(), cert.GetNamespace(), err.Error())
+		return err
+	}
+
+	secret, err := w.client.CoreV1().Secrets(w.NamespaceName.Namespace).Get(ctx, w.NamespaceName.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.Infof("watchSecret reconcile the secret name=%s of webhook not issued by cert-manager, wait for it.", w.NamespaceName.Name)
+		return nil
+	} else if err != nil {
+		klog.Errorf("watchSecret reconcile failed to get secret, error=%s", err.Error())
+		return err
+	}
+
+	caBundle := certificate.GetCABundle(secret)
+	if len(caBundle) == 0 || len(secret.Data[certificate.TlsKeyName]) == 0 {
+		klog.Infof("watchSecret reconcile the certificate of webhook not issued by cert-manager, wait for it.")
+		return nil
+	}
+
+	mutatingWebhook, err := w.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, w.MutatingWebhookConfigurationName, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("watchSecret reconcile get mutatingwebhookconfiguration name=%s failed, err=%s.", w.MutatingWebhookConfigurationName, err.Error())
+		return err
+	}
+	// the ca bundle is not changed, the certificate is not issued or rotated by cert-manager.
+	if len(mutatingWebhook.Webhooks) != 0 && bytes.Equal(mutatingWebhook.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		return nil
+	}
+
+	if err := w.updateWebhookCABundle(ctx, caBundle); err != nil {
+		return err
+	}
+	// restart operator pods for loading the certificate.
+	w.updateOperatorPods(ctx, w.client, w.NamespaceName.Namespace)
+	return nil
+}
+
+// updateWebhookCABundle set the ca bundle of mutating and validating webhook configurations.
+func (w *WatchSecret) updateWebhookCABundle(ctx context.Context, caBundle []byte) error {
 	mutatingWebhook, err := w.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, w.MutatingWebhookConfigurationName, metav1.GetOptions{})
 	if err != nil {
 		klog.Errorf("watchSecret reconcile get mutatingwebhookconfiguration name=%s failed, err=%s.", w.MutatingWebhookConfigurationName, err.Error())
 		return err
 	}
 	for i, _ := range mutatingWebhook.Webhooks {
-		mutatingWebhook.Webhooks[i].ClientConfig.CABundle = ca.GetEncodeCert()
+		mutatingWebhook.Webhooks[i].ClientConfig.CABundle = caBundle
 	}
 
 	if _, err := w.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, mutatingWebhook, metav1.UpdateOptions{}); err != nil {
@@ -159,17 +225,25 @@ func (w *WatchSecret) Reconcile(ctx context.Context) error {
 	}
 
 	for i, _ := range validatingWebhook.Webhooks {
-		validatingWebhook.Webhooks[i].ClientConfig.CABundle = ca.GetEncodeCert()
+		validatingWebhook.Webhooks[i].ClientConfig.CABundle = caBundle
 	}
 
 	if _, err := w.client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx, validatingWebhook, metav1.UpdateOptions{}); err != nil {
 		klog.Errorf("watchSecret reconcile update validatingwebhookconfiguration name=%s failed, err=%s.", w.ValidatingWebhookConfigurationName, err.Error())
 		return err
 	}
-	w.updateOperatorPods(ctx, w.client, w.NamespaceName.Namespace)
 	return nil
 }
 
+func (w *WatchSecret) getDnsNames() []string {
+	return []string{
+		fmt.Sprintf("%s.%s", w.WebhookService, w.NamespaceName.Namespace),
+		fmt.Sprintf("%s.%s.svc", w.WebhookService, w.NamespaceName.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", w.WebhookService, w.NamespaceName.Namespace),
+		testDNSName,
+	}
+}
+
 func (w *WatchSecret) updateOperatorPods(ctx context.Context, client kubernetes.Interface, operatorNamespace string) {
 	labels := metav1.ListOptions{
 		LabelSelector: OperatorPodSelector,
@@ -228,7 +302,7 @@ func (w *WatchSecret) shouldRenewCertificate(secret *corev1.Secret) bool {
 		return true
 	}
 
-	return certificate.ValidCA(ca)
+	return !certificate.ValidCA(ca)
 }
 
 var _ watch = &WatchSecret{}
@@ -99,7 +99,7 @@ func (w *WatchValidatingWebhookConfiguration) Reconcile(ctx context.Context) err
 		return err
 	}
 
-	cert := secret.Data[certificate.TLsCertName]
+	cert := certificate.GetCABundle(secret)
 	for i, _ := range validatingWebhook.Webhooks {
 		validatingWebhook.Webhooks[i].ClientConfig.CABundle = cert
 	}